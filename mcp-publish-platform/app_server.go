@@ -36,8 +36,10 @@ func NewAppServer(xiaohongshuService *XiaohongshuService) *AppServer {
 	return appServer
 }
 
-func NewMultiPlatformAppServer(multiPlatformService *MultiPlatformService) *AppServer {
+// NewMultiPlatformAppServer 多平台模式同样持有小红书服务，供收件箱、监控等小红书专属工具使用
+func NewMultiPlatformAppServer(multiPlatformService *MultiPlatformService, xiaohongshuService *XiaohongshuService) *AppServer {
 	appServer := &AppServer{
+		xiaohongshuService:   xiaohongshuService,
 		multiPlatformService: multiPlatformService,
 	}
 
@@ -149,13 +151,36 @@ func setupXiaohongshuRoutes(r *gin.Engine, s *AppServer) {
 		api.POST("/feeds/search", s.searchFeedsHandler)
 		api.GET("/feeds/search", s.searchFeedsHandler)
 		api.POST("/feeds/detail", s.getFeedDetailHandler)
+		api.GET("/feeds/comments", s.listFeedCommentsHandler)
+		api.POST("/feeds/comments", s.listFeedCommentsHandler)
+		api.POST("/feeds/comments/export", s.exportFeedCommentsHandler)
+		api.POST("/feeds/media/download", s.downloadFeedMediaHandler)
 
 		api.POST("/user/profile", s.userProfileHandler)
 		api.GET("/user/me", s.myProfileHandler)
+		api.GET("/user/me/notes", s.myNotesHandler)
+		api.GET("/user/me/following", s.listFollowingHandler)
+		api.GET("/user/me/followers", s.listFollowersHandler)
+		api.POST("/user/follow", s.followUserHandler)
+		api.POST("/user/unfollow", s.unfollowUserHandler)
+
+		api.GET("/topics/search", s.searchTopicsHandler)
 
 		api.POST("/comment", s.postCommentHandler)
 		api.POST("/comment/reply", s.replyCommentHandler)
 
+		api.POST("/inbox/sync", s.syncInboxHandler)
+		api.GET("/inbox", s.listInboxHandler)
+		api.POST("/inbox/read", s.markInboxReadHandler)
+		api.POST("/inbox/:comment_id/reply", s.replyInboxHandler)
+
+		api.GET("/monitor/watches", s.listWatchesHandler)
+		api.POST("/monitor/watches", s.addWatchHandler)
+		api.PUT("/monitor/watches/:id", s.updateWatchHandler)
+		api.DELETE("/monitor/watches/:id", s.deleteWatchHandler)
+		api.POST("/monitor/watches/:id/check", s.checkWatchHandler)
+		api.GET("/monitor/alerts", s.listMonitorAlertsHandler)
+
 		api.GET("/health", healthHandler)
 	}
 }
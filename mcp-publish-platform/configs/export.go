@@ -0,0 +1,15 @@
+package configs
+
+import "os"
+
+const (
+	defaultExportDir = "exports"
+)
+
+// GetExportDir 评论导出目录，可通过 EXPORT_DIR 环境变量覆盖
+func GetExportDir() string {
+	if dir := os.Getenv("EXPORT_DIR"); dir != "" {
+		return dir
+	}
+	return defaultExportDir
+}
@@ -0,0 +1,15 @@
+package configs
+
+import "os"
+
+const (
+	defaultInboxPath = "inbox.json"
+)
+
+// GetInboxPath 评论收件箱文件路径，可通过 INBOX_PATH 环境变量覆盖
+func GetInboxPath() string {
+	if path := os.Getenv("INBOX_PATH"); path != "" {
+		return path
+	}
+	return defaultInboxPath
+}
@@ -0,0 +1,15 @@
+package configs
+
+import "os"
+
+const (
+	defaultMediaDir = "downloads"
+)
+
+// GetMediaDir 笔记媒体下载目录，可通过 MEDIA_DIR 环境变量覆盖
+func GetMediaDir() string {
+	if dir := os.Getenv("MEDIA_DIR"); dir != "" {
+		return dir
+	}
+	return defaultMediaDir
+}
@@ -0,0 +1,20 @@
+package configs
+
+import "os"
+
+const (
+	defaultMonitorPath = "monitor.json"
+)
+
+// GetMonitorPath 监控数据文件路径，可通过 MONITOR_PATH 环境变量覆盖
+func GetMonitorPath() string {
+	if path := os.Getenv("MONITOR_PATH"); path != "" {
+		return path
+	}
+	return defaultMonitorPath
+}
+
+// GetMonitorWebhook 监控告警的全局 webhook，通过 MONITOR_WEBHOOK_URL 环境变量配置
+func GetMonitorWebhook() string {
+	return os.Getenv("MONITOR_WEBHOOK_URL")
+}
@@ -0,0 +1,9 @@
+package configs
+
+import "os"
+
+// GetContentImageDir 富文本正文中相对路径图片的根目录，通过 CONTENT_IMAGE_DIR 环境变量配置
+// 未配置时正文图片必须使用绝对路径
+func GetContentImageDir() string {
+	return os.Getenv("CONTENT_IMAGE_DIR")
+}
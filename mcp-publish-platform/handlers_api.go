@@ -1,9 +1,15 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/inbox"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/monitor"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 
 	"github.com/gin-gonic/gin"
@@ -109,6 +115,18 @@ func (s *AppServer) publishVideoHandler(c *gin.Context) {
 		return
 	}
 
+	if wantsEventStream(c) {
+		streamPublish(c, func(progress platform.ProgressFunc) (any, error) {
+			req.Progress = progress
+			result, err := s.xiaohongshuService.PublishVideo(c.Request.Context(), &req)
+			if err != nil {
+				return nil, err
+			}
+			return SuccessResponse{Success: true, Data: result, Message: "视频发布成功"}, nil
+		})
+		return
+	}
+
 	// 执行视频发布
 	result, err := s.xiaohongshuService.PublishVideo(c.Request.Context(), &req)
 	if err != nil {
@@ -134,34 +152,59 @@ func (s *AppServer) listFeedsHandler(c *gin.Context) {
 	respondSuccess(c, result, "获取Feeds列表成功")
 }
 
+// myNotesHandler 获取当前账号的笔记列表
+func (s *AppServer) myNotesHandler(c *gin.Context) {
+	var req MyNotesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListMyNotes(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_MY_NOTES_FAILED",
+			"获取我的笔记失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取我的笔记成功")
+}
+
 // searchFeedsHandler 搜索Feeds
 func (s *AppServer) searchFeedsHandler(c *gin.Context) {
-	var keyword string
-	var filters xiaohongshu.FilterOption
+	var searchReq SearchFeedsRequest
 
 	switch c.Request.Method {
 	case http.MethodPost:
 		// 对于POST请求，从JSON中获取keyword
-		var searchReq SearchFeedsRequest
 		if err := c.ShouldBindJSON(&searchReq); err != nil {
 			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
 				"请求参数错误", err.Error())
 			return
 		}
-		keyword = searchReq.Keyword
-		filters = searchReq.Filters
 	default:
-		keyword = c.Query("keyword")
+		searchReq.Keyword = c.Query("keyword")
+		searchReq.Cursor = c.Query("cursor")
+		searchReq.MaxResults, _ = strconv.Atoi(c.Query("max_results"))
+		searchReq.Page, _ = strconv.Atoi(c.Query("page"))
 	}
 
-	if keyword == "" {
+	if searchReq.Keyword == "" {
 		respondError(c, http.StatusBadRequest, "MISSING_KEYWORD",
 			"缺少关键词参数", "keyword parameter is required")
 		return
 	}
 
 	// 搜索 Feeds
-	result, err := s.xiaohongshuService.SearchFeeds(c.Request.Context(), keyword, filters)
+	var result any
+	var err error
+	if searchReq.deep() {
+		result, err = s.xiaohongshuService.DeepSearchFeeds(c.Request.Context(), &searchReq)
+	} else {
+		result, err = s.xiaohongshuService.SearchFeeds(c.Request.Context(), searchReq.Keyword, searchReq.Filters)
+	}
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "SEARCH_FEEDS_FAILED",
 			"搜索Feeds失败", err.Error())
@@ -208,6 +251,166 @@ func (s *AppServer) getFeedDetailHandler(c *gin.Context) {
 	respondSuccess(c, result, "获取Feed详情成功")
 }
 
+// listFeedCommentsHandler 按游标分页获取评论
+func (s *AppServer) listFeedCommentsHandler(c *gin.Context) {
+	var req FeedCommentsRequest
+	if err := c.ShouldBind(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListFeedComments(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_FEED_COMMENTS_FAILED",
+			"获取评论失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取评论成功")
+}
+
+// exportFeedCommentsHandler 导出评论到文件，可断点续传
+func (s *AppServer) exportFeedCommentsHandler(c *gin.Context) {
+	var req CommentExportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ExportFeedComments(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "EXPORT_FEED_COMMENTS_FAILED",
+			"导出评论失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "导出评论成功")
+}
+
+// downloadFeedMediaHandler 下载笔记的图片、实况图和视频
+func (s *AppServer) downloadFeedMediaHandler(c *gin.Context) {
+	var req MediaDownloadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.DownloadFeedMedia(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "DOWNLOAD_FEED_MEDIA_FAILED",
+			"下载笔记媒体失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "下载笔记媒体成功")
+}
+
+// searchTopicsHandler 查询话题联想候选
+func (s *AppServer) searchTopicsHandler(c *gin.Context) {
+	var req TopicSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.SearchTopics(c.Request.Context(), req.Keyword)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "SEARCH_TOPICS_FAILED",
+			"查询话题失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "查询话题成功")
+}
+
+// followUserHandler 关注用户
+func (s *AppServer) followUserHandler(c *gin.Context) {
+	var req FollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.FollowUser(c.Request.Context(), req.UserID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "FOLLOW_USER_FAILED",
+			"关注用户失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// unfollowUserHandler 取消关注用户
+func (s *AppServer) unfollowUserHandler(c *gin.Context) {
+	var req FollowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.UnfollowUser(c.Request.Context(), req.UserID, req.XsecToken)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "UNFOLLOW_USER_FAILED",
+			"取消关注用户失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// listFollowingHandler 我关注的人
+func (s *AppServer) listFollowingHandler(c *gin.Context) {
+	var req FollowListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListFollowing(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_FOLLOWING_FAILED",
+			"获取关注列表失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取关注列表成功")
+}
+
+// listFollowersHandler 我的粉丝
+func (s *AppServer) listFollowersHandler(c *gin.Context) {
+	var req FollowListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListFollowers(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_FOLLOWERS_FAILED",
+			"获取粉丝列表失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "获取粉丝列表成功")
+}
+
 // userProfileHandler 用户主页
 func (s *AppServer) userProfileHandler(c *gin.Context) {
 	var req UserProfileRequest
@@ -239,7 +442,7 @@ func (s *AppServer) postCommentHandler(c *gin.Context) {
 	}
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.Content)
+	result, err := s.xiaohongshuService.PostCommentToFeed(c.Request.Context(), req.FeedID, req.XsecToken, req.Content, req.Mentions)
 	if err != nil {
 		respondError(c, http.StatusInternalServerError, "POST_COMMENT_FAILED",
 			"发表评论失败", err.Error())
@@ -270,6 +473,98 @@ func (s *AppServer) replyCommentHandler(c *gin.Context) {
 	respondSuccess(c, result, result.Message)
 }
 
+// syncInboxHandler 同步评论收件箱
+func (s *AppServer) syncInboxHandler(c *gin.Context) {
+	var req InboxSyncRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", err.Error())
+			return
+		}
+	}
+
+	result, err := s.xiaohongshuService.SyncInbox(c.Request.Context(), &req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "SYNC_INBOX_FAILED",
+			"同步评论收件箱失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, fmt.Sprintf("同步完成，新增评论 %d 条", result.NewCount))
+}
+
+// listInboxHandler 查询评论收件箱
+func (s *AppServer) listInboxHandler(c *gin.Context) {
+	var req InboxListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListInbox(&req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_INBOX_FAILED",
+			"查询评论收件箱失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "查询评论收件箱成功")
+}
+
+// replyInboxHandler 回复收件箱中的评论
+func (s *AppServer) replyInboxHandler(c *gin.Context) {
+	var req InboxReplyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ReplyInboxComment(c.Request.Context(), c.Param("comment_id"), req.Content)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, inbox.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(c, status, "REPLY_INBOX_FAILED",
+			"回复评论失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, result.Message)
+}
+
+// markInboxReadHandler 标记收件箱评论已读
+func (s *AppServer) markInboxReadHandler(c *gin.Context) {
+	var req InboxMarkReadRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+				"请求参数错误", err.Error())
+			return
+		}
+	}
+
+	changed, err := s.xiaohongshuService.MarkInboxRead(req.CommentIDs)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, inbox.ErrNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(c, status, "MARK_INBOX_READ_FAILED",
+			"标记已读失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, map[string]any{"marked": changed}, "标记已读成功")
+}
+
 // healthHandler 健康检查
 func healthHandler(c *gin.Context) {
 	respondSuccess(c, map[string]any{
@@ -293,3 +588,108 @@ func (s *AppServer) myProfileHandler(c *gin.Context) {
 	c.Set("account", "ai-report")
 	respondSuccess(c, map[string]any{"data": result}, "获取我的主页成功")
 }
+
+// addWatchHandler 新增监控项
+func (s *AppServer) addWatchHandler(c *gin.Context) {
+	var req WatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.AddWatch(&req)
+	if err != nil {
+		respondError(c, http.StatusBadRequest, "ADD_WATCH_FAILED",
+			"新增监控项失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "新增监控项成功")
+}
+
+// listWatchesHandler 列出监控项
+func (s *AppServer) listWatchesHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.ListWatches()
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_WATCHES_FAILED",
+			"查询监控项失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "查询监控项成功")
+}
+
+// updateWatchHandler 修改监控项
+func (s *AppServer) updateWatchHandler(c *gin.Context) {
+	var req WatchUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.UpdateWatch(c.Param("id"), &req)
+	if err != nil {
+		respondWatchError(c, "UPDATE_WATCH_FAILED", "修改监控项失败", err)
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "修改监控项成功")
+}
+
+// deleteWatchHandler 删除监控项
+func (s *AppServer) deleteWatchHandler(c *gin.Context) {
+	id := c.Param("id")
+	if err := s.xiaohongshuService.DeleteWatch(id); err != nil {
+		respondWatchError(c, "DELETE_WATCH_FAILED", "删除监控项失败", err)
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, map[string]any{"id": id}, "删除监控项成功")
+}
+
+// checkWatchHandler 立即执行一次监控检查
+func (s *AppServer) checkWatchHandler(c *gin.Context) {
+	result, err := s.xiaohongshuService.CheckWatch(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		respondWatchError(c, "CHECK_WATCH_FAILED", "监控检查失败", err)
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "监控检查完成")
+}
+
+// listMonitorAlertsHandler 查询监控告警
+func (s *AppServer) listMonitorAlertsHandler(c *gin.Context) {
+	var req AlertListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		respondError(c, http.StatusBadRequest, "INVALID_REQUEST",
+			"请求参数错误", err.Error())
+		return
+	}
+
+	result, err := s.xiaohongshuService.ListMonitorAlerts(&req)
+	if err != nil {
+		respondError(c, http.StatusInternalServerError, "LIST_ALERTS_FAILED",
+			"查询监控告警失败", err.Error())
+		return
+	}
+
+	c.Set("account", "ai-report")
+	respondSuccess(c, result, "查询监控告警成功")
+}
+
+// respondWatchError 监控项不存在时返回 404
+func respondWatchError(c *gin.Context, code, message string, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, monitor.ErrNotFound) {
+		status = http.StatusNotFound
+	}
+	respondError(c, status, code, message, err.Error())
+}
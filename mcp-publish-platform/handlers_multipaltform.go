@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
@@ -169,13 +170,7 @@ func HandlePlatformPublish(s *MultiPlatformService, getBrowserPage func() (*rod.
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"success":  resp.Success,
-			"message":  resp.Message,
-			"feed_id":  resp.FeedID,
-			"feed_url": resp.FeedURL,
-			"platform": string(platformID),
-		})
+		c.JSON(http.StatusOK, publishResultBody(platformID, resp))
 	}
 }
 
@@ -207,6 +202,18 @@ func HandlePlatformPublishVideo(s *MultiPlatformService, getBrowserPage func() (
 		ctx, cancel := context.WithTimeout(c.Request.Context(), 10*time.Minute)
 		defer cancel()
 
+		if wantsEventStream(c) {
+			streamPublish(c, func(progress platform.ProgressFunc) (any, error) {
+				req.Progress = progress
+				resp, err := s.PublishVideo(ctx, platformID, page, &req)
+				if err != nil {
+					return nil, err
+				}
+				return publishResultBody(platformID, resp), nil
+			})
+			return
+		}
+
 		resp, err := s.PublishVideo(ctx, platformID, page, &req)
 		if err != nil {
 			logrus.Errorf("视频发布失败: %v", err)
@@ -217,11 +224,97 @@ func HandlePlatformPublishVideo(s *MultiPlatformService, getBrowserPage func() (
 			return
 		}
 
+		c.JSON(http.StatusOK, publishResultBody(platformID, resp))
+	}
+}
+
+// publishResultBody 发布接口的响应体，SSE 的 result 事件使用相同结构
+func publishResultBody(platformID platform.PlatformID, resp *platform.PublishResponse) gin.H {
+	return gin.H{
+		"success":  resp.Success,
+		"status":   resp.Status,
+		"message":  resp.Message,
+		"feed_id":  resp.FeedID,
+		"feed_url": resp.FeedURL,
+		"draft_id": resp.DraftID,
+		"platform": string(platformID),
+	}
+}
+
+func HandlePlatformListDrafts(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+
+		logrus.Infof("收到获取草稿列表请求: platform=%s", platformID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		drafts, err := s.ListDrafts(ctx, platformID, page)
+		if err != nil {
+			logrus.Errorf("获取草稿列表失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"data":     drafts,
+			"platform": string(platformID),
+		})
+	}
+}
+
+func HandlePlatformPublishDraft(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		draftID := c.Param("draft_id")
+
+		logrus.Infof("收到发布草稿请求: platform=%s, draft_id=%s", platformID, draftID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+		defer cancel()
+
+		resp, err := s.PublishDraft(ctx, platformID, page, draftID)
+		if err != nil {
+			logrus.Errorf("发布草稿失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
 		c.JSON(http.StatusOK, gin.H{
 			"success":  resp.Success,
+			"status":   resp.Status,
 			"message":  resp.Message,
 			"feed_id":  resp.FeedID,
 			"feed_url": resp.FeedURL,
+			"draft_id": draftID,
 			"platform": string(platformID),
 		})
 	}
@@ -317,3 +410,386 @@ func HandlePlatformGetFeedDetail(s *MultiPlatformService, getBrowserPage func()
 		})
 	}
 }
+
+func HandlePlatformEditPost(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		feedID := c.Param("feed_id")
+
+		logrus.Infof("收到编辑内容请求: platform=%s, feed_id=%s", platformID, feedID)
+
+		var req platform.EditPostRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "请求参数错误: " + err.Error(),
+			})
+			return
+		}
+		if req.IsEmpty() {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "请求参数错误: title、content、tags 至少填写一项",
+			})
+			return
+		}
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 5*time.Minute)
+		defer cancel()
+
+		if err := s.EditPost(ctx, platformID, page, feedID, &req); err != nil {
+			logrus.Errorf("编辑内容失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"message":  "编辑成功",
+			"feed_id":  feedID,
+			"platform": string(platformID),
+		})
+	}
+}
+
+// HandlePlatformDeletePost 删除内容
+// 未携带 confirm_token 时只签发令牌，不执行删除；携带有效令牌再次请求才会真正删除
+func HandlePlatformDeletePost(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		feedID := c.Param("feed_id")
+
+		var req platform.DeletePostRequest
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "请求参数错误: " + err.Error(),
+			})
+			return
+		}
+		if req.ConfirmToken == "" && c.Request.ContentLength > 0 {
+			if err := c.ShouldBindJSON(&req); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"success": false,
+					"error":   "请求参数错误: " + err.Error(),
+				})
+				return
+			}
+		}
+
+		if req.ConfirmToken == "" {
+			confirm, err := s.RequestDeleteConfirmation(platformID, feedID)
+			if err != nil {
+				c.JSON(http.StatusOK, gin.H{
+					"success": false,
+					"error":   err.Error(),
+				})
+				return
+			}
+
+			logrus.Infof("签发删除确认令牌: platform=%s, feed_id=%s", platformID, feedID)
+			c.JSON(http.StatusAccepted, gin.H{
+				"success":          false,
+				"confirm_required": true,
+				"message":          "删除不可恢复，请携带 confirm_token 再次请求以确认删除",
+				"data":             confirm,
+				"platform":         string(platformID),
+			})
+			return
+		}
+
+		logrus.Infof("收到删除内容请求: platform=%s, feed_id=%s", platformID, feedID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Minute)
+		defer cancel()
+
+		if err := s.DeletePost(ctx, platformID, page, feedID, req.ConfirmToken); err != nil {
+			logrus.Errorf("删除内容失败: %v", err)
+			status := http.StatusOK
+			if errors.Is(err, platform.ErrConfirmInvalid) {
+				status = http.StatusForbidden
+			}
+			c.JSON(status, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"message":  "删除成功",
+			"feed_id":  feedID,
+			"platform": string(platformID),
+		})
+	}
+}
+
+// PlatformCommentRequest 评论请求
+type PlatformCommentRequest struct {
+	Content  string   `json:"content" binding:"required"`
+	Mentions []string `json:"mentions,omitempty"` // 评论中 @提及的用户昵称
+}
+
+func HandlePlatformLike(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return handlePlatformInteraction(s, getBrowserPage, "点赞", s.Like)
+}
+
+func HandlePlatformCollect(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return handlePlatformInteraction(s, getBrowserPage, "收藏", s.Collect)
+}
+
+func HandlePlatformComment(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req PlatformCommentRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "请求参数错误: " + err.Error(),
+			})
+			return
+		}
+
+		comment := func(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string) error {
+			return s.Comment(ctx, platformID, page, feedID, req.Content, req.Mentions)
+		}
+		handlePlatformInteraction(s, getBrowserPage, "评论", comment)(c)
+	}
+}
+
+// handlePlatformInteraction 点赞、收藏、评论等互动操作的通用处理
+func handlePlatformInteraction(
+	s *MultiPlatformService,
+	getBrowserPage func() (*rod.Page, error),
+	action string,
+	do func(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string) error,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		feedID := c.Param("feed_id")
+
+		logrus.Infof("收到%s请求: platform=%s, feed_id=%s", action, platformID, feedID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		if err := do(ctx, platformID, page, feedID); err != nil {
+			logrus.Errorf("%s失败: %v", action, err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"message":  action + "成功",
+			"feed_id":  feedID,
+			"platform": string(platformID),
+		})
+	}
+}
+
+func HandlePlatformListConversations(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+
+		logrus.Infof("收到获取私信会话请求: platform=%s", platformID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		conversations, err := s.ListConversations(ctx, platformID, page)
+		if err != nil {
+			logrus.Errorf("获取私信会话失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"data":     conversations,
+			"platform": string(platformID),
+		})
+	}
+}
+
+func HandlePlatformGetMessages(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		conversationID := c.Param("conversation_id")
+
+		logrus.Infof("收到获取私信消息请求: platform=%s, conversation_id=%s", platformID, conversationID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		messages, err := s.GetMessages(ctx, platformID, page, conversationID)
+		if err != nil {
+			logrus.Errorf("获取私信消息失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":         true,
+			"data":            messages,
+			"conversation_id": conversationID,
+			"platform":        string(platformID),
+		})
+	}
+}
+
+func HandlePlatformSendMessage(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		conversationID := c.Param("conversation_id")
+
+		var req platform.SendMessageRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "请求参数错误: " + err.Error(),
+			})
+			return
+		}
+
+		logrus.Infof("收到发送私信请求: platform=%s, conversation_id=%s", platformID, conversationID)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		if err := s.SendMessage(ctx, platformID, page, conversationID, req.Content); err != nil {
+			logrus.Errorf("发送私信失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":         true,
+			"message":         "私信发送成功",
+			"conversation_id": conversationID,
+			"platform":        string(platformID),
+		})
+	}
+}
+
+func HandlePlatformSearchTopics(s *MultiPlatformService, getBrowserPage func() (*rod.Page, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		platformID := platform.PlatformID(c.Param("platform"))
+		keyword := c.Query("keyword")
+
+		if keyword == "" {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"success": false,
+				"error":   "缺少keyword参数",
+			})
+			return
+		}
+
+		logrus.Infof("收到查询话题请求: platform=%s, keyword=%s", platformID, keyword)
+
+		page, err := getBrowserPage()
+		if err != nil {
+			logrus.Errorf("获取浏览器页面失败: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"error":   "获取浏览器页面失败: " + err.Error(),
+			})
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), 1*time.Minute)
+		defer cancel()
+
+		topics, err := s.SearchTopics(ctx, platformID, page, keyword)
+		if err != nil {
+			logrus.Errorf("查询话题失败: %v", err)
+			c.JSON(http.StatusOK, gin.H{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"success":  true,
+			"data":     topics,
+			"keyword":  keyword,
+			"platform": string(platformID),
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// wantsEventStream 客户端通过 Accept: text/event-stream 请求以 SSE 接收发布进度
+func wantsEventStream(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+}
+
+// streamPublish 以 SSE 执行发布：上传和转码进度作为 progress 事件推送，
+// 结束时推送 result 事件（与普通请求的响应体相同）或 error 事件
+func streamPublish(c *gin.Context, publish func(progress platform.ProgressFunc) (any, error)) {
+	type outcome struct {
+		body any
+		err  error
+	}
+
+	events := make(chan platform.UploadProgress, 16)
+	done := make(chan outcome, 1)
+	go func() {
+		body, err := publish(func(p platform.UploadProgress) {
+			// 客户端读取较慢时丢弃最旧的进度，不阻塞发布流程，且最新的进度（包括 done）总能送达
+			for {
+				select {
+				case events <- p:
+					return
+				default:
+				}
+				select {
+				case <-events:
+				default:
+				}
+			}
+		})
+		done <- outcome{body: body, err: err}
+	}()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case p := <-events:
+			c.SSEvent("progress", p)
+			return true
+		case o := <-done:
+			for len(events) > 0 {
+				c.SSEvent("progress", <-events)
+			}
+			if o.err != nil {
+				logrus.Errorf("%s %s 发布失败: %v", c.Request.Method, c.Request.URL.Path, o.err)
+				c.SSEvent("error", gin.H{"success": false, "error": o.err.Error()})
+			} else {
+				c.SSEvent("result", o.body)
+			}
+			return false
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
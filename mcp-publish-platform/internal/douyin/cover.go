@@ -0,0 +1,12 @@
+package douyin
+
+import "github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+
+// douyinCoverDialog "选择封面"弹窗
+var douyinCoverDialog = platform.CoverDialog{
+	EntrySelector:  `[class*="cover"]`,
+	EntryPattern:   "选择封面|设置封面|编辑封面",
+	ModalSelector:  `[class*="semi-modal-content"], [class*="modal"], [role="dialog"]`,
+	UploadTab:      "^上传封面$",
+	ConfirmPattern: "^完成$|^确定$",
+}
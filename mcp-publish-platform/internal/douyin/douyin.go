@@ -30,13 +30,17 @@ func New() *DouyinPlatform {
 			MaxVideoSize: 2048,
 			SupportedTypes: []string{"image_text", "video"},
 			Features: platform.PlatformFeatures{
-				SupportImageText: true,
-				SupportVideo:     true,
-				SupportSchedule:  true,
-				SupportTags:      true,
-				SupportComment:   true,
-				SupportLike:      true,
-				SupportCollect:   false,
+				SupportImageText:   true,
+				SupportVideo:       true,
+				SupportSchedule:    true,
+				SupportTags:        true,
+				SupportComment:     true,
+				SupportLike:        true,
+				SupportCollect:     false,
+				SupportDraft:       true,
+				SupportMessages:    true,
+				SupportTopicSearch: true,
+				SupportRichText:    false,
 			},
 		},
 	}
@@ -269,7 +273,7 @@ func (d *DouyinPlatform) Like(ctx context.Context, page *rod.Page, feedID string
 	return errors.New("未找到点赞按钮")
 }
 
-func (d *DouyinPlatform) Comment(ctx context.Context, page *rod.Page, feedID string, content string) error {
+func (d *DouyinPlatform) Comment(ctx context.Context, page *rod.Page, feedID string, content string, mentions []string) error {
 	logrus.Infof("抖音评论: %s", feedID)
 	
 	pp := page.Context(ctx)
@@ -308,6 +312,10 @@ func (d *DouyinPlatform) Comment(ctx context.Context, page *rod.Page, feedID str
 		return errors.Wrap(err, "输入评论失败")
 	}
 	
+	if err := inputMentionsDouyin(commentInput, mentions); err != nil {
+		return err
+	}
+	
 	time.Sleep(500 * time.Millisecond)
 	
 	submitSelectors := []string{
@@ -0,0 +1,132 @@
+package douyin
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+const douyinContentManage = "https://creator.douyin.com/creator-micro/content/manage"
+
+// douyinManagePage 内容管理页中的卡片通过这些 data 属性携带内容ID
+var douyinManagePage = platform.ManagePage{
+	URL:     douyinContentManage,
+	IDAttrs: []string{"data-id", "data-feed-id", "data-video-id", "data-item-id"},
+}
+
+func (d *DouyinPlatform) EditPost(ctx context.Context, page *rod.Page, feedID string, req *platform.EditPostRequest) error {
+	logrus.Infof("编辑抖音作品: %s", feedID)
+
+	pp := page.Timeout(300 * time.Second).Context(ctx)
+
+	card, err := douyinManagePage.OpenCard(pp, feedID)
+	if err != nil {
+		return err
+	}
+
+	if err := platform.ClickCardAction(pp, card, `^\s*(编辑作品|修改作品|编辑)\s*$`); err != nil {
+		return errors.Wrap(err, "打开作品编辑页失败")
+	}
+
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	if req.Title != "" {
+		titleElem := platform.FindFirstElement(pp, []string{
+			`input[placeholder*="标题"]`,
+			`input[placeholder*="填写标题"]`,
+			`.title-input input`,
+			`[class*="title"] input`,
+		})
+		if titleElem == nil {
+			return errors.New("查找标题输入框失败")
+		}
+		if err := titleElem.SelectAllText(); err != nil {
+			return errors.Wrap(err, "选中原标题失败")
+		}
+		if err := titleElem.Input(req.Title); err != nil {
+			return errors.Wrap(err, "输入标题失败")
+		}
+		logrus.Info("标题修改完成")
+	}
+
+	if req.Content != "" {
+		descElem := platform.FindFirstElement(pp, []string{
+			`textarea[placeholder*="描述"]`,
+			`textarea[placeholder*="简介"]`,
+			`[class*="desc"] textarea`,
+			`[contenteditable="true"]`,
+		})
+		if descElem == nil {
+			return errors.New("查找描述输入框失败")
+		}
+		if err := platform.ClearEditable(descElem); err != nil {
+			return errors.Wrap(err, "清空原描述失败")
+		}
+		if err := descElem.Input(req.Content); err != nil {
+			return errors.Wrap(err, "输入描述失败")
+		}
+		logrus.Info("描述修改完成")
+
+		if len(req.Tags) > 0 {
+			if err := inputTagsDouyin(pp, req.Tags, false); err != nil {
+				logrus.Warnf("添加标签失败: %v", err)
+			}
+		}
+	}
+
+	time.Sleep(1 * time.Second)
+
+	publishBtn, err := pp.Timeout(10*time.Second).ElementR(`button`, `^\s*(发布|保存|确认修改)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "查找发布按钮失败")
+	}
+	clickEmptyPositionDouyin(pp)
+	if err := platform.ClickSubmit(pp, publishBtn, ""); err != nil {
+		return errors.Wrap(err, "点击发布按钮失败")
+	}
+
+	if err := douyinManagePage.VerifyEdit(pp, feedID, req); err != nil {
+		return err
+	}
+	logrus.Info("抖音作品编辑完成")
+	return nil
+}
+
+func (d *DouyinPlatform) DeletePost(ctx context.Context, page *rod.Page, feedID string) error {
+	logrus.Infof("删除抖音作品: %s", feedID)
+
+	pp := page.Timeout(120 * time.Second).Context(ctx)
+
+	card, err := douyinManagePage.OpenCard(pp, feedID)
+	if err != nil {
+		return err
+	}
+
+	if err := platform.ClickCardAction(pp, card, `^\s*(删除作品|删除)\s*$`); err != nil {
+		return err
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	confirmBtn, err := pp.Timeout(10*time.Second).ElementR(
+		`[role="dialog"] button, [class*="modal"] button, [class*="popconfirm"] button`, `^\s*(确定|确认|删除)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "未找到删除确认按钮")
+	}
+	if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击删除确认按钮失败")
+	}
+
+	if err := douyinManagePage.WaitCardGone(pp, feedID, 10*time.Second); err != nil {
+		return err
+	}
+	logrus.Info("抖音作品已删除")
+	return nil
+}
@@ -0,0 +1,84 @@
+package douyin
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+var douyinMentionItemSelectors = []string{
+	`[class*="mention"] [class*="item"]`,
+	`[class*="at-user"] [class*="item"]`,
+	`[class*="user-list"] [class*="user-item"]`,
+}
+
+// inputMentionsDouyin 在输入框末尾逐个插入 @提及
+// 只有从提及列表中选中的用户才会变成可跳转的提及，匹配不到的用户清除输入后跳过
+func inputMentionsDouyin(elem *rod.Element, names []string) error {
+	for _, name := range names {
+		name = strings.TrimSpace(strings.TrimLeft(name, "@"))
+		if name == "" {
+			continue
+		}
+
+		if err := elem.Input(" @" + name); err != nil {
+			return errors.Wrapf(err, "输入提及[%s]失败", name)
+		}
+
+		// 提及列表需要请求用户搜索接口
+		time.Sleep(1500 * time.Millisecond)
+
+		items := mentionItemsDouyin(elem.Page())
+		texts := make([]string, 0, len(items))
+		for _, item := range items {
+			text, _ := item.Text()
+			texts = append(texts, text)
+		}
+
+		idx := platform.MatchMention(texts, name)
+		if idx < 0 {
+			clearMentionDouyin(elem, name)
+			logrus.Warnf("提及列表中未找到用户[%s]，已跳过", name)
+			continue
+		}
+
+		if err := items[idx].Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrapf(err, "点击提及用户[%s]失败", name)
+		}
+		logrus.Infof("成功提及用户: @%s", name)
+		time.Sleep(500 * time.Millisecond)
+	}
+	return nil
+}
+
+// mentionItemsDouyin 读取当前展开的提及列表
+func mentionItemsDouyin(page *rod.Page) []*rod.Element {
+	for _, selector := range douyinMentionItemSelectors {
+		has, _, err := page.Has(selector)
+		if err != nil || !has {
+			continue
+		}
+		items, err := page.Elements(selector)
+		if err == nil && len(items) > 0 {
+			return items
+		}
+	}
+	return nil
+}
+
+// clearMentionDouyin 用退格删除未选中的 " @昵称"
+func clearMentionDouyin(elem *rod.Element, name string) {
+	keys := make([]input.Key, 0, len([]rune(name))+2)
+	for i := 0; i < len([]rune(name))+2; i++ {
+		keys = append(keys, input.Backspace)
+	}
+	if err := elem.Type(keys...); err != nil {
+		logrus.Warnf("清除提及输入失败: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package douyin
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// douyinChatPage 创作者中心的私信管理页
+var douyinChatPage = platform.ChatPage{
+	URL:                  "https://creator.douyin.com/creator-micro/data/following/chat",
+	ConversationSelector: `[class*="conversation"] [class*="item"], [class*="session"] [class*="item"], [class*="chat-list"] [class*="item"]`,
+	MessageSelector:      `[class*="message-list"] [class*="message-item"], [class*="msg-list"] [class*="msg-item"], [class*="chat-content"] [class*="message"]`,
+	InputSelector:        `textarea, [contenteditable="true"]`,
+}
+
+func (d *DouyinPlatform) ListConversations(ctx context.Context, page *rod.Page) ([]platform.Conversation, error) {
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+
+	conversations, err := douyinChatPage.Conversations(pp)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]platform.Conversation, 0, len(conversations))
+	for _, c := range conversations {
+		items = append(items, c.Conversation)
+	}
+	return items, nil
+}
+
+func (d *DouyinPlatform) GetMessages(ctx context.Context, page *rod.Page, conversationID string) ([]platform.Message, error) {
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+
+	if _, err := douyinChatPage.Open(pp, conversationID); err != nil {
+		return nil, err
+	}
+	return douyinChatPage.Messages(pp)
+}
+
+func (d *DouyinPlatform) SendMessage(ctx context.Context, page *rod.Page, conversationID string, content string) error {
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+
+	target, err := douyinChatPage.Open(pp, conversationID)
+	if err != nil {
+		return err
+	}
+	if err := douyinChatPage.Send(pp, content); err != nil {
+		return err
+	}
+
+	logrus.Infof("已向 %s 发送抖音私信", target.Nickname)
+	return nil
+}
@@ -0,0 +1,125 @@
+package douyin
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// douyinMusicEntrySelector 只匹配文字恰好是入口名称的按钮，避免点到包含这些文字的外层容器
+	douyinMusicEntrySelector = `button, [role="button"], span`
+	douyinMusicEntryPattern  = "^(选择音乐|添加音乐|更换音乐)$"
+
+	// douyinMusicDrawerSelector 选择音乐的抽屉或弹窗，搜索框和曲目只在其中查找
+	douyinMusicDrawerSelector = `.semi-sidesheet, .semi-modal, [role="dialog"], [class*="drawer"]`
+
+	douyinMusicSearchSelectors = []string{
+		`input[placeholder*="搜索音乐"]`,
+		`input[placeholder*="音乐"]`,
+		`[class*="music"] input`,
+	}
+
+	douyinMusicItemSelectors = []string{
+		`[class*="music-item"]`,
+		`[class*="song-item"]`,
+		`[class*="music"] li`,
+	}
+)
+
+// selectMusicDouyin 在图文的"选择音乐"面板中搜索关键词，并使用第一个搜索结果作为背景音乐
+// 找不到曲目时返回错误，而不是以平台默认配乐发布
+func selectMusicDouyin(page *rod.Page, keyword string) error {
+	if keyword == "" {
+		return nil
+	}
+
+	entry, err := page.Timeout(10*time.Second).ElementR(douyinMusicEntrySelector, douyinMusicEntryPattern)
+	if err != nil {
+		return errors.Wrap(err, "未找到选择音乐入口")
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击选择音乐失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	drawer, err := openMusicDrawerDouyin(page)
+	if err != nil {
+		return err
+	}
+
+	var searchInput *rod.Element
+	for _, selector := range douyinMusicSearchSelectors {
+		has, elem, err := drawer.Has(selector)
+		if err == nil && has && elem != nil {
+			searchInput = elem
+			break
+		}
+	}
+	if searchInput == nil {
+		return errors.New("未找到音乐搜索框")
+	}
+	if err := searchInput.Input(keyword); err != nil {
+		return errors.Wrap(err, "输入音乐关键词失败")
+	}
+	if err := searchInput.Type(input.Enter); err != nil {
+		return errors.Wrap(err, "提交音乐搜索失败")
+	}
+	// 音乐搜索需要请求曲库接口
+	time.Sleep(2 * time.Second)
+
+	item := firstMusicItemDouyin(drawer)
+	if item == nil {
+		return errors.Errorf("未找到音乐: %s", keyword)
+	}
+	name, _ := item.Text()
+
+	// "使用"按钮在鼠标悬停到曲目上后才出现
+	if err := item.Hover(); err != nil {
+		return errors.Wrap(err, "悬停音乐条目失败")
+	}
+	time.Sleep(300 * time.Millisecond)
+
+	useBtn, err := item.ElementR("button, span", "^使用$")
+	if err != nil {
+		return errors.Wrap(err, "未找到使用按钮")
+	}
+	if err := useBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击使用音乐失败")
+	}
+	logrus.Infof("已选择背景音乐: %s", strings.SplitN(name, "\n", 2)[0])
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// openMusicDrawerDouyin 返回已展开的选择音乐抽屉，多个弹层同时存在时取最后一个可见的
+func openMusicDrawerDouyin(page *rod.Page) (*rod.Element, error) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if elems, err := page.Elements(douyinMusicDrawerSelector); err == nil {
+			for i := len(elems) - 1; i >= 0; i-- {
+				if visible, err := elems[i].Visible(); err == nil && visible {
+					return elems[i], nil
+				}
+			}
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return nil, errors.New("未找到选择音乐面板")
+}
+
+// firstMusicItemDouyin 返回音乐面板搜索结果中的第一首曲目，没有结果时返回 nil
+func firstMusicItemDouyin(drawer *rod.Element) *rod.Element {
+	for _, selector := range douyinMusicItemSelectors {
+		items, err := drawer.Elements(selector)
+		if err == nil && len(items) > 0 {
+			return items[0]
+		}
+	}
+	return nil
+}
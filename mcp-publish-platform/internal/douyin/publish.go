@@ -44,11 +44,16 @@ func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 }
 
 type PublishImageContent struct {
-	Title        string
-	Content      string
-	Tags         []string
-	ImagePaths   []string
-	ScheduleTime *time.Time
+	Title              string
+	Content            string
+	Tags               []string
+	ImagePaths         []string
+	ScheduleTime       *time.Time
+	Draft              bool                     // 仅保存草稿，不发布
+	ExistingTopicsOnly bool                     // 只使用能匹配到已有话题的标签
+	Mentions           []string                 // 正文末尾 @提及的用户昵称或抖音号
+	Settings           platform.PublishSettings // 位置、可见范围、合集、原创声明
+	Music              string                   // 背景音乐搜索关键词，使用第一个搜索结果
 }
 
 func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) error {
@@ -69,13 +74,18 @@ func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent
 		tags = tags[:5]
 	}
 
-	logrus.Infof("发布内容: title=%s, images=%d, tags=%v", content.Title, len(content.ImagePaths), tags)
+	logrus.Infof("发布内容: title=%s, images=%d, tags=%v, draft=%v", content.Title, len(content.ImagePaths), tags, content.Draft)
 
-	if err := submitPublishDouyin(page, content.Title, content.Content, tags); err != nil {
+	content.Tags = tags
+	if err := submitPublishDouyin(page, content); err != nil {
 		return errors.Wrap(err, "抖音发布失败")
 	}
 
-	logrus.Info("抖音图文发布成功！")
+	if content.Draft {
+		logrus.Info("抖音图文草稿保存成功！")
+		return nil
+	}
+	logrus.Info("抖音图文已提交发布")
 	return nil
 }
 
@@ -166,7 +176,7 @@ func waitForDouyinUploadComplete(page *rod.Page, expectedCount int) error {
 	return errors.Errorf("第%d张图片上传超时(60s)", expectedCount)
 }
 
-func submitPublishDouyin(page *rod.Page, title, content string, tags []string) error {
+func submitPublishDouyin(page *rod.Page, content PublishImageContent) error {
 	titleSelectors := []string{
 		`input[placeholder*="标题"]`,
 		`input[placeholder*="填写标题"]`,
@@ -187,7 +197,7 @@ func submitPublishDouyin(page *rod.Page, title, content string, tags []string) e
 		return errors.New("查找标题输入框失败")
 	}
 
-	if err := titleElem.Input(title); err != nil {
+	if err := titleElem.Input(content.Title); err != nil {
 		return errors.Wrap(err, "输入标题失败")
 	}
 
@@ -212,23 +222,38 @@ func submitPublishDouyin(page *rod.Page, title, content string, tags []string) e
 	}
 
 	if contentElem != nil {
-		if err := contentElem.Input(content); err != nil {
+		if err := contentElem.Input(content.Content); err != nil {
 			logrus.Warnf("输入内容失败: %v", err)
 		} else {
 			logrus.Info("内容输入完成")
 		}
+		if err := inputMentionsDouyin(contentElem, content.Mentions); err != nil {
+			logrus.Warnf("添加提及失败: %v", err)
+		}
 	}
 
 	time.Sleep(500 * time.Millisecond)
 
-	if len(tags) > 0 {
-		if err := inputTagsDouyin(page, tags); err != nil {
+	if len(content.Tags) > 0 {
+		if err := inputTagsDouyin(page, content.Tags, content.ExistingTopicsOnly); err != nil {
 			logrus.Warnf("添加标签失败: %v", err)
 		}
 	}
 
 	time.Sleep(1 * time.Second)
 
+	if err := selectMusicDouyin(page, content.Music); err != nil {
+		return errors.Wrap(err, "选择背景音乐失败")
+	}
+
+	if err := applyPublishSettingsDouyin(page, content.Settings); err != nil {
+		return err
+	}
+
+	if content.Draft {
+		return saveDraftDouyin(page)
+	}
+
 	publishSelectors := []string{
 		`button[class*="publish"]`,
 		`button[class*="submit"]`,
@@ -252,37 +277,22 @@ func submitPublishDouyin(page *rod.Page, title, content string, tags []string) e
 
 	clickEmptyPositionDouyin(page)
 
-	if err := publishBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+	if err := platform.ClickSubmit(page, publishBtn, douyinPublishCheck.FeedbackSelector); err != nil {
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
 
 	logrus.Info("已点击发布按钮")
 
-	time.Sleep(3 * time.Second)
-
 	return nil
 }
 
-func inputTagsDouyin(page *rod.Page, tags []string) error {
+// inputTagsDouyin 逐个输入标签并选择话题联想
+// existingOnly 为 true 时只选择与标签同名的已有话题，找不到时清除输入并跳过该标签
+func inputTagsDouyin(page *rod.Page, tags []string, existingOnly bool) error {
 	for _, tag := range tags {
 		tag = strings.TrimLeft(tag, "#")
 
-		tagSelectors := []string{
-			`input[placeholder*="话题"]`,
-			`input[placeholder*="标签"]`,
-			`.tag-input input`,
-			`[class*="tag"] input`,
-		}
-
-		var tagInput *rod.Element
-		var err error
-		for _, selector := range tagSelectors {
-			tagInput, err = page.Element(selector)
-			if err == nil && tagInput != nil {
-				break
-			}
-		}
-
+		tagInput := findTagInputDouyin(page)
 		if tagInput == nil {
 			logrus.Warnf("未找到标签输入框，跳过标签: %s", tag)
 			continue
@@ -295,23 +305,20 @@ func inputTagsDouyin(page *rod.Page, tags []string) error {
 
 		time.Sleep(500 * time.Millisecond)
 
-		topicSelectors := []string{
-			`.topic-item`,
-			`.tag-item`,
-			`[class*="topic"] li`,
-			`[class*="suggestion"] li`,
-		}
+		topics, items := topicSuggestionsDouyin(page)
 
-		var firstItem *rod.Element
-		for _, selector := range topicSelectors {
-			firstItem, err = page.Element(selector)
-			if err == nil && firstItem != nil {
-				break
+		idx := 0
+		if existingOnly {
+			idx = platform.FindTopic(topics, tag)
+			if idx < 0 {
+				clearTagInputDouyin(tagInput)
+				logrus.Warnf("标签[%s]没有对应的已有话题，已跳过", tag)
+				continue
 			}
 		}
 
-		if firstItem != nil {
-			if err := firstItem.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		if len(items) > 0 {
+			if err := items[idx].Click(proto.InputMouseButtonLeft, 1); err != nil {
 				logrus.Warnf("点击标签选项失败: %v", err)
 			} else {
 				logrus.Infof("成功添加标签: #%s", tag)
@@ -354,12 +361,18 @@ func NewPublishVideoAction(page *rod.Page) (*VideoPublishAction, error) {
 }
 
 type PublishVideoContent struct {
-	Title        string
-	Description  string
-	Tags         []string
-	VideoPath    string
-	CoverPath    string
-	ScheduleTime *time.Time
+	Title              string
+	Description        string
+	Tags               []string
+	VideoPath          string
+	CoverPath          string  // 自定义封面图片路径
+	CoverTime          float64 // 截取视频第几秒的画面作为封面，CoverPath 为空时生效
+	ScheduleTime       *time.Time
+	Draft              bool                     // 仅保存草稿，不发布
+	ExistingTopicsOnly bool                     // 只使用能匹配到已有话题的标签
+	Mentions           []string                 // 正文末尾 @提及的用户昵称或抖音号
+	Settings           platform.PublishSettings // 位置、可见范围、合集、原创声明
+	Progress           platform.ProgressFunc    // 上传和转码进度回调（可选）
 }
 
 func (p *VideoPublishAction) Publish(ctx context.Context, content PublishVideoContent) error {
@@ -374,22 +387,28 @@ func (p *VideoPublishAction) Publish(ctx context.Context, content PublishVideoCo
 	page := p.page.Context(ctx)
 
 	logrus.Info("开始上传视频...")
+	tracker := platform.NewProgressTracker(content.VideoPath, content.Progress)
+	tracker.Start()
 	if err := uploadVideoDouyin(page, content.VideoPath); err != nil {
 		return errors.Wrap(err, "抖音上传视频失败")
 	}
 
 	logrus.Info("等待视频处理完成...")
-	if err := waitForVideoProcessing(page); err != nil {
+	if err := waitForVideoProcessing(page, tracker); err != nil {
 		return errors.Wrap(err, "视频处理失败")
 	}
 
 	logrus.Infof("填写视频信息: title=%s", content.Title)
 
-	if err := fillVideoInfo(page, content.Title, content.Description, content.Tags); err != nil {
+	if err := fillVideoInfo(page, content); err != nil {
 		return errors.Wrap(err, "填写视频信息失败")
 	}
 
-	logrus.Info("抖音视频发布成功！")
+	if content.Draft {
+		logrus.Info("抖音视频草稿保存成功！")
+		return nil
+	}
+	logrus.Info("抖音视频已提交发布")
 	return nil
 }
 
@@ -421,9 +440,10 @@ func uploadVideoDouyin(page *rod.Page, videoPath string) error {
 	return nil
 }
 
-func waitForVideoProcessing(page *rod.Page) error {
+// waitForVideoProcessing 等待上传和转码完成，期间把页面上的进度交给 tracker 上报
+func waitForVideoProcessing(page *rod.Page, tracker *platform.ProgressTracker) error {
 	maxWaitTime := 10 * time.Minute
-	checkInterval := 3 * time.Second
+	checkInterval := 1 * time.Second
 	start := time.Now()
 
 	for time.Since(start) < maxWaitTime {
@@ -438,6 +458,7 @@ func waitForVideoProcessing(page *rod.Page) error {
 			has, _, err := page.Has(selector)
 			if err == nil && has {
 				logrus.Info("视频处理完成")
+				tracker.Done()
 				return nil
 			}
 		}
@@ -453,6 +474,7 @@ func waitForVideoProcessing(page *rod.Page) error {
 			if err == nil && has && elem != nil {
 				text, _ := elem.Text()
 				logrus.Debugf("视频处理中: %s", text)
+				tracker.Update(text)
 			}
 		}
 
@@ -462,7 +484,11 @@ func waitForVideoProcessing(page *rod.Page) error {
 	return errors.New("视频处理超时(10分钟)")
 }
 
-func fillVideoInfo(page *rod.Page, title, description string, tags []string) error {
+func fillVideoInfo(page *rod.Page, content PublishVideoContent) error {
+	if err := douyinCoverDialog.Set(page, content.CoverPath, content.CoverTime); err != nil {
+		return errors.Wrap(err, "设置封面失败")
+	}
+
 	titleSelectors := []string{
 		`input[placeholder*="标题"]`,
 		`input[placeholder*="填写标题"]`,
@@ -480,7 +506,7 @@ func fillVideoInfo(page *rod.Page, title, description string, tags []string) err
 	}
 
 	if titleElem != nil {
-		if err := titleElem.Input(title); err != nil {
+		if err := titleElem.Input(content.Title); err != nil {
 			logrus.Warnf("输入标题失败: %v", err)
 		} else {
 			logrus.Info("标题输入完成")
@@ -489,7 +515,7 @@ func fillVideoInfo(page *rod.Page, title, description string, tags []string) err
 
 	time.Sleep(500 * time.Millisecond)
 
-	if description != "" {
+	if content.Description != "" {
 		descSelectors := []string{
 			`textarea[placeholder*="描述"]`,
 			`textarea[placeholder*="简介"]`,
@@ -506,24 +532,35 @@ func fillVideoInfo(page *rod.Page, title, description string, tags []string) err
 		}
 
 		if descElem != nil {
-			if err := descElem.Input(description); err != nil {
+			if err := descElem.Input(content.Description); err != nil {
 				logrus.Warnf("输入描述失败: %v", err)
 			} else {
 				logrus.Info("描述输入完成")
 			}
+			if err := inputMentionsDouyin(descElem, content.Mentions); err != nil {
+				logrus.Warnf("添加提及失败: %v", err)
+			}
 		}
 	}
 
 	time.Sleep(500 * time.Millisecond)
 
-	if len(tags) > 0 {
-		if err := inputTagsDouyin(page, tags); err != nil {
+	if len(content.Tags) > 0 {
+		if err := inputTagsDouyin(page, content.Tags, content.ExistingTopicsOnly); err != nil {
 			logrus.Warnf("添加标签失败: %v", err)
 		}
 	}
 
 	time.Sleep(1 * time.Second)
 
+	if err := applyPublishSettingsDouyin(page, content.Settings); err != nil {
+		return err
+	}
+
+	if content.Draft {
+		return saveDraftDouyin(page)
+	}
+
 	publishSelectors := []string{
 		`button[class*="publish"]`,
 		`button[class*="submit"]`,
@@ -541,29 +578,36 @@ func fillVideoInfo(page *rod.Page, title, description string, tags []string) err
 
 	if publishBtn != nil {
 		clickEmptyPositionDouyin(page)
-		if err := publishBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		if err := platform.ClickSubmit(page, publishBtn, douyinPublishCheck.FeedbackSelector); err != nil {
 			logrus.Warnf("点击发布按钮失败: %v", err)
 		} else {
 			logrus.Info("已点击发布按钮")
 		}
 	}
 
-	time.Sleep(3 * time.Second)
-
 	return nil
 }
 
 func (d *DouyinPlatform) PublishImageText(ctx context.Context, page *rod.Page, req *platform.ImageTextRequest) (*platform.PublishResponse, error) {
+	if req.Micro {
+		logrus.Warn("微头条仅适用于今日头条，忽略 micro")
+	}
+
 	publishAction, err := NewPublishImageAction(page)
 	if err != nil {
 		return nil, err
 	}
 
 	content := PublishImageContent{
-		Title:      req.Title,
-		Content:    req.Content,
-		Tags:       req.Tags,
-		ImagePaths: req.Images,
+		Title:              req.Title,
+		Content:            req.Content,
+		Tags:               req.Tags,
+		ImagePaths:         req.Images,
+		Draft:              req.Draft,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		Settings:           req.PublishSettings,
+		Music:              req.Music,
 	}
 
 	if req.ScheduleAt != "" {
@@ -580,24 +624,37 @@ func (d *DouyinPlatform) PublishImageText(ctx context.Context, page *rod.Page, r
 		}, err
 	}
 
-	return &platform.PublishResponse{
-		Success: true,
-		Message: "抖音图文发布成功",
-	}, nil
+	if req.Draft {
+		return draftSavedDouyin(ctx, page, req.Title, "image_text"), nil
+	}
+
+	return douyinPublishCheck.Verify(ctx, page, "抖音图文", req.Title)
 }
 
 func (d *DouyinPlatform) PublishVideo(ctx context.Context, page *rod.Page, req *platform.VideoRequest) (*platform.PublishResponse, error) {
+	if req.CoverPath != "" {
+		if err := platform.CheckCoverSize(req.CoverPath, platform.VideoCoverSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	publishAction, err := NewPublishVideoAction(page)
 	if err != nil {
 		return nil, err
 	}
 
 	content := PublishVideoContent{
-		Title:       req.Title,
-		Description: req.Description,
-		Tags:        req.Tags,
-		VideoPath:   req.VideoPath,
-		CoverPath:   req.CoverPath,
+		Title:              req.Title,
+		Description:        req.Description,
+		Tags:               req.Tags,
+		VideoPath:          req.VideoPath,
+		CoverPath:          req.CoverPath,
+		CoverTime:          req.CoverTime,
+		Draft:              req.Draft,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		Settings:           req.PublishSettings,
+		Progress:           req.Progress,
 	}
 
 	if req.ScheduleAt != "" {
@@ -614,8 +671,9 @@ func (d *DouyinPlatform) PublishVideo(ctx context.Context, page *rod.Page, req *
 		}, err
 	}
 
-	return &platform.PublishResponse{
-		Success: true,
-		Message: "抖音视频发布成功",
-	}, nil
+	if req.Draft {
+		return draftSavedDouyin(ctx, page, req.Title, "video"), nil
+	}
+
+	return douyinPublishCheck.Verify(ctx, page, "抖音视频", req.Title)
 }
@@ -0,0 +1,189 @@
+package douyin
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+var (
+	douyinLocationInputSelectors = []string{
+		`input[placeholder*="位置"]`,
+		`input[placeholder*="地点"]`,
+		`[class*="poi"] input`,
+		`[class*="location"] input`,
+	}
+
+	// douyinDropdownSelector 位置、合集下拉框展开后的弹层，通常渲染在 body 下
+	douyinDropdownSelector       = `.semi-select-option-list, .semi-popover-content, [role="listbox"], [class*="dropdown"], [class*="poi-list"]`
+	douyinDropdownOptionSelector = `.semi-select-option, [role="option"], [class*="option"], [class*="poi-item"], li`
+
+	// douyinVisibilityLabels "设置谁可以看"中的选项文案
+	douyinVisibilityLabels = map[platform.Visibility]string{
+		platform.VisibilityPublic:  "公开",
+		platform.VisibilityFriends: "好友可见",
+		platform.VisibilityPrivate: "仅自己可见",
+	}
+)
+
+// applyPublishSettingsDouyin 设置位置、合集、原创声明和可见范围
+// 任一项失败都返回错误，避免作品以不符合要求的可见范围发布
+func applyPublishSettingsDouyin(page *rod.Page, s platform.PublishSettings) error {
+	if s.Location != "" {
+		if err := setLocationDouyin(page, s.Location); err != nil {
+			return errors.Wrap(err, "设置位置失败")
+		}
+	}
+	if s.Collection != "" {
+		if err := setCollectionDouyin(page, s.Collection); err != nil {
+			return errors.Wrap(err, "加入合集失败")
+		}
+	}
+	if s.Original {
+		if err := declareOriginalDouyin(page); err != nil {
+			return errors.Wrap(err, "声明原创失败")
+		}
+	}
+	if s.Visibility != "" && s.Visibility != platform.VisibilityPublic {
+		if err := setVisibilityDouyin(page, s.Visibility); err != nil {
+			return errors.Wrap(err, "设置可见范围失败")
+		}
+	}
+	return nil
+}
+
+// setLocationDouyin 搜索位置并选中第一个结果
+func setLocationDouyin(page *rod.Page, keyword string) error {
+	var locationInput *rod.Element
+	for _, selector := range douyinLocationInputSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			locationInput = elem
+			break
+		}
+	}
+	if locationInput == nil {
+		return errors.New("未找到位置输入框")
+	}
+
+	if err := locationInput.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击位置输入框失败")
+	}
+	if err := locationInput.Input(keyword); err != nil {
+		return errors.Wrap(err, "输入位置关键词失败")
+	}
+	// 位置搜索需要请求 POI 接口
+	time.Sleep(2 * time.Second)
+
+	dropdown, err := openDropdownDouyin(page)
+	if err != nil {
+		return errors.Wrap(err, "位置搜索结果未展开")
+	}
+	item, err := dropdown.Timeout(5*time.Second).ElementR(douyinDropdownOptionSelector, `\S`)
+	if err != nil {
+		return errors.Errorf("未找到位置: %s", keyword)
+	}
+	if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择位置失败")
+	}
+	logrus.Infof("已设置位置: %s", keyword)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// setCollectionDouyin 打开合集下拉框并选中同名合集
+func setCollectionDouyin(page *rod.Page, name string) error {
+	trigger, err := page.Timeout(5*time.Second).ElementR(`[class*="mix"], [class*="collection"], .semi-select`, "合集")
+	if err != nil {
+		return errors.Wrap(err, "未找到合集入口")
+	}
+	if err := trigger.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击合集入口失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	dropdown, err := openDropdownDouyin(page)
+	if err != nil {
+		return errors.Wrap(err, "合集列表未展开")
+	}
+	option, err := dropdown.Timeout(5*time.Second).ElementR(douyinDropdownOptionSelector, "^"+regexp.QuoteMeta(name)+"$")
+	if err != nil {
+		return errors.Errorf("未找到合集: %s", name)
+	}
+	if err := option.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择合集失败")
+	}
+	logrus.Infof("已加入合集: %s", name)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// openDropdownDouyin 返回当前展开的下拉弹层，选项只在弹层内查找，避免点到页面上其他列表项
+// 多个弹层同时存在时取最后一个，即最近展开的
+func openDropdownDouyin(page *rod.Page) (*rod.Element, error) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		if elems, err := page.Elements(douyinDropdownSelector); err == nil {
+			for i := len(elems) - 1; i >= 0; i-- {
+				if visible, err := elems[i].Visible(); err == nil && visible {
+					return elems[i], nil
+				}
+			}
+		}
+		time.Sleep(300 * time.Millisecond)
+	}
+	return nil, errors.New("未找到展开的下拉列表")
+}
+
+// declareOriginalDouyin 勾选原创声明并确认弹窗，已勾选时跳过
+func declareOriginalDouyin(page *rod.Page) error {
+	checkbox, err := page.Timeout(5*time.Second).ElementR(`label, [class*="checkbox"]`, "原创")
+	if err != nil {
+		return errors.Wrap(err, "未找到原创声明选项")
+	}
+	checked, err := platform.IsChecked(checkbox)
+	if err != nil {
+		return err
+	}
+	if checked {
+		logrus.Info("原创声明已勾选")
+		return nil
+	}
+	if err := checkbox.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击原创声明失败")
+	}
+	time.Sleep(800 * time.Millisecond)
+
+	if btn, err := page.Timeout(3*time.Second).ElementR(`[class*="modal"] button, [class*="dialog"] button`, "确定|确认|同意"); err == nil {
+		if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "确认原创声明失败")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	logrus.Info("已声明原创")
+	return nil
+}
+
+// setVisibilityDouyin 在"设置谁可以看"中选择可见范围
+func setVisibilityDouyin(page *rod.Page, visibility platform.Visibility) error {
+	label, ok := douyinVisibilityLabels[visibility]
+	if !ok {
+		return errors.Errorf("不支持的可见范围: %s", visibility)
+	}
+
+	radio, err := page.Timeout(5*time.Second).ElementR(`label, [class*="radio"]`, "^"+regexp.QuoteMeta(label)+"$")
+	if err != nil {
+		return errors.Errorf("未找到可见范围选项: %s", label)
+	}
+	if err := radio.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择可见范围失败")
+	}
+	logrus.Infof("已设置可见范围: %s", label)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
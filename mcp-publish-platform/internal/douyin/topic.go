@@ -0,0 +1,114 @@
+package douyin
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+var (
+	douyinTagInputSelectors = []string{
+		`input[placeholder*="话题"]`,
+		`input[placeholder*="标签"]`,
+		`.tag-input input`,
+		`[class*="tag"] input`,
+	}
+
+	douyinTopicItemSelectors = []string{
+		`.topic-item`,
+		`.tag-item`,
+		`[class*="topic"] li`,
+		`[class*="suggestion"] li`,
+	}
+)
+
+func (d *DouyinPlatform) SearchTopics(ctx context.Context, page *rod.Page, keyword string) ([]platform.Topic, error) {
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+
+	if err := pp.Navigate(douyinPublishURL); err != nil {
+		return nil, errors.Wrap(err, "导航到发布页面失败")
+	}
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	tagInput := findTagInputDouyin(pp)
+	if tagInput == nil {
+		return nil, errors.New("未找到话题输入框")
+	}
+	if err := tagInput.Input("#" + keyword); err != nil {
+		return nil, errors.Wrap(err, "输入话题关键词失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	topics, _ := topicSuggestionsDouyin(pp)
+	clearTagInputDouyin(tagInput)
+
+	logrus.Infof("抖音话题联想 %q 返回 %d 个候选", keyword, len(topics))
+	return topics, nil
+}
+
+// findTagInputDouyin 查找话题输入框，找不到时返回 nil
+func findTagInputDouyin(page *rod.Page) *rod.Element {
+	for _, selector := range douyinTagInputSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			return elem
+		}
+	}
+	return nil
+}
+
+// topicSuggestionsDouyin 读取话题联想下拉框，返回候选及对应的可点击元素
+func topicSuggestionsDouyin(page *rod.Page) ([]platform.Topic, []*rod.Element) {
+	for _, selector := range douyinTopicItemSelectors {
+		items, err := page.Elements(selector)
+		if err != nil || len(items) == 0 {
+			continue
+		}
+
+		topics := make([]platform.Topic, 0, len(items))
+		for _, item := range items {
+			text, _ := item.Text()
+			topics = append(topics, parseTopicDouyin(text))
+		}
+		return topics, items
+	}
+	return nil, nil
+}
+
+// clearTagInputDouyin 清空话题输入框，避免未选中的 #关键词 留在正文中
+func clearTagInputDouyin(tagInput *rod.Element) {
+	if err := tagInput.SelectAllText(); err != nil {
+		logrus.Warnf("清除话题输入失败: %v", err)
+		return
+	}
+	if err := tagInput.Type(input.Backspace); err != nil {
+		logrus.Warnf("清除话题输入失败: %v", err)
+	}
+}
+
+// parseTopicDouyin 解析联想候选文本，如 "#美食\n12.3亿次播放"
+func parseTopicDouyin(text string) platform.Topic {
+	var topic platform.Topic
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case topic.Name == "":
+			topic.Name = strings.TrimSpace(strings.TrimLeft(line, "#"))
+		case topic.ViewsText == "" && strings.ContainsAny(line, "0123456789"):
+			topic.ViewsText = line
+			topic.Views = xhsutil.ExtractCount(line)
+		}
+	}
+	return topic
+}
@@ -0,0 +1,10 @@
+package douyin
+
+import "github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+
+// douyinPublishCheck 发布成功后页面会跳转到内容管理页，跳转本身即视为提交成功
+var douyinPublishCheck = platform.PublishCheck{
+	SuccessURL:   douyinContentManage,
+	ManageURL:    douyinContentManage,
+	ItemSelector: `[class*="video-card"], [class*="content-item"], [class*="card-container"]`,
+}
@@ -0,0 +1,261 @@
+package platform
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// outermostJS 查询 selector 匹配的元素，去掉嵌套在其他匹配元素内的重复项和包含多个匹配项的列表容器
+const outermostJS = `(selector) => {
+	const all = Array.from(document.querySelectorAll(selector));
+	const items = all.filter((el) => all.filter((o) => o !== el && el.contains(o)).length < 2);
+	return items.filter((el) => !items.some((o) => o !== el && o.contains(el)));
+}`
+
+// ChatPage 网页私信工作台，各平台的页面结构相同，只有地址和选择器不同
+type ChatPage struct {
+	URL                  string // 私信页面地址
+	ConversationSelector string // 会话列表中的会话
+	MessageSelector      string // 当前会话中的消息
+	InputSelector        string // 消息输入框
+}
+
+// ChatConversation 会话列表中的会话及其页面元素
+type ChatConversation struct {
+	Conversation
+	Element *rod.Element `json:"-"`
+}
+
+// rawConversation 会话元素上读取到的原始属性
+type rawConversation struct {
+	ID          string `json:"id"`
+	UserID      string `json:"user_id"`
+	Nickname    string `json:"nickname"`
+	Avatar      string `json:"avatar"`
+	LastMessage string `json:"last_message"`
+	LastTime    string `json:"last_time"`
+	Unread      int    `json:"unread"`
+}
+
+// conversationID 优先使用页面上的会话ID或对方用户ID；都没有时用昵称和头像地址生成，
+// 避免同名联系人得到相同的ID
+func (c rawConversation) conversationID() string {
+	switch {
+	case c.ID != "":
+		return c.ID
+	case c.UserID != "":
+		return c.UserID
+	}
+	return ConversationIDFor(c.Nickname, c.Avatar)
+}
+
+// Conversations 打开私信页面并读取会话列表，最近的在前
+func (c ChatPage) Conversations(page *rod.Page) ([]ChatConversation, error) {
+	if err := page.Navigate(c.URL); err != nil {
+		return nil, errors.Wrap(err, "打开私信页面失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待私信页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	if _, err := page.Timeout(15 * time.Second).Element(c.ConversationSelector); err != nil {
+		return nil, errors.Wrap(err, "未找到私信会话列表，可能没有会话或账号未开通网页私信")
+	}
+
+	elems, err := page.ElementsByJS(rod.Eval(outermostJS, c.ConversationSelector))
+	if err != nil {
+		return nil, errors.Wrap(err, "读取私信会话列表失败")
+	}
+
+	conversations := make([]ChatConversation, 0, len(elems))
+	for _, el := range elems {
+		result, err := el.Eval(`function () {
+			const text = (sel) => {
+				const found = this.querySelector(sel);
+				return found ? found.innerText.trim() : '';
+			};
+			const attr = (names) => {
+				for (const name of names) {
+					const holder = this.matches('[' + name + ']') ? this : this.querySelector('[' + name + ']');
+					if (holder && holder.getAttribute(name)) return holder.getAttribute(name);
+				}
+				return '';
+			};
+			const link = this.querySelector('a[href*="/user/"], a[href*="uid="]');
+			const m = link ? link.href.match(/\/user\/(?:profile\/)?([\w-]+)|uid=([\w-]+)/) : null;
+			const img = this.querySelector('img');
+			const badge = text('[class*="badge"], [class*="unread"], [class*="count"]');
+			return JSON.stringify({
+				id: attr(['data-conversation-id', 'data-session-id', 'data-id']),
+				user_id: attr(['data-user-id', 'data-uid']) || (m ? (m[1] || m[2]) : ''),
+				nickname: text('[class*="name"], [class*="nick"]'),
+				avatar: img ? img.src : '',
+				last_message: text('[class*="content"], [class*="desc"], [class*="last"]'),
+				last_time: text('[class*="time"], [class*="date"]'),
+				unread: parseInt(badge, 10) || 0,
+			});
+		}`)
+		if err != nil {
+			return nil, errors.Wrap(err, "读取私信会话失败")
+		}
+
+		var raw rawConversation
+		if err := json.Unmarshal([]byte(result.Value.Str()), &raw); err != nil {
+			return nil, errors.Wrap(err, "解析私信会话失败")
+		}
+		conversations = append(conversations, ChatConversation{
+			Conversation: Conversation{
+				ConversationID: raw.conversationID(),
+				UserID:         raw.UserID,
+				Nickname:       raw.Nickname,
+				Avatar:         raw.Avatar,
+				LastMessage:    raw.LastMessage,
+				LastTime:       raw.LastTime,
+				UnreadCount:    raw.Unread,
+			},
+			Element: el,
+		})
+	}
+	return conversations, nil
+}
+
+// Open 打开私信页面并点击指定会话
+func (c ChatPage) Open(page *rod.Page, conversationID string) (*ChatConversation, error) {
+	conversations, err := c.Conversations(page)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := findConversation(conversations, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	if err := target.Element.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, errors.Wrap(err, "打开私信会话失败")
+	}
+	time.Sleep(2 * time.Second)
+
+	return target, nil
+}
+
+// findConversation 按会话ID查找会话，多个会话得到相同ID时无法确定对象，返回错误而不是任选一个
+func findConversation(conversations []ChatConversation, conversationID string) (*ChatConversation, error) {
+	var target *ChatConversation
+	for i := range conversations {
+		if conversations[i].ConversationID != conversationID {
+			continue
+		}
+		if target != nil {
+			return nil, errors.Errorf("多个私信会话无法区分（昵称和头像相同）: %s", conversationID)
+		}
+		target = &conversations[i]
+	}
+	if target == nil {
+		return nil, errors.Errorf("未找到私信会话，会话列表可能已变化，请重新获取: %s", conversationID)
+	}
+	return target, nil
+}
+
+// Messages 读取当前会话中已加载的消息，按时间先后排列
+func (c ChatPage) Messages(page *rod.Page) ([]Message, error) {
+	elems, err := page.ElementsByJS(rod.Eval(outermostJS, c.MessageSelector))
+	if err != nil {
+		return nil, errors.Wrap(err, "读取私信消息失败")
+	}
+
+	messages := make([]Message, 0, len(elems))
+	for _, el := range elems {
+		result, err := el.Eval(`function () {
+			const text = (sel) => {
+				const found = this.querySelector(sel);
+				return found ? found.innerText.trim() : '';
+			};
+			const cls = (this.className || '').toString();
+			const img = this.querySelector('[class*="content"] img, [class*="bubble"] img');
+			const card = this.querySelector('[class*="card"]');
+			return JSON.stringify({
+				message_id: this.getAttribute('data-id') || this.getAttribute('data-message-id') || '',
+				from_me: /self|mine|right|send/.test(cls),
+				sender: text('[class*="name"], [class*="nick"]'),
+				content: text('[class*="content"], [class*="bubble"], [class*="text"]') || (img ? img.src : ''),
+				type: card ? 'card' : (img ? 'image' : 'text'),
+				sent_at: text('[class*="time"]'),
+			});
+		}`)
+		if err != nil {
+			return nil, errors.Wrap(err, "读取私信消息失败")
+		}
+
+		var m Message
+		if err := json.Unmarshal([]byte(result.Value.Str()), &m); err != nil {
+			return nil, errors.Wrap(err, "解析私信消息失败")
+		}
+		messages = append(messages, m)
+	}
+	return messages, nil
+}
+
+// Send 在当前会话中发送文本消息，并等待消息出现在会话中
+func (c ChatPage) Send(page *rod.Page, content string) error {
+	before, err := c.Messages(page)
+	if err != nil {
+		return err
+	}
+
+	box, err := page.Timeout(10 * time.Second).Element(c.InputSelector)
+	if err != nil {
+		return errors.Wrap(err, "未找到私信输入框")
+	}
+	if err := box.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击私信输入框失败")
+	}
+	if err := box.Input(content); err != nil {
+		return errors.Wrap(err, "输入私信内容失败")
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if btn, err := page.Timeout(3*time.Second).ElementR(`button`, `^\s*发送\s*$`); err == nil {
+		if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "点击发送按钮失败")
+		}
+	} else if err := page.Keyboard.Type(input.Enter); err != nil {
+		return errors.Wrap(err, "发送私信失败")
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		after, err := c.Messages(page)
+		if err != nil {
+			continue
+		}
+		if messageSent(before, after, content) {
+			return nil
+		}
+	}
+	return errors.New("发送后会话中没有出现该消息，私信可能未发送成功")
+}
+
+// messageSent 发送后会话中同内容的消息比发送前多，视为发送成功
+// 不依赖 FromMe：消息方向是按样式类名推断的，不一定可靠
+func messageSent(before, after []Message, content string) bool {
+	content = strings.TrimSpace(content)
+	count := func(messages []Message) int {
+		n := 0
+		for _, m := range messages {
+			if strings.TrimSpace(m.Content) == content {
+				n++
+			}
+		}
+		return n
+	}
+	return count(after) > count(before)
+}
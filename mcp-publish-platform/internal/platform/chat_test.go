@@ -0,0 +1,44 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawConversationID(t *testing.T) {
+	assert.Equal(t, "c1", rawConversation{ID: "c1", UserID: "u1", Nickname: "小红"}.conversationID())
+	assert.Equal(t, "u1", rawConversation{UserID: "u1", Nickname: "小红"}.conversationID())
+
+	// 同名联系人头像不同时得到不同的ID
+	a := rawConversation{Nickname: "小红", Avatar: "https://img/a.jpg"}.conversationID()
+	b := rawConversation{Nickname: "小红", Avatar: "https://img/b.jpg"}.conversationID()
+	assert.NotEqual(t, a, b)
+}
+
+func TestFindConversation(t *testing.T) {
+	conversations := []ChatConversation{
+		{Conversation: Conversation{ConversationID: "a", Nickname: "小红"}},
+		{Conversation: Conversation{ConversationID: "b", Nickname: "小蓝"}},
+		{Conversation: Conversation{ConversationID: "b", Nickname: "小蓝"}},
+	}
+
+	target, err := findConversation(conversations, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "小红", target.Nickname)
+
+	_, err = findConversation(conversations, "b")
+	assert.Error(t, err, "多个会话得到相同ID时不能任选一个")
+
+	_, err = findConversation(conversations, "c")
+	assert.Error(t, err)
+}
+
+func TestMessageSent(t *testing.T) {
+	before := []Message{{Content: "你好"}, {Content: "在吗", FromMe: true}}
+
+	assert.True(t, messageSent(before, append(before, Message{Content: "在吗 "}), "在吗"))
+	assert.False(t, messageSent(before, before, "在吗"), "只有旧的同内容消息")
+	assert.False(t, messageSent(before, append(before, Message{Content: "其他"}), "在吗"))
+}
@@ -0,0 +1,24 @@
+package platform
+
+import (
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+)
+
+// IsChecked 读取复选框当前是否已勾选：依次检查内部 input 的 checked、aria-checked
+// 和组件库常用的 checked 样式类，用于点击前判断，避免把已勾选的选项点掉
+func IsChecked(elem *rod.Element) (bool, error) {
+	result, err := elem.Eval(`function () {
+		const input = this.matches('input[type="checkbox"]') ? this : this.querySelector('input[type="checkbox"]');
+		if (input) return input.checked;
+		const aria = this.matches('[aria-checked]') ? this : this.querySelector('[aria-checked]');
+		if (aria) return aria.getAttribute('aria-checked') === 'true';
+		const checked = /(^|[\s_-])(is-)?checked(\s|$)/;
+		if (checked.test((this.className || '').toString())) return true;
+		return Array.from(this.querySelectorAll('[class*="checked"]')).some((el) => checked.test((el.className || '').toString()));
+	}`)
+	if err != nil {
+		return false, errors.Wrap(err, "读取勾选状态失败")
+	}
+	return result.Value.Bool(), nil
+}
@@ -0,0 +1,91 @@
+package platform
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultConfirmTTL 删除确认令牌有效期
+const DefaultConfirmTTL = 5 * time.Minute
+
+var (
+	// ErrConfirmRequired 删除操作缺少确认令牌
+	ErrConfirmRequired = errors.New("删除操作需要确认令牌，请先获取 confirm_token")
+	// ErrConfirmInvalid 确认令牌无效、已使用或已过期
+	ErrConfirmInvalid = errors.New("确认令牌无效或已过期，请重新获取")
+)
+
+// DeleteConfirmation 删除确认令牌
+// 删除不可恢复，调用方需先获取令牌，再携带令牌发起删除，令牌只能使用一次
+type DeleteConfirmation struct {
+	Token      string     `json:"confirm_token"`
+	PlatformID PlatformID `json:"platform"`
+	FeedID     string     `json:"feed_id"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+}
+
+// confirmStore 确认令牌存储
+type confirmStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	tokens map[string]DeleteConfirmation
+}
+
+func newConfirmStore(ttl time.Duration) *confirmStore {
+	return &confirmStore{
+		ttl:    ttl,
+		tokens: make(map[string]DeleteConfirmation),
+	}
+}
+
+// Issue 为指定内容签发确认令牌
+func (s *confirmStore) Issue(platformID PlatformID, feedID string) (*DeleteConfirmation, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	c := DeleteConfirmation{
+		Token:      hex.EncodeToString(buf),
+		PlatformID: platformID,
+		FeedID:     feedID,
+		ExpiresAt:  time.Now().Add(s.ttl),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purge()
+	s.tokens[c.Token] = c
+	return &c, nil
+}
+
+// Consume 校验并作废令牌，令牌必须与平台和内容ID匹配
+func (s *confirmStore) Consume(platformID PlatformID, feedID, token string) error {
+	if token == "" {
+		return ErrConfirmRequired
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.purge()
+
+	c, ok := s.tokens[token]
+	if !ok || c.PlatformID != platformID || c.FeedID != feedID {
+		return ErrConfirmInvalid
+	}
+	delete(s.tokens, token)
+	return nil
+}
+
+// purge 清理过期令牌，调用方需持有锁
+func (s *confirmStore) purge() {
+	now := time.Now()
+	for token, c := range s.tokens {
+		if now.After(c.ExpiresAt) {
+			delete(s.tokens, token)
+		}
+	}
+}
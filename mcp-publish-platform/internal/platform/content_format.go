@@ -0,0 +1,33 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRichTextUnsupported 平台不支持富文本正文
+var ErrRichTextUnsupported = errors.New("平台不支持富文本正文")
+
+// ContentFormat 正文格式
+type ContentFormat string
+
+const (
+	ContentFormatText     ContentFormat = "text"     // 纯文本（默认）
+	ContentFormatMarkdown ContentFormat = "markdown" // Markdown，转换为富文本
+	ContentFormatHTML     ContentFormat = "html"     // HTML 片段
+)
+
+// IsRich 是否需要按富文本写入编辑器
+func (f ContentFormat) IsRich() bool {
+	return f == ContentFormatMarkdown || f == ContentFormatHTML
+}
+
+// Validate 校验正文格式取值
+func (f ContentFormat) Validate() error {
+	switch f {
+	case "", ContentFormatText, ContentFormatMarkdown, ContentFormatHTML:
+		return nil
+	default:
+		return fmt.Errorf("不支持的正文格式: %s，可选 text|markdown|html", f)
+	}
+}
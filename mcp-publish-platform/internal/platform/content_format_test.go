@@ -0,0 +1,21 @@
+package platform
+
+import "testing"
+
+func TestContentFormat(t *testing.T) {
+	for _, f := range []ContentFormat{"", ContentFormatText, ContentFormatMarkdown, ContentFormatHTML} {
+		if err := f.Validate(); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", f, err)
+		}
+	}
+	if err := ContentFormat("rtf").Validate(); err == nil {
+		t.Error("Validate(rtf) = nil, want error")
+	}
+
+	if ContentFormatText.IsRich() || ContentFormat("").IsRich() {
+		t.Error("text should not be rich")
+	}
+	if !ContentFormatMarkdown.IsRich() || !ContentFormatHTML.IsRich() {
+		t.Error("markdown and html should be rich")
+	}
+}
@@ -0,0 +1,136 @@
+package platform
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ValidateCover 校验封面参数：图片与截帧二选一，截帧时间不能为负数
+// 图片尺寸要求因平台而异，由各平台上传前自行校验
+func (r *VideoRequest) ValidateCover() error {
+	if r.CoverTime < 0 {
+		return fmt.Errorf("封面时间不能为负数: %v", r.CoverTime)
+	}
+	if r.CoverPath != "" && r.CoverTime > 0 {
+		return fmt.Errorf("cover_path 与 cover_time 只能指定一个")
+	}
+	return nil
+}
+
+// coverSeekTimeout 等待预览视频加载元数据和定位到目标帧的最长时间
+const coverSeekTimeout = 15 * time.Second
+
+// CoverDialog 视频封面弹窗，各平台的流程相同：点击入口打开弹窗，上传图片或截取视频帧，再点击确认
+type CoverDialog struct {
+	EntrySelector  string // 打开封面弹窗的入口
+	EntryPattern   string // 入口文案，正则
+	ModalSelector  string // 封面弹窗
+	UploadTab      string // "上传封面"标签的文案，正则
+	ConfirmPattern string // 确认按钮的文案，正则
+}
+
+// Set 上传封面图片或截取视频指定秒数的画面，并确认
+// coverPath 优先；两者都为空时保持平台默认封面
+func (d CoverDialog) Set(page *rod.Page, coverPath string, coverTime float64) error {
+	if coverPath == "" && coverTime <= 0 {
+		return nil
+	}
+
+	entry, err := page.Timeout(10*time.Second).ElementR(d.EntrySelector, d.EntryPattern)
+	if err != nil {
+		return errors.Wrap(err, "未找到设置封面入口")
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击设置封面失败")
+	}
+
+	modal, err := page.Timeout(10 * time.Second).Element(d.ModalSelector)
+	if err != nil {
+		return errors.Wrap(err, "封面弹窗未出现")
+	}
+	time.Sleep(1 * time.Second)
+
+	if coverPath != "" {
+		err = d.upload(modal, coverPath)
+	} else {
+		err = SeekCoverFrame(modal, coverTime)
+	}
+	if err != nil {
+		return err
+	}
+
+	confirm, err := modal.ElementR("button", d.ConfirmPattern)
+	if err != nil {
+		return errors.Wrap(err, "未找到封面确认按钮")
+	}
+	if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "确认封面失败")
+	}
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// upload 切换到上传标签并上传本地图片
+func (d CoverDialog) upload(modal *rod.Element, coverPath string) error {
+	if tab, err := modal.ElementR("div, span", d.UploadTab); err == nil {
+		if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "切换到上传封面失败")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	fileInput, err := modal.Element(`input[type="file"]`)
+	if err != nil {
+		return errors.Wrap(err, "未找到封面上传输入框")
+	}
+	if err := fileInput.SetFiles([]string{coverPath}); err != nil {
+		return errors.Wrap(err, "上传封面失败")
+	}
+	// 等待封面上传和裁剪区域渲染
+	time.Sleep(3 * time.Second)
+	logrus.Infof("封面图片已上传: %s", coverPath)
+	return nil
+}
+
+// SeekCoverFrame 在"截取封面"中把预览视频定位到指定秒数，以该帧作为封面
+// 设置 currentTime 后等待 seeked 事件，确保截取的是目标帧；视频迟迟不加载或不触发事件时超时返回
+func SeekCoverFrame(modal *rod.Element, seconds float64) error {
+	video, err := modal.Element("video")
+	if err != nil {
+		return errors.Wrap(err, "未找到封面预览视频")
+	}
+
+	res, err := video.Timeout(coverSeekTimeout+5*time.Second).Eval(`function (t, timeout) {
+		return new Promise((resolve) => {
+			const timer = setTimeout(() => resolve(-2), timeout);
+			const done = (v) => { clearTimeout(timer); resolve(v); };
+			const apply = () => {
+				if (!isFinite(this.duration) || t > this.duration) {
+					done(-1);
+					return;
+				}
+				this.addEventListener('seeked', () => done(this.currentTime), { once: true });
+				this.currentTime = t;
+			};
+			if (this.readyState >= 1) apply();
+			else this.addEventListener('loadedmetadata', apply, { once: true });
+		});
+	}`, seconds, coverSeekTimeout.Milliseconds())
+	if err != nil {
+		return errors.Wrap(err, "定位封面帧失败")
+	}
+	switch v := res.Value.Num(); {
+	case v == -1:
+		return fmt.Errorf("封面时间 %.1f 秒超出视频时长", seconds)
+	case v == -2:
+		return fmt.Errorf("定位封面帧超时: 预览视频在 %s 内未加载或未完成定位", coverSeekTimeout)
+	}
+	time.Sleep(1 * time.Second)
+	logrus.Infof("已截取封面帧: %.1f 秒", seconds)
+	return nil
+}
@@ -0,0 +1,51 @@
+package platform
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"os"
+)
+
+// CoverSpec 视频封面尺寸要求
+type CoverSpec struct {
+	MinShortEdge int     // 短边最小像素
+	MinRatio     float64 // 宽高比下限
+	MaxRatio     float64 // 宽高比上限
+}
+
+// VideoCoverSpec 视频封面要求，各平台共用：短边至少 720 像素，宽高比覆盖竖版 9:16 到横版 16:9
+// 平台没有公开统一的封面尺寸下限，这里按 720p 视频的画面尺寸校验，更细的限制由平台页面提示
+var VideoCoverSpec = CoverSpec{MinShortEdge: 720, MinRatio: 9.0 / 16, MaxRatio: 16.0 / 9}
+
+// CheckCoverSize 读取封面图片尺寸并校验是否满足要求，支持 jpg、png、gif
+func CheckCoverSize(path string, spec CoverSpec) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("打开封面失败: %w", err)
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return fmt.Errorf("无法识别封面图片格式（支持 jpg/png/gif）: %w", err)
+	}
+	return spec.Check(cfg.Width, cfg.Height)
+}
+
+// Check 校验封面宽高
+func (s CoverSpec) Check(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("封面尺寸无效: %dx%d", width, height)
+	}
+	if min(width, height) < s.MinShortEdge {
+		return fmt.Errorf("封面尺寸 %dx%d 过小，短边至少 %d 像素", width, height, s.MinShortEdge)
+	}
+	ratio := float64(width) / float64(height)
+	if ratio < s.MinRatio || ratio > s.MaxRatio {
+		return fmt.Errorf("封面宽高比 %.2f 超出范围 %.2f~%.2f", ratio, s.MinRatio, s.MaxRatio)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package platform
+
+import (
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverSpecCheck(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		wantErr       bool
+	}{
+		{name: "竖版3:4", width: 1080, height: 1440},
+		{name: "竖版9:16", width: 1080, height: 1920},
+		{name: "横版16:9", width: 1920, height: 1080},
+		{name: "短边过小", width: 540, height: 960, wantErr: true},
+		{name: "过宽", width: 3000, height: 1000, wantErr: true},
+		{name: "过长", width: 720, height: 2000, wantErr: true},
+		{name: "无效尺寸", width: 0, height: 1080, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VideoCoverSpec.Check(tt.width, tt.height)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCheckCoverSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cover.png")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(f, image.NewRGBA(image.Rect(0, 0, 800, 450))))
+	require.NoError(t, f.Close())
+
+	assert.NoError(t, CheckCoverSize(path, CoverSpec{MinShortEdge: 370, MinRatio: 9.0 / 16, MaxRatio: 16.0 / 9}))
+	assert.Error(t, CheckCoverSize(path, VideoCoverSpec))
+	assert.Error(t, CheckCoverSize(filepath.Join(t.TempDir(), "missing.png"), VideoCoverSpec))
+}
@@ -0,0 +1,24 @@
+package platform
+
+import "testing"
+
+func TestVideoRequestValidateCover(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     VideoRequest
+		wantErr bool
+	}{
+		{name: "默认封面", req: VideoRequest{}},
+		{name: "上传封面", req: VideoRequest{CoverPath: "/tmp/cover.jpg"}},
+		{name: "截取封面", req: VideoRequest{CoverTime: 3.5}},
+		{name: "同时指定", req: VideoRequest{CoverPath: "/tmp/cover.jpg", CoverTime: 3}, wantErr: true},
+		{name: "负数时间", req: VideoRequest{CoverTime: -1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.ValidateCover(); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCover() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package platform
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// DraftIDFor 根据草稿的可见属性生成稳定的草稿ID
+// 部分平台的草稿没有对外暴露的ID，只能用标题、类型等属性组合标识
+func DraftIDFor(parts ...string) string {
+	sum := sha1.Sum([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// DraftID 优先使用页面上的草稿ID，没有时用标题、类型和封面地址生成
+// 不使用保存时间：草稿箱展示的是"刚刚""5分钟前"这类相对时间，同一草稿每次读取都可能不同
+func DraftID(domID, title, feedType, coverURL string) string {
+	if domID != "" {
+		return domID
+	}
+	return DraftIDFor(title, feedType, coverKey(coverURL))
+}
+
+// coverKey 去掉封面地址中会变化的部分：blob 地址每次加载都不同，CDN 地址的查询参数通常是签名
+func coverKey(url string) string {
+	if strings.HasPrefix(url, "blob:") {
+		return ""
+	}
+	if i := strings.IndexAny(url, "?#"); i >= 0 && !strings.HasPrefix(url, "data:") {
+		url = url[:i]
+	}
+	return url
+}
+
+// FindDraft 返回草稿ID对应的下标；多个草稿生成了相同的ID时无法区分，返回错误
+func FindDraft(items []DraftItem, draftID string) (int, error) {
+	found := -1
+	for i := range items {
+		if items[i].DraftID != draftID {
+			continue
+		}
+		if found >= 0 {
+			return -1, fmt.Errorf("草稿箱中有多个草稿对应ID %s，请修改标题后重新获取草稿列表", draftID)
+		}
+		found = i
+	}
+	if found < 0 {
+		return -1, fmt.Errorf("草稿箱中未找到草稿: %s", draftID)
+	}
+	return found, nil
+}
+
+// FindSavedDraft 保存草稿后按标题和类型找出刚保存的草稿
+// 草稿箱中有多个同名同类型的草稿时无法确定哪个是新保存的，返回 false
+func FindSavedDraft(items []DraftItem, title, feedType string) (DraftItem, bool) {
+	var (
+		match DraftItem
+		count int
+	)
+	for _, item := range items {
+		if item.Title == title && (feedType == "" || item.FeedType == feedType) {
+			match = item
+			count++
+		}
+	}
+	return match, count == 1
+}
@@ -0,0 +1,53 @@
+package platform
+
+import "testing"
+
+func TestDraftIDFor(t *testing.T) {
+	a := DraftIDFor("标题", "2026-01-01 10:00")
+	if len(a) != 12 {
+		t.Fatalf("draft id length = %d, want 12", len(a))
+	}
+	if a != DraftIDFor("标题", "2026-01-01 10:00") {
+		t.Fatal("draft id should be stable")
+	}
+	if a == DraftIDFor("标题2026-01-01", " 10:00") {
+		t.Fatal("draft id should separate parts")
+	}
+}
+
+func TestDraftIDIgnoresVolatileParts(t *testing.T) {
+	a := DraftID("", "标题", "video", "https://cdn.example.com/c.jpg?sign=1")
+	if a != DraftID("", "标题", "video", "https://cdn.example.com/c.jpg?sign=2") {
+		t.Fatal("draft id should ignore cover url signatures")
+	}
+	if a != DraftID("", "标题", "video", "https://cdn.example.com/c.jpg?sign=1") {
+		t.Fatal("draft id should be stable")
+	}
+	if DraftID("d1", "标题", "video", "") != "d1" {
+		t.Fatal("dom id should be used when present")
+	}
+}
+
+func TestFindDraft(t *testing.T) {
+	items := []DraftItem{
+		{DraftID: "a", Title: "标题", FeedType: "video"},
+		{DraftID: "b", Title: "标题", FeedType: "image_text"},
+		{DraftID: "b", Title: "标题", FeedType: "image_text"},
+	}
+	if i, err := FindDraft(items, "a"); err != nil || i != 0 {
+		t.Fatalf("FindDraft(a) = %d, %v", i, err)
+	}
+	if _, err := FindDraft(items, "b"); err == nil {
+		t.Fatal("duplicate draft ids should be rejected")
+	}
+	if _, err := FindDraft(items, "c"); err == nil {
+		t.Fatal("missing draft should be rejected")
+	}
+
+	if d, ok := FindSavedDraft(items, "标题", "video"); !ok || d.DraftID != "a" {
+		t.Fatalf("FindSavedDraft(video) = %+v, %v", d, ok)
+	}
+	if _, ok := FindSavedDraft(items, "标题", "image_text"); ok {
+		t.Fatal("ambiguous saved draft should not be matched")
+	}
+}
@@ -0,0 +1,154 @@
+package platform
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// ManagePage 内容管理页，编辑和删除都先在这里按内容ID找到卡片
+type ManagePage struct {
+	URL          string   // 内容管理页地址
+	IDAttrs      []string // 卡片上可能携带内容ID的 data 属性，属性值包含内容ID即可，如小红书 data-impression 中的 JSON
+	CardSelector string   // 卡片容器，为空时取最近的类名含 item 或 card 的元素
+}
+
+// OpenCard 打开内容管理页并滚动查找内容卡片
+func (m ManagePage) OpenCard(pp *rod.Page, feedID string) (*rod.Element, error) {
+	if err := pp.Navigate(m.URL); err != nil {
+		return nil, errors.Wrap(err, "导航到内容管理页面失败")
+	}
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	for i := 0; i < 20; i++ {
+		if card, err := m.FindCard(pp, feedID); err == nil {
+			return card, nil
+		}
+		pp.Mouse.MustScroll(0, 2000)
+		time.Sleep(1 * time.Second)
+	}
+	return nil, errors.Errorf("内容管理页中未找到内容: %s", feedID)
+}
+
+// FindCard 通过 data 属性、链接或图片地址中的内容ID定位当前已渲染的卡片
+func (m ManagePage) FindCard(pp *rod.Page, feedID string) (*rod.Element, error) {
+	cardSelector := m.CardSelector
+	if cardSelector == "" {
+		cardSelector = `[class*="item"], [class*="card"]`
+	}
+	return pp.Sleeper(rod.NotFoundSleeper).ElementByJS(rod.Eval(`(id, attrs, cardSelector) => {
+		for (const attr of attrs) {
+			const el = document.querySelector('[' + attr + '*="' + id + '"]');
+			if (el) return el.closest(cardSelector) || el;
+		}
+		const link = document.querySelector('a[href*="' + id + '"], img[src*="' + id + '"]');
+		if (link) return link.closest(cardSelector) || link;
+		return null;
+	}`, feedID, m.IDAttrs, cardSelector))
+}
+
+// WaitCardGone 删除后等待卡片从列表中消失
+func (m ManagePage) WaitCardGone(pp *rod.Page, feedID string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if _, err := m.FindCard(pp, feedID); err != nil {
+			return nil
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return errors.Errorf("删除后内容仍在列表中: %s", feedID)
+}
+
+// VerifyEdit 点击保存后先观察页面提示，再回到内容管理页核对卡片标题已更新
+// 列表中看不到正文，只修改正文时以页面提示为准
+func (m ManagePage) VerifyEdit(pp *rod.Page, feedID string, req *EditPostRequest) error {
+	state, message := WatchSubmitFeedback(pp, 15*time.Second, "", "")
+	logrus.Infof("保存修改提示: state=%s, message=%s", state, message)
+	if state == PublishStateFailed {
+		return errors.Errorf("保存修改失败: %s", message)
+	}
+
+	card, err := m.OpenCard(pp, feedID)
+	if err != nil {
+		return errors.Wrap(err, "保存后核对失败")
+	}
+	if req.Title == "" {
+		return nil
+	}
+
+	title := ""
+	if elem, err := card.Sleeper(rod.NotFoundSleeper).Element(`[class*="title"]`); err == nil {
+		title, _ = elem.Text()
+	}
+	if !titleMatches(title, strings.TrimSpace(req.Title)) {
+		return errors.Errorf("保存后标题未更新，当前标题: %s", strings.TrimSpace(title))
+	}
+	return nil
+}
+
+// ClickCardAction 点击卡片上的操作，找不到时尝试展开"更多"菜单
+func ClickCardAction(pp *rod.Page, card *rod.Element, pattern string) error {
+	if err := card.ScrollIntoView(); err != nil {
+		logrus.Warnf("滚动到内容卡片失败: %v", err)
+	}
+	if err := card.Hover(); err != nil {
+		logrus.Warnf("悬停内容卡片失败: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	action, err := card.Sleeper(rod.NotFoundSleeper).ElementR(`span, div, button, a`, pattern)
+	if err != nil {
+		more, merr := card.Sleeper(rod.NotFoundSleeper).ElementR(`span, div, button`, `^\s*更多\s*$`)
+		if merr != nil {
+			return errors.Errorf("未找到内容操作: %s", pattern)
+		}
+		if err := more.Hover(); err != nil {
+			logrus.Warnf("展开更多菜单失败: %v", err)
+		}
+		time.Sleep(500 * time.Millisecond)
+
+		// 下拉菜单通常渲染在 body 下
+		action, err = pp.Timeout(5*time.Second).ElementR(`[class*="dropdown"] *, [class*="popover"] *, [role="menu"] *`, pattern)
+		if err != nil {
+			return errors.Errorf("未找到内容操作: %s", pattern)
+		}
+	}
+
+	// 链接类操作通常在新标签页打开，直接在当前页导航
+	if href, err := action.Property("href"); err == nil && href.Str() != "" {
+		return pp.Navigate(href.Str())
+	}
+
+	return action.Click(proto.InputMouseButtonLeft, 1)
+}
+
+// FindFirstElement 返回第一个能找到的元素，都找不到时返回 nil
+func FindFirstElement(pp *rod.Page, selectors []string) *rod.Element {
+	for _, selector := range selectors {
+		has, elem, err := pp.Has(selector)
+		if err == nil && has && elem != nil {
+			return elem
+		}
+	}
+	return nil
+}
+
+// ClearEditable 全选后删除输入框或可编辑区域中的内容
+func ClearEditable(elem *rod.Element) error {
+	if err := elem.Focus(); err != nil {
+		return err
+	}
+	ka, err := elem.KeyActions()
+	if err != nil {
+		return err
+	}
+	return ka.Press(input.ControlLeft).Type(input.KeyA).Release(input.ControlLeft).Type(input.Backspace).Do()
+}
@@ -3,6 +3,7 @@ package platform
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/go-rod/rod"
@@ -14,6 +15,7 @@ type PlatformManager struct {
 	mu       sync.RWMutex
 	registry *PlatformRegistry
 	browser  *rod.Browser
+	confirms *confirmStore
 }
 
 // NewPlatformManager 创建平台管理器
@@ -22,6 +24,7 @@ func NewPlatformManager() *PlatformManager {
 		registry: &PlatformRegistry{
 			platforms: make(map[PlatformID]Platform),
 		},
+		confirms: newConfirmStore(DefaultConfirmTTL),
 	}
 }
 
@@ -95,6 +98,18 @@ func (pm *PlatformManager) PublishImageText(ctx context.Context, platformID Plat
 	if err != nil {
 		return nil, fmt.Errorf("获取平台失败: %w", err)
 	}
+	if err := req.ValidateImages(platformID); err != nil {
+		return nil, err
+	}
+	if err := req.PublishSettings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.ContentFormat.Validate(); err != nil {
+		return nil, err
+	}
+	if req.ContentFormat.IsRich() && !p.GetPlatformConfig().Features.SupportRichText {
+		return nil, fmt.Errorf("%w: %s", ErrRichTextUnsupported, p.Name())
+	}
 	
 	logrus.Infof("开始发布图文到平台: %s", p.Name())
 	return p.PublishImageText(ctx, page, req)
@@ -106,11 +121,41 @@ func (pm *PlatformManager) PublishVideo(ctx context.Context, platformID Platform
 	if err != nil {
 		return nil, fmt.Errorf("获取平台失败: %w", err)
 	}
+	if err := req.PublishSettings.Validate(); err != nil {
+		return nil, err
+	}
+	if err := req.ValidateCover(); err != nil {
+		return nil, err
+	}
 	
 	logrus.Infof("开始发布视频到平台: %s", p.Name())
 	return p.PublishVideo(ctx, page, req)
 }
 
+// ListDrafts 获取草稿列表
+func (pm *PlatformManager) ListDrafts(ctx context.Context, platformID PlatformID, page *rod.Page) ([]DraftItem, error) {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return nil, fmt.Errorf("获取平台失败: %w", err)
+	}
+
+	return p.ListDrafts(ctx, page)
+}
+
+// PublishDraft 发布草稿
+func (pm *PlatformManager) PublishDraft(ctx context.Context, platformID PlatformID, page *rod.Page, draftID string) (*PublishResponse, error) {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return nil, fmt.Errorf("获取平台失败: %w", err)
+	}
+	if draftID == "" {
+		return nil, fmt.Errorf("草稿ID不能为空")
+	}
+
+	logrus.Infof("开始发布草稿到平台: %s, draft_id=%s", p.Name(), draftID)
+	return p.PublishDraft(ctx, page, draftID)
+}
+
 // GetFeeds 获取内容列表
 func (pm *PlatformManager) GetFeeds(ctx context.Context, platformID PlatformID, page *rod.Page, req *GetFeedsRequest) (*GetFeedsResponse, error) {
 	p, err := pm.GetPlatform(platformID)
@@ -131,6 +176,145 @@ func (pm *PlatformManager) GetFeedDetail(ctx context.Context, platformID Platfor
 	return p.GetFeedDetail(ctx, page, feedID)
 }
 
+// EditPost 编辑已发布的内容
+func (pm *PlatformManager) EditPost(ctx context.Context, platformID PlatformID, page *rod.Page, feedID string, req *EditPostRequest) error {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return fmt.Errorf("获取平台失败: %w", err)
+	}
+	if req.IsEmpty() {
+		return fmt.Errorf("没有需要修改的内容")
+	}
+
+	logrus.Infof("开始编辑平台内容: %s, feed_id=%s", p.Name(), feedID)
+	return p.EditPost(ctx, page, feedID, req)
+}
+
+// RequestDeleteConfirmation 签发删除确认令牌
+func (pm *PlatformManager) RequestDeleteConfirmation(platformID PlatformID, feedID string) (*DeleteConfirmation, error) {
+	if _, err := pm.GetPlatform(platformID); err != nil {
+		return nil, fmt.Errorf("获取平台失败: %w", err)
+	}
+	if feedID == "" {
+		return nil, fmt.Errorf("内容ID不能为空")
+	}
+
+	return pm.confirms.Issue(platformID, feedID)
+}
+
+// DeletePost 校验确认令牌后删除已发布的内容
+func (pm *PlatformManager) DeletePost(ctx context.Context, platformID PlatformID, page *rod.Page, feedID, confirmToken string) error {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return fmt.Errorf("获取平台失败: %w", err)
+	}
+	if err := pm.confirms.Consume(platformID, feedID, confirmToken); err != nil {
+		return err
+	}
+
+	logrus.Warnf("开始删除平台内容: %s, feed_id=%s", p.Name(), feedID)
+	return p.DeletePost(ctx, page, feedID)
+}
+
+// Like 点赞内容
+func (pm *PlatformManager) Like(ctx context.Context, platformID PlatformID, page *rod.Page, feedID string) error {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return fmt.Errorf("获取平台失败: %w", err)
+	}
+
+	return p.Like(ctx, page, feedID)
+}
+
+// Comment 评论内容
+func (pm *PlatformManager) Comment(ctx context.Context, platformID PlatformID, page *rod.Page, feedID string, content string, mentions []string) error {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return fmt.Errorf("获取平台失败: %w", err)
+	}
+
+	return p.Comment(ctx, page, feedID, content, mentions)
+}
+
+// Collect 收藏内容
+func (pm *PlatformManager) Collect(ctx context.Context, platformID PlatformID, page *rod.Page, feedID string) error {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return fmt.Errorf("获取平台失败: %w", err)
+	}
+
+	return p.Collect(ctx, page, feedID)
+}
+
+// messagePlatform 获取支持私信的平台
+func (pm *PlatformManager) messagePlatform(platformID PlatformID) (Platform, error) {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return nil, fmt.Errorf("获取平台失败: %w", err)
+	}
+	if !p.GetPlatformConfig().Features.SupportMessages {
+		return nil, fmt.Errorf("%w: %s", ErrMessagesUnsupported, p.Name())
+	}
+	return p, nil
+}
+
+// ListConversations 获取私信会话列表
+func (pm *PlatformManager) ListConversations(ctx context.Context, platformID PlatformID, page *rod.Page) ([]Conversation, error) {
+	p, err := pm.messagePlatform(platformID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ListConversations(ctx, page)
+}
+
+// GetMessages 获取会话的消息记录
+func (pm *PlatformManager) GetMessages(ctx context.Context, platformID PlatformID, page *rod.Page, conversationID string) ([]Message, error) {
+	p, err := pm.messagePlatform(platformID)
+	if err != nil {
+		return nil, err
+	}
+	if conversationID == "" {
+		return nil, fmt.Errorf("会话ID不能为空")
+	}
+
+	return p.GetMessages(ctx, page, conversationID)
+}
+
+// SendMessage 发送私信
+func (pm *PlatformManager) SendMessage(ctx context.Context, platformID PlatformID, page *rod.Page, conversationID, content string) error {
+	p, err := pm.messagePlatform(platformID)
+	if err != nil {
+		return err
+	}
+	if conversationID == "" {
+		return fmt.Errorf("会话ID不能为空")
+	}
+	if content == "" {
+		return fmt.Errorf("私信内容不能为空")
+	}
+
+	logrus.Infof("发送私信: %s, conversation_id=%s", p.Name(), conversationID)
+	return p.SendMessage(ctx, page, conversationID, content)
+}
+
+// SearchTopics 查询平台已有话题
+func (pm *PlatformManager) SearchTopics(ctx context.Context, platformID PlatformID, page *rod.Page, keyword string) ([]Topic, error) {
+	p, err := pm.GetPlatform(platformID)
+	if err != nil {
+		return nil, fmt.Errorf("获取平台失败: %w", err)
+	}
+	if !p.GetPlatformConfig().Features.SupportTopicSearch {
+		return nil, fmt.Errorf("%w: %s", ErrTopicSearchUnsupported, p.Name())
+	}
+	keyword = strings.TrimSpace(strings.TrimLeft(keyword, "#"))
+	if keyword == "" {
+		return nil, fmt.Errorf("关键词不能为空")
+	}
+
+	return p.SearchTopics(ctx, page, keyword)
+}
+
 // 全局平台管理器实例
 var globalManager *PlatformManager
 var once sync.Once
@@ -0,0 +1,27 @@
+package platform
+
+import "strings"
+
+// mentionPrefixes 提及候选中昵称以外的账号号码前缀
+var mentionPrefixes = []string{"小红书号：", "小红书号:", "抖音号：", "抖音号:", "@"}
+
+// MatchMention 在提及选择器的候选文本中查找与 name 匹配的下标，找不到时返回 -1
+// 候选文本通常为 "昵称\n小红书号：xxx\n粉丝 1.2万"，昵称或账号号码任一行相同（忽略大小写）即视为匹配
+func MatchMention(candidates []string, name string) int {
+	name = strings.TrimSpace(strings.TrimLeft(name, "@"))
+	if name == "" {
+		return -1
+	}
+	for i, text := range candidates {
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(line)
+			for _, prefix := range mentionPrefixes {
+				line = strings.TrimSpace(strings.TrimPrefix(line, prefix))
+			}
+			if strings.EqualFold(line, name) {
+				return i
+			}
+		}
+	}
+	return -1
+}
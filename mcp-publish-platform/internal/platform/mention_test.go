@@ -0,0 +1,31 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchMention(t *testing.T) {
+	candidates := []string{
+		"小明同学\n小红书号：12345\n粉丝 1.2万",
+		"小明\n小红书号：xm_2024",
+		"Alice\n抖音号: alice01",
+	}
+
+	tests := []struct {
+		name string
+		want int
+	}{
+		{name: "小明", want: 1},
+		{name: "@小明", want: 1},
+		{name: "12345", want: 0},
+		{name: "alice", want: 2},
+		{name: "alice01", want: 2},
+		{name: "小", want: -1},
+		{name: "", want: -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, MatchMention(candidates, tt.name), tt.name)
+	}
+}
@@ -0,0 +1,12 @@
+package platform
+
+import "errors"
+
+// ErrMessagesUnsupported 平台不支持私信
+var ErrMessagesUnsupported = errors.New("平台不支持私信")
+
+// ConversationIDFor 根据会话的可见属性生成稳定的会话ID
+// 私信会话列表通常不暴露会话ID，只能用对方昵称、用户ID等属性组合标识
+func ConversationIDFor(parts ...string) string {
+	return DraftIDFor(parts...)
+}
@@ -38,6 +38,12 @@ type Platform interface {
 	// PublishVideo 发布视频内容
 	PublishVideo(ctx context.Context, page *rod.Page, req *VideoRequest) (*PublishResponse, error)
 	
+	// ListDrafts 获取草稿列表
+	ListDrafts(ctx context.Context, page *rod.Page) ([]DraftItem, error)
+	
+	// PublishDraft 发布指定草稿
+	PublishDraft(ctx context.Context, page *rod.Page, draftID string) (*PublishResponse, error)
+	
 	// ========== 内容管理 ==========
 	
 	// GetFeeds 获取内容列表
@@ -46,17 +52,39 @@ type Platform interface {
 	// GetFeedDetail 获取内容详情
 	GetFeedDetail(ctx context.Context, page *rod.Page, feedID string) (*FeedDetail, error)
 	
+	// EditPost 编辑已发布的内容
+	EditPost(ctx context.Context, page *rod.Page, feedID string, req *EditPostRequest) error
+	
+	// DeletePost 删除已发布的内容
+	DeletePost(ctx context.Context, page *rod.Page, feedID string) error
+	
 	// ========== 互动功能 ==========
 	
 	// Like 点赞
 	Like(ctx context.Context, page *rod.Page, feedID string) error
 	
-	// Comment 评论
-	Comment(ctx context.Context, page *rod.Page, feedID string, content string) error
+	// Comment 评论，mentions 为评论中 @提及的用户昵称
+	Comment(ctx context.Context, page *rod.Page, feedID string, content string, mentions []string) error
 	
 	// Collect 收藏
 	Collect(ctx context.Context, page *rod.Page, feedID string) error
 	
+	// ========== 私信 ==========
+	
+	// ListConversations 获取私信会话列表
+	ListConversations(ctx context.Context, page *rod.Page) ([]Conversation, error)
+	
+	// GetMessages 获取会话的消息记录
+	GetMessages(ctx context.Context, page *rod.Page, conversationID string) ([]Message, error)
+	
+	// SendMessage 在会话中发送文本消息
+	SendMessage(ctx context.Context, page *rod.Page, conversationID string, content string) error
+	
+	// ========== 话题 ==========
+	
+	// SearchTopics 通过平台的话题联想查询关键词对应的已有话题
+	SearchTopics(ctx context.Context, page *rod.Page, keyword string) ([]Topic, error)
+	
 	// ========== 平台配置 ==========
 	
 	// GetPlatformConfig 获取平台配置
@@ -0,0 +1,15 @@
+package platform
+
+import "github.com/go-rod/rod"
+
+// ProgressText 拼接页面上进度元素的文字，供 ProgressTracker 解析；读取失败时返回空字符串
+func ProgressText(page *rod.Page, selector string) string {
+	res, err := page.Eval(`(selector) => Array.from(document.querySelectorAll(selector))
+		.map((el) => el.innerText.trim())
+		.filter((t) => t)
+		.join(' ')`, selector)
+	if err != nil {
+		return ""
+	}
+	return res.Value.Str()
+}
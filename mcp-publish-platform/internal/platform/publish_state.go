@@ -0,0 +1,45 @@
+package platform
+
+import "strings"
+
+// PublishState 提交发布后的状态
+type PublishState string
+
+const (
+	PublishStatePublished PublishState = "published" // 已发布
+	PublishStateReviewing PublishState = "reviewing" // 审核中
+	PublishStateFailed    PublishState = "failed"    // 发布失败或审核未通过
+	PublishStateUnknown   PublishState = "unknown"   // 没有观察到可判断的结果
+)
+
+// 提示文案关键词，按失败、审核中、成功的顺序匹配，
+// 如 "提交成功，等待审核" 应判断为审核中，"审核未通过" 应判断为失败
+var (
+	publishFailedKeywords    = []string{"失败", "未通过", "不通过", "违规", "违反", "不符合", "错误", "异常", "频繁", "超出", "超过", "不能为空", "请上传", "请填写", "请先"}
+	publishReviewingKeywords = []string{"审核"}
+	publishSuccessKeywords   = []string{"发布成功", "发表成功", "提交成功", "已发布", "已发表"}
+)
+
+// ClassifyPublishMessage 根据平台的提示文案或作品状态文案判断发布状态，无法判断时返回 PublishStateUnknown
+func ClassifyPublishMessage(text string) PublishState {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return PublishStateUnknown
+	}
+	for _, kw := range publishFailedKeywords {
+		if strings.Contains(text, kw) {
+			return PublishStateFailed
+		}
+	}
+	for _, kw := range publishReviewingKeywords {
+		if strings.Contains(text, kw) {
+			return PublishStateReviewing
+		}
+	}
+	for _, kw := range publishSuccessKeywords {
+		if strings.Contains(text, kw) {
+			return PublishStatePublished
+		}
+	}
+	return PublishStateUnknown
+}
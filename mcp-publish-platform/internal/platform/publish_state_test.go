@@ -0,0 +1,28 @@
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyPublishMessage(t *testing.T) {
+	tests := []struct {
+		text string
+		want PublishState
+	}{
+		{text: "发布成功", want: PublishStatePublished},
+		{text: "已发布\n2024-01-20 10:30", want: PublishStatePublished},
+		{text: "提交成功，等待审核", want: PublishStateReviewing},
+		{text: "审核中", want: PublishStateReviewing},
+		{text: "审核未通过：内容涉嫌违规", want: PublishStateFailed},
+		{text: "发布失败，请稍后重试", want: PublishStateFailed},
+		{text: "操作过于频繁", want: PublishStateFailed},
+		{text: "标题不能为空", want: PublishStateFailed},
+		{text: "正在上传", want: PublishStateUnknown},
+		{text: "", want: PublishStateUnknown},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ClassifyPublishMessage(tt.text), tt.text)
+	}
+}
@@ -0,0 +1,35 @@
+package platform
+
+import "fmt"
+
+// Visibility 作品可见范围
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"  // 公开
+	VisibilityPrivate Visibility = "private" // 仅自己可见
+	VisibilityFriends Visibility = "friends" // 好友（互关）可见
+)
+
+// PublishSettings 发布页的附加设置，零值表示保持发布页默认
+type PublishSettings struct {
+	Location   string     `json:"location,omitempty"`   // 地点关键词，选择地点搜索的第一个结果
+	Visibility Visibility `json:"visibility,omitempty"` // 可见范围：public|private|friends，默认公开
+	Collection string     `json:"collection,omitempty"` // 加入的合集名称，需已在平台创建
+	Original   bool       `json:"original,omitempty"`   // 声明原创
+}
+
+// IsZero 是否没有任何附加设置
+func (s PublishSettings) IsZero() bool {
+	return s == PublishSettings{}
+}
+
+// Validate 校验可见范围取值
+func (s PublishSettings) Validate() error {
+	switch s.Visibility {
+	case "", VisibilityPublic, VisibilityPrivate, VisibilityFriends:
+		return nil
+	default:
+		return fmt.Errorf("不支持的可见范围: %s，可选 public|private|friends", s.Visibility)
+	}
+}
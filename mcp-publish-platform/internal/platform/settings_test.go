@@ -0,0 +1,23 @@
+package platform
+
+import "testing"
+
+func TestPublishSettingsValidate(t *testing.T) {
+	for _, v := range []Visibility{"", VisibilityPublic, VisibilityPrivate, VisibilityFriends} {
+		if err := (PublishSettings{Visibility: v}).Validate(); err != nil {
+			t.Errorf("Validate(%q) = %v, want nil", v, err)
+		}
+	}
+	if err := (PublishSettings{Visibility: "secret"}).Validate(); err == nil {
+		t.Error("Validate(secret) = nil, want error")
+	}
+}
+
+func TestPublishSettingsIsZero(t *testing.T) {
+	if !(PublishSettings{}).IsZero() {
+		t.Error("empty settings should be zero")
+	}
+	if (PublishSettings{Original: true}).IsZero() {
+		t.Error("settings with original should not be zero")
+	}
+}
@@ -0,0 +1,21 @@
+package platform
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrTopicSearchUnsupported 平台不支持话题查询
+var ErrTopicSearchUnsupported = errors.New("平台不支持话题查询")
+
+// FindTopic 返回与标签同名（忽略#和大小写）的话题下标，找不到时返回 -1
+// 话题联想通常按热度排序，排在首位的不一定与标签同名，只有同名才视为标签对应的已有话题
+func FindTopic(topics []Topic, tag string) int {
+	tag = strings.TrimSpace(strings.TrimLeft(tag, "#"))
+	for i, t := range topics {
+		if strings.EqualFold(strings.TrimLeft(t.Name, "#"), tag) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,23 @@
+package platform
+
+import "testing"
+
+func TestFindTopic(t *testing.T) {
+	topics := []Topic{{Name: "美食探店"}, {Name: "#美食"}, {Name: "Vlog"}}
+
+	tests := []struct {
+		tag  string
+		want int
+	}{
+		{"美食", 1},
+		{"#美食", 1},
+		{"vlog", 2},
+		{"旅行", -1},
+		{"", -1},
+	}
+	for _, tt := range tests {
+		if got := FindTopic(topics, tt.tag); got != tt.want {
+			t.Errorf("FindTopic(%q) = %d, want %d", tt.tag, got, tt.want)
+		}
+	}
+}
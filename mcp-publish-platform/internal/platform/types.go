@@ -1,35 +1,64 @@
 package platform
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+)
 
 // ========== 发布相关类型 ==========
 
 // ImageTextRequest 图文发布请求
 type ImageTextRequest struct {
-	Title      string   `json:"title" binding:"required"`       // 标题（必填）
-	Content    string   `json:"content" binding:"required"`     // 内容（必填）
-	Images     []string `json:"images" binding:"required,min=1"` // 图片列表（必填，至少1张）
-	Tags       []string `json:"tags,omitempty"`                 // 标签列表（可选）
-	ScheduleAt string   `json:"schedule_at,omitempty"`          // 定时发布时间 ISO8601（可选）
+	Title              string        `json:"title" binding:"required"`        // 标题（必填）
+	Content            string        `json:"content" binding:"required"`      // 内容（必填）
+	ContentFormat      ContentFormat `json:"content_format,omitempty"`        // 正文格式：text|markdown|html（可选，富文本仅部分平台支持）
+	Images             []string      `json:"images"`                          // 图片列表（至少1张，今日头条微头条可为空）
+	Tags               []string      `json:"tags,omitempty"`                  // 标签列表（可选）
+	ScheduleAt         string        `json:"schedule_at,omitempty"`           // 定时发布时间 ISO8601（可选）
+	Draft              bool          `json:"draft,omitempty"`                 // 仅保存草稿，不发布（可选）
+	ExistingTopicsOnly bool          `json:"existing_topics_only,omitempty"`  // 只使用能匹配到已有话题的标签（可选）
+	Mentions           []string      `json:"mentions,omitempty"`              // 正文中 @提及的用户昵称（可选）
+	Micro              bool          `json:"micro,omitempty"`                 // 今日头条：发布为微头条而不是文章（可选）
+	Music              string        `json:"music,omitempty"`                 // 抖音：背景音乐搜索关键词，使用第一个搜索结果（可选）
+	PublishSettings                  // 地点、可见范围、合集、原创声明（可选）
+}
+
+// ValidateImages 校验图片数量：只有今日头条微头条允许纯文字发布
+func (r *ImageTextRequest) ValidateImages(platformID PlatformID) error {
+	if len(r.Images) == 0 && !(r.Micro && platformID == PlatformToutiao) {
+		return fmt.Errorf("至少需要1张图片")
+	}
+	return nil
 }
 
 // VideoRequest 视频发布请求
 type VideoRequest struct {
-	Title       string   `json:"title" binding:"required"`        // 标题（必填）
-	Description string   `json:"description" binding:"required"`  // 描述（必填）
-	VideoPath   string   `json:"video_path" binding:"required"`   // 视频文件路径（必填）
-	CoverPath   string   `json:"cover_path,omitempty"`            // 封面图路径（可选）
-	Tags        []string `json:"tags,omitempty"`                  // 标签列表（可选）
-	ScheduleAt  string   `json:"schedule_at,omitempty"`           // 定时发布时间（可选）
+	Title              string   `json:"title" binding:"required"`       // 标题（必填）
+	Description        string   `json:"description" binding:"required"` // 描述（必填）
+	VideoPath          string   `json:"video_path" binding:"required"`  // 视频文件路径（必填）
+	CoverPath          string   `json:"cover_path,omitempty"`           // 封面图路径（可选）
+	CoverTime          float64  `json:"cover_time,omitempty"`           // 截取视频第几秒的画面作为封面（可选，与 cover_path 二选一）
+	Tags               []string `json:"tags,omitempty"`                 // 标签列表（可选）
+	ScheduleAt         string   `json:"schedule_at,omitempty"`          // 定时发布时间（可选）
+	Draft              bool     `json:"draft,omitempty"`                // 仅保存草稿，不发布（可选）
+	ExistingTopicsOnly bool     `json:"existing_topics_only,omitempty"` // 只使用能匹配到已有话题的标签（可选）
+	Mentions           []string `json:"mentions,omitempty"`             // 正文中 @提及的用户昵称（可选）
+	PublishSettings             // 地点、可见范围、合集、原创声明（可选）
+
+	// Progress 上传和转码进度回调，由 MCP 进度通知或 SSE 接口设置，不参与 JSON 绑定
+	Progress ProgressFunc `json:"-"`
 }
 
 // PublishResponse 发布响应
 type PublishResponse struct {
-	Success  bool   `json:"success"`              // 是否成功
-	FeedID   string `json:"feed_id,omitempty"`    // 内容ID
-	FeedURL  string `json:"feed_url,omitempty"`   // 内容URL
-	Error    string `json:"error,omitempty"`      // 错误信息
-	Message  string `json:"message,omitempty"`    // 提示信息
+	Success bool   `json:"success"`            // 是否成功
+	Status  string `json:"status,omitempty"`   // 发布状态：published|reviewing|failed|unknown
+	FeedID  string `json:"feed_id,omitempty"`  // 内容ID
+	FeedURL string `json:"feed_url,omitempty"` // 内容URL
+	Error   string `json:"error,omitempty"`    // 错误信息
+	Message string `json:"message,omitempty"`  // 提示信息
+	DraftID string `json:"draft_id,omitempty"` // 草稿ID（保存草稿时返回）
 }
 
 // ========== 内容管理相关类型 ==========
@@ -63,7 +92,7 @@ type FeedItem struct {
 	CommentCount int       `json:"comment_count"`  // 评论数
 	ShareCount   int       `json:"share_count"`    // 分享数
 	CollectCount int       `json:"collect_count"`  // 收藏数
-	Status       string    `json:"status"`         // 状态：published, draft, deleted
+	Status       string    `json:"status"`         // 状态：published, reviewing, rejected, draft, deleted
 }
 
 // FeedDetail 内容详情
@@ -88,6 +117,60 @@ type FeedMetrics struct {
 	ForwardCount int `json:"forward_count"`  // 转发数
 }
 
+// EditPostRequest 编辑已发布内容请求，未填写的字段保持不变
+type EditPostRequest struct {
+	Title   string   `json:"title,omitempty"`   // 新标题（可选）
+	Content string   `json:"content,omitempty"` // 新正文/描述（可选）
+	Tags    []string `json:"tags,omitempty"`    // 新标签列表（可选，需同时提供正文）
+}
+
+// IsEmpty 是否没有任何需要修改的字段
+func (r *EditPostRequest) IsEmpty() bool {
+	return r.Title == "" && r.Content == "" && len(r.Tags) == 0
+}
+
+// DeletePostRequest 删除已发布内容请求
+type DeletePostRequest struct {
+	ConfirmToken string `json:"confirm_token" form:"confirm_token"` // 删除确认令牌，为空时返回新令牌
+}
+
+// DraftItem 草稿项
+type DraftItem struct {
+	DraftID   string `json:"draft_id"`   // 草稿ID
+	FeedType  string `json:"feed_type"`  // 内容类型：image_text, video, article
+	Title     string `json:"title"`      // 标题
+	CoverURL  string `json:"cover_url"`  // 封面URL
+	UpdatedAt string `json:"updated_at"` // 最后保存时间（平台展示文本）
+}
+
+// ========== 私信相关类型 ==========
+
+// Conversation 私信会话
+type Conversation struct {
+	ConversationID string `json:"conversation_id"`        // 会话ID
+	UserID         string `json:"user_id,omitempty"`      // 对方用户ID（平台提供时）
+	Nickname       string `json:"nickname"`               // 对方昵称
+	Avatar         string `json:"avatar,omitempty"`       // 对方头像
+	LastMessage    string `json:"last_message,omitempty"` // 最后一条消息
+	LastTime       string `json:"last_time,omitempty"`    // 最后消息时间（平台展示文本）
+	UnreadCount    int    `json:"unread_count"`           // 未读数
+}
+
+// Message 私信消息
+type Message struct {
+	MessageID string `json:"message_id,omitempty"` // 消息ID（平台提供时）
+	FromMe    bool   `json:"from_me"`              // 是否为自己发送
+	Sender    string `json:"sender,omitempty"`     // 发送者昵称
+	Content   string `json:"content"`              // 文本内容，图片消息为图片地址
+	Type      string `json:"type"`                 // 消息类型：text, image, card
+	SentAt    string `json:"sent_at,omitempty"`    // 发送时间（平台展示文本）
+}
+
+// SendMessageRequest 发送私信请求
+type SendMessageRequest struct {
+	Content string `json:"content" binding:"required"` // 文本内容（必填）
+}
+
 // ========== 平台配置类型 ==========
 
 // PlatformConfig 平台配置
@@ -107,11 +190,24 @@ type PlatformConfig struct {
 
 // PlatformFeatures 平台功能特性
 type PlatformFeatures struct {
-	SupportImageText bool `json:"support_image_text"` // 支持图文
-	SupportVideo     bool `json:"support_video"`      // 支持视频
-	SupportSchedule  bool `json:"support_schedule"`   // 支持定时发布
-	SupportTags      bool `json:"support_tags"`       // 支持标签
-	SupportComment   bool `json:"support_comment"`    // 支持评论
-	SupportLike      bool `json:"support_like"`       // 支持点赞
-	SupportCollect   bool `json:"support_collect"`    // 支持收藏
+	SupportImageText   bool `json:"support_image_text"`   // 支持图文
+	SupportVideo       bool `json:"support_video"`        // 支持视频
+	SupportSchedule    bool `json:"support_schedule"`     // 支持定时发布
+	SupportTags        bool `json:"support_tags"`         // 支持标签
+	SupportComment     bool `json:"support_comment"`      // 支持评论
+	SupportLike        bool `json:"support_like"`         // 支持点赞
+	SupportCollect     bool `json:"support_collect"`      // 支持收藏
+	SupportDraft       bool `json:"support_draft"`        // 支持草稿
+	SupportMessages    bool `json:"support_messages"`     // 支持私信
+	SupportTopicSearch bool `json:"support_topic_search"` // 支持话题查询
+	SupportRichText    bool `json:"support_rich_text"`    // 支持富文本正文（Markdown/HTML）
+}
+
+// ========== 话题相关类型 ==========
+
+// Topic 平台话题联想候选
+type Topic struct {
+	Name      string `json:"name"`                 // 话题名称（不含#）
+	Views     int    `json:"views"`                // 浏览/播放量，无法解析时为 0
+	ViewsText string `json:"views_text,omitempty"` // 平台展示的原始文案
 }
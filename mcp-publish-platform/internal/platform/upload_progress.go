@@ -0,0 +1,203 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// UploadPhase 视频发布的阶段
+type UploadPhase string
+
+const (
+	UploadPhaseUploading  UploadPhase = "uploading"  // 上传中
+	UploadPhaseProcessing UploadPhase = "processing" // 平台转码、检测中
+	UploadPhaseDone       UploadPhase = "done"       // 上传和处理完成
+)
+
+// 整体进度中上传阶段占 0-80，处理阶段占 80-99，完成为 100
+const (
+	uploadWeight     = 80
+	processingWeight = 19
+)
+
+var (
+	progressPercentPattern = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)\s*%`)
+	processingKeywords     = []string{"转码", "处理中", "检测中", "解析中", "生成中"}
+)
+
+// UploadProgress 一次进度事件
+type UploadProgress struct {
+	Phase      UploadPhase `json:"phase"`
+	Percent    float64     `json:"percent"`               // 当前阶段的百分比，页面上没有百分比时为 -1
+	BytesSent  int64       `json:"bytes_sent,omitempty"`  // 按上传百分比估算的已发送字节数
+	BytesTotal int64       `json:"bytes_total,omitempty"` // 视频文件大小
+	Text       string      `json:"text,omitempty"`        // 页面上的原始进度文案
+}
+
+// Overall 将阶段和百分比换算为单调递增的整体进度（0-100）
+func (p UploadProgress) Overall() float64 {
+	percent := p.Percent
+	if percent < 0 {
+		percent = 0
+	}
+	switch p.Phase {
+	case UploadPhaseUploading:
+		return percent * uploadWeight / 100
+	case UploadPhaseProcessing:
+		return uploadWeight + percent*processingWeight/100
+	default:
+		return 100
+	}
+}
+
+// String 进度提示，如 "上传中 45% (12.3MB/27.3MB)"
+func (p UploadProgress) String() string {
+	var b strings.Builder
+	switch p.Phase {
+	case UploadPhaseUploading:
+		b.WriteString("上传中")
+	case UploadPhaseProcessing:
+		b.WriteString("处理中")
+	default:
+		b.WriteString("上传完成")
+	}
+	if p.Phase != UploadPhaseDone && p.Percent >= 0 {
+		b.WriteString(" " + strconv.FormatFloat(p.Percent, 'f', -1, 64) + "%")
+	}
+	if p.Phase == UploadPhaseUploading && p.BytesTotal > 0 {
+		fmt.Fprintf(&b, " (%.1fMB/%.1fMB)", float64(p.BytesSent)/(1<<20), float64(p.BytesTotal)/(1<<20))
+	}
+	return b.String()
+}
+
+// ProgressFunc 接收进度事件的回调，为 nil 时不上报
+type ProgressFunc func(UploadProgress)
+
+// ParseProgressPercent 从页面文案中解析百分比，如 "上传中 45%"、"转码 12.5 %"
+func ParseProgressPercent(text string) (float64, bool) {
+	m := progressPercentPattern.FindStringSubmatch(text)
+	if m == nil {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(m[1], 64)
+	if err != nil || v > 100 {
+		return 0, false
+	}
+	return v, true
+}
+
+// ProgressTracker 把页面上的进度文案转换为进度事件
+// 只在阶段或百分比变化时回调，且整体进度不会倒退
+type ProgressTracker struct {
+	report  ProgressFunc
+	total   int64
+	mu      sync.Mutex
+	last    UploadProgress
+	started bool
+}
+
+// NewProgressTracker 创建进度跟踪器，文件大小用于估算已发送字节数
+func NewProgressTracker(videoPath string, report ProgressFunc) *ProgressTracker {
+	t := &ProgressTracker{report: report}
+	if info, err := os.Stat(videoPath); err == nil {
+		t.total = info.Size()
+	}
+	return t
+}
+
+// Start 上报上传开始
+func (t *ProgressTracker) Start() {
+	t.emit(UploadProgress{Phase: UploadPhaseUploading, Percent: 0})
+}
+
+// Update 解析页面上的进度文案，文案中有转码、处理等字样时进入处理阶段
+func (t *ProgressTracker) Update(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	phase := UploadPhaseUploading
+	for _, kw := range processingKeywords {
+		if strings.Contains(text, kw) {
+			phase = UploadPhaseProcessing
+			break
+		}
+	}
+
+	percent, ok := ParseProgressPercent(text)
+	if !ok {
+		if phase == UploadPhaseUploading {
+			return
+		}
+		percent = -1
+	}
+	t.emit(UploadProgress{Phase: phase, Percent: percent, Text: text})
+}
+
+// Done 上报上传和处理完成
+func (t *ProgressTracker) Done() {
+	t.emit(UploadProgress{Phase: UploadPhaseDone, Percent: 100})
+}
+
+// Watch 每隔 interval 调用 read 读取进度文案，返回的 stop 会等待后台读取结束
+func (t *ProgressTracker) Watch(read func() string, interval time.Duration) (stop func()) {
+	done := make(chan struct{})
+	exited := make(chan struct{})
+	go func() {
+		defer close(exited)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				t.Update(read())
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-exited
+		})
+	}
+}
+
+func (t *ProgressTracker) emit(p UploadProgress) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.started {
+		if p.Overall() < t.last.Overall() {
+			return
+		}
+		if p.Phase == t.last.Phase && p.Percent == t.last.Percent {
+			return
+		}
+	}
+
+	p.BytesTotal = t.total
+	switch p.Phase {
+	case UploadPhaseUploading:
+		if p.Percent > 0 {
+			p.BytesSent = int64(float64(t.total) * p.Percent / 100)
+		}
+	default:
+		p.BytesSent = t.total
+	}
+
+	t.started = true
+	t.last = p
+	if t.report != nil {
+		t.report(p)
+	}
+}
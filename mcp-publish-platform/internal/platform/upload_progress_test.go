@@ -0,0 +1,82 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProgressPercent(t *testing.T) {
+	tests := []struct {
+		text string
+		want float64
+		ok   bool
+	}{
+		{text: "上传中 45%", want: 45, ok: true},
+		{text: "转码 12.5 %", want: 12.5, ok: true},
+		{text: "100%", want: 100, ok: true},
+		{text: "上传中", ok: false},
+		{text: "250%", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := ParseProgressPercent(tt.text)
+		assert.Equal(t, tt.ok, ok, tt.text)
+		assert.Equal(t, tt.want, got, tt.text)
+	}
+}
+
+func TestProgressTracker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "v.mp4")
+	require.NoError(t, os.WriteFile(path, make([]byte, 1000), 0o644))
+
+	var events []UploadProgress
+	tr := NewProgressTracker(path, func(p UploadProgress) { events = append(events, p) })
+
+	tr.Start()
+	tr.Update("上传中 40%")
+	tr.Update("上传中 40%") // 未变化不上报
+	tr.Update("正在上传")    // 没有百分比不上报
+	tr.Update("上传中 30%") // 进度倒退不上报
+	tr.Update("视频转码中 50%")
+	tr.Update("上传中 90%") // 已进入处理阶段，不再回到上传
+	tr.Done()
+
+	require.Len(t, events, 4)
+	assert.Equal(t, UploadProgress{Phase: UploadPhaseUploading, BytesTotal: 1000}, events[0])
+	assert.Equal(t, UploadProgress{Phase: UploadPhaseUploading, Percent: 40, BytesSent: 400, BytesTotal: 1000, Text: "上传中 40%"}, events[1])
+	assert.Equal(t, UploadPhaseProcessing, events[2].Phase)
+	assert.Equal(t, int64(1000), events[2].BytesSent)
+	assert.Equal(t, UploadPhaseDone, events[3].Phase)
+
+	for i := 1; i < len(events); i++ {
+		assert.Greater(t, events[i].Overall(), events[i-1].Overall())
+	}
+}
+
+func TestProgressTrackerWatch(t *testing.T) {
+	var reads atomic.Int32
+	var last UploadProgress
+	tr := NewProgressTracker("", func(p UploadProgress) { last = p })
+
+	stop := tr.Watch(func() string {
+		reads.Add(1)
+		return "上传中 60%"
+	}, time.Millisecond)
+	assert.Eventually(t, func() bool { return reads.Load() > 0 }, time.Second, time.Millisecond)
+	stop()
+	stop()
+
+	assert.Equal(t, float64(60), last.Percent)
+}
+
+func TestUploadProgressString(t *testing.T) {
+	p := UploadProgress{Phase: UploadPhaseUploading, Percent: 50, BytesSent: 1 << 20, BytesTotal: 2 << 20}
+	assert.Equal(t, "上传中 50% (1.0MB/2.0MB)", p.String())
+	assert.Equal(t, "处理中", UploadProgress{Phase: UploadPhaseProcessing, Percent: -1}.String())
+	assert.Equal(t, "上传完成", UploadProgress{Phase: UploadPhaseDone, Percent: 100}.String())
+}
@@ -0,0 +1,307 @@
+package platform
+
+import (
+	"context"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SubmitFeedbackSelector 发布后可能出现的提示：toast、alert 和弹窗
+// 不匹配 message、notice 等类名，避免消息中心角标、公告栏的文字被当作发布结果
+const SubmitFeedbackSelector = `[class*="toast"], [role="alert"], [role="dialog"], [class*="modal"]`
+
+// submitSeenAttr 点击提交前页面上已有提示的文字记录在该属性中
+const submitSeenAttr = "data-submit-seen"
+
+// ManagedRecentWindow 内容管理页中发布时间早于该窗口的同名作品视为旧作品
+const ManagedRecentWindow = 10 * time.Minute
+
+var (
+	managedMinutesAgo = regexp.MustCompile(`(\d+)\s*分钟前`)
+	managedHoursAgo   = regexp.MustCompile(`(\d+)\s*小时前`)
+	managedToday      = regexp.MustCompile(`今天\s*(\d{1,2}):(\d{2})`)
+	managedFullDate   = regexp.MustCompile(`(\d{4})[-/.年](\d{1,2})[-/.月](\d{1,2})日?\s*(\d{1,2}):(\d{2})`)
+	managedShortDate  = regexp.MustCompile(`(\d{1,2})[-/.月](\d{1,2})日?\s*(\d{1,2}):(\d{2})`)
+)
+
+// ManagedItem 内容管理页中的一张作品卡片
+type ManagedItem struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"` // 卡片上审核状态标签的文字，如 "审核中"、"未通过"
+	Time   string `json:"time"`   // 卡片上显示的发布时间，如 "刚刚"、"5分钟前"、"2024-05-01 12:00"
+}
+
+// PublishedAt 解析卡片上的发布时间，相对时间以 now 为基准；无法识别时返回零值
+func (it ManagedItem) PublishedAt(now time.Time) time.Time {
+	text := strings.TrimSpace(it.Time)
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	switch {
+	case text == "":
+		return time.Time{}
+	case strings.Contains(text, "刚刚"):
+		return now
+	}
+	if m := managedMinutesAgo.FindStringSubmatch(text); m != nil {
+		return now.Add(-time.Duration(atoi(m[1])) * time.Minute)
+	}
+	if m := managedHoursAgo.FindStringSubmatch(text); m != nil {
+		return now.Add(-time.Duration(atoi(m[1])) * time.Hour)
+	}
+	if m := managedToday.FindStringSubmatch(text); m != nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), atoi(m[1]), atoi(m[2]), 0, 0, now.Location())
+	}
+	if m := managedFullDate.FindStringSubmatch(text); m != nil {
+		return time.Date(atoi(m[1]), time.Month(atoi(m[2])), atoi(m[3]), atoi(m[4]), atoi(m[5]), 0, 0, now.Location())
+	}
+	if m := managedShortDate.FindStringSubmatch(text); m != nil {
+		return time.Date(now.Year(), time.Month(atoi(m[1])), atoi(m[2]), atoi(m[3]), atoi(m[4]), 0, 0, now.Location())
+	}
+	return time.Time{}
+}
+
+// PublishCheck 发布后核对结果所需的页面信息，各平台只需提供选择器和地址
+type PublishCheck struct {
+	FeedbackSelector string // 发布后提示的选择器，为空时使用 SubmitFeedbackSelector
+	SuccessURL       string // 提交成功后跳转到的页面地址片段
+	ManageURL        string // 内容管理页地址
+	ItemSelector     string // 内容管理页中的作品卡片
+
+	// List 读取最近发布的作品，为空时打开 ManageURL 按 ItemSelector 读取卡片
+	List func(ctx context.Context, page *rod.Page) ([]ManagedItem, error)
+}
+
+// Verify 点击发布后先观察页面提示，再到内容管理页核对作品的审核状态
+func (c PublishCheck) Verify(ctx context.Context, page *rod.Page, name, title string) (*PublishResponse, error) {
+	state, message := WatchSubmitFeedback(page, 15*time.Second, c.FeedbackSelector, c.SuccessURL)
+	logrus.Infof("发布提示: state=%s, message=%s", state, message)
+	if state == PublishStateFailed {
+		return VerifiedResponse(name, state, message, nil)
+	}
+
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+	list := c.List
+	if list == nil {
+		list = c.listManaged
+	}
+	items, err := list(ctx, pp)
+	if err != nil {
+		logrus.Warnf("核对%s内容列表失败: %v", name, err)
+	}
+	return VerifiedResponse(name, state, message, FindManagedItem(items, title, time.Now()))
+}
+
+// listManaged 打开内容管理页并读取作品列表
+func (c PublishCheck) listManaged(_ context.Context, pp *rod.Page) ([]ManagedItem, error) {
+	if err := pp.Navigate(c.ManageURL); err != nil {
+		return nil, err
+	}
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	return ListManagedItems(pp, c.ItemSelector)
+}
+
+// ClickSubmit 记下页面上已有的提示后点击提交按钮，selector 为空时使用 SubmitFeedbackSelector
+// 之后 WatchSubmitFeedback 只识别点击后新出现或文字发生变化的提示
+func ClickSubmit(page *rod.Page, btn *rod.Element, selector string) error {
+	if selector == "" {
+		selector = SubmitFeedbackSelector
+	}
+	if _, err := page.Eval(`(selector, attr) => {
+		for (const el of document.querySelectorAll(selector)) el.setAttribute(attr, el.innerText.trim());
+	}`, selector, submitSeenAttr); err != nil {
+		logrus.Warnf("记录提交前的页面提示失败: %v", err)
+	}
+	return btn.Click(proto.InputMouseButtonLeft, 1)
+}
+
+// WatchSubmitFeedback 点击发布后轮询页面：跳转到地址包含 successURL 的页面视为提交成功，
+// 匹配 selector 的提示中出现能判断结果的文案时返回该文案；超时仍无结果时返回 PublishStateUnknown
+// 点击前已在页面上且文字没有变化的提示不参与判断，提交按钮需通过 ClickSubmit 点击
+func WatchSubmitFeedback(page *rod.Page, timeout time.Duration, selector, successURL string) (PublishState, string) {
+	if selector == "" {
+		selector = SubmitFeedbackSelector
+	}
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if info, err := page.Info(); err == nil && successURL != "" && strings.Contains(info.URL, successURL) {
+			return PublishStatePublished, ""
+		}
+
+		if elems, err := readFeedback(page, selector); err == nil {
+			if state, text := classifyFeedback(elems); state != PublishStateUnknown {
+				return state, text
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	return PublishStateUnknown, ""
+}
+
+// feedbackElem 页面上的一条提示
+type feedbackElem struct {
+	Text string  `json:"text"`
+	Seen *string `json:"seen"` // 点击提交前记录的文字，点击后才出现的提示为 nil
+}
+
+// readFeedback 读取匹配 selector 的提示及其点击前记录的文字
+func readFeedback(page *rod.Page, selector string) ([]feedbackElem, error) {
+	res, err := page.Eval(`(selector, attr) => JSON.stringify(Array.from(document.querySelectorAll(selector)).map((el) => ({
+		text: el.innerText.trim(),
+		seen: el.hasAttribute(attr) ? el.getAttribute(attr) : null,
+	})))`, selector, submitSeenAttr)
+	if err != nil {
+		return nil, err
+	}
+	var elems []feedbackElem
+	if err := json.Unmarshal([]byte(res.Value.Str()), &elems); err != nil {
+		return nil, err
+	}
+	return elems, nil
+}
+
+// classifyFeedback 只在点击提交后新出现或文字发生变化的提示中判断发布结果
+func classifyFeedback(elems []feedbackElem) (PublishState, string) {
+	for _, elem := range elems {
+		text := strings.TrimSpace(elem.Text)
+		if elem.Seen != nil && *elem.Seen == text {
+			continue
+		}
+		if state := ClassifyPublishMessage(text); state != PublishStateUnknown {
+			return state, text
+		}
+	}
+	return PublishStateUnknown, ""
+}
+
+// managedStatusSelector 作品卡片中的审核状态标签
+const managedStatusSelector = `[class*="status"], [class*="audit"], [class*="review"], [class*="badge"]`
+
+// ListManagedItems 读取内容管理页当前渲染的作品卡片，调用前页面需已打开内容管理页
+func ListManagedItems(page *rod.Page, selector string) ([]ManagedItem, error) {
+	result, err := page.Eval(`(selector, statusSelector) => {
+		const attrs = ['data-id', 'data-feed-id', 'data-video-id', 'data-item-id', 'data-article-id', 'data-content-id'];
+		const items = Array.from(document.querySelectorAll(selector));
+		return JSON.stringify(items.map((el) => {
+			let id = '';
+			for (const attr of attrs) {
+				const holder = el.matches('[' + attr + ']') ? el : el.querySelector('[' + attr + ']');
+				if (holder) { id = holder.getAttribute(attr); break; }
+			}
+			if (!id) {
+				const link = el.querySelector('a[href]');
+				const m = link ? link.href.match(/(\d{8,})/) : null;
+				if (m) id = m[1];
+			}
+			const title = el.querySelector('[class*="title"]');
+			const status = el.querySelector(statusSelector);
+			const time = el.querySelector('[class*="time"], [class*="date"]');
+			return {
+				id: id,
+				title: title ? title.innerText.trim() : '',
+				status: status ? status.innerText.trim() : '',
+				time: time ? time.innerText.trim() : '',
+			};
+		}));
+	}`, selector, managedStatusSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "读取内容列表失败")
+	}
+
+	var items []ManagedItem
+	if err := json.Unmarshal([]byte(result.Value.Str()), &items); err != nil {
+		return nil, errors.Wrap(err, "解析内容列表失败")
+	}
+	return items, nil
+}
+
+// FindManagedItem 按标题查找刚发布的作品，列表按发布时间倒序，取第一个匹配的卡片
+// 发布时间早于 ManagedRecentWindow 的同名作品跳过；标题须完全一致，
+// 只有列表中以省略号结尾的截断标题才按前缀匹配
+func FindManagedItem(items []ManagedItem, title string, now time.Time) *ManagedItem {
+	want := strings.TrimSpace(title)
+	if want == "" {
+		return nil
+	}
+	for i := range items {
+		if !titleMatches(items[i].Title, want) {
+			continue
+		}
+		if t := items[i].PublishedAt(now); !t.IsZero() && now.Sub(t) > ManagedRecentWindow {
+			continue
+		}
+		return &items[i]
+	}
+	return nil
+}
+
+func titleMatches(got, want string) bool {
+	got = strings.TrimSpace(got)
+	if got == want {
+		return true
+	}
+	for _, ellipsis := range []string{"...", "…"} {
+		if prefix, ok := strings.CutSuffix(got, ellipsis); ok {
+			prefix = strings.TrimSpace(prefix)
+			return prefix != "" && strings.HasPrefix(want, prefix)
+		}
+	}
+	return false
+}
+
+// VerifiedResponse 综合页面提示和内容管理页中的作品卡片得出发布结果，发布失败时同时返回错误
+// 卡片上的状态标签优先于页面提示，只识别标签本身，避免标题中的"失败"等字样造成误判；
+// 既没有提示也没有找到作品时判定为失败，而不是按成功返回
+func VerifiedResponse(name string, state PublishState, message string, item *ManagedItem) (*PublishResponse, error) {
+	if item != nil && state != PublishStateFailed {
+		switch ClassifyPublishMessage(item.Status) {
+		case PublishStateFailed:
+			state, message = PublishStateFailed, "审核未通过"
+		case PublishStateReviewing:
+			state, message = PublishStateReviewing, ""
+		default:
+			state = PublishStatePublished
+		}
+	}
+	if item == nil && state == PublishStateUnknown {
+		state, message = PublishStateFailed, "提交后没有出现发布成功提示，内容管理页中也没有找到该内容"
+	}
+
+	resp := &PublishResponse{Status: string(state), Message: message}
+	if item != nil {
+		resp.FeedID = item.ID
+	}
+
+	switch state {
+	case PublishStateFailed:
+		resp.Error = message
+		resp.Message = ""
+		return resp, errors.Errorf("%s发布失败: %s", name, message)
+	case PublishStateReviewing:
+		resp.Success = true
+		if resp.Message == "" {
+			resp.Message = name + "已提交，正在审核"
+		}
+	default:
+		resp.Success = true
+		if resp.Message == "" {
+			resp.Message = name + "发布成功"
+		}
+	}
+	return resp, nil
+}
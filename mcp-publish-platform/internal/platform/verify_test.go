@@ -0,0 +1,103 @@
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindManagedItem(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	items := []ManagedItem{
+		{ID: "1", Title: "另一篇", Time: "刚刚"},
+		{ID: "2", Title: "春天的第一场旅行...", Time: "3分钟前"},
+		{ID: "3", Title: "春天的第一场旅行", Time: "2024-05-01 11:58"},
+		{ID: "4", Title: "旧的一篇", Time: "2024年04月30日 09:00"},
+		{ID: "5", Title: "春天"},
+	}
+	assert.Equal(t, "2", FindManagedItem(items, "春天的第一场旅行，去了杭州", now).ID)
+	assert.Equal(t, "1", FindManagedItem(items, "另一篇", now).ID)
+	assert.Equal(t, "5", FindManagedItem(items, "春天", now).ID)
+	assert.Nil(t, FindManagedItem(items, "旧的一篇", now))
+	assert.Nil(t, FindManagedItem(items, "另一篇文章", now))
+	assert.Nil(t, FindManagedItem(items, "不存在", now))
+	assert.Nil(t, FindManagedItem(items, " ", now))
+}
+
+func TestManagedItemPublishedAt(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	tests := map[string]time.Time{
+		"刚刚":                   now,
+		"5分钟前":                 now.Add(-5 * time.Minute),
+		"2小时前":                 now.Add(-2 * time.Hour),
+		"今天 09:30":             time.Date(2024, 5, 1, 9, 30, 0, 0, time.Local),
+		"发布于 2024-04-30 18:05": time.Date(2024, 4, 30, 18, 5, 0, 0, time.Local),
+		"2024年04月30日 18:05":    time.Date(2024, 4, 30, 18, 5, 0, 0, time.Local),
+		"04-30 18:05":          time.Date(2024, 4, 30, 18, 5, 0, 0, time.Local),
+		"":                     {},
+		"播放 12":                {},
+	}
+	for text, want := range tests {
+		assert.True(t, want.Equal(ManagedItem{Time: text}.PublishedAt(now)), text)
+	}
+}
+
+func TestVerifiedResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   PublishState
+		message string
+		item    *ManagedItem
+		status  PublishState
+		wantErr bool
+	}{
+		{name: "提示失败", state: PublishStateFailed, message: "标题含违规内容", status: PublishStateFailed, wantErr: true},
+		{name: "卡片审核中", state: PublishStatePublished, item: &ManagedItem{ID: "1", Title: "标题", Status: "审核中"}, status: PublishStateReviewing},
+		{name: "卡片审核未通过", state: PublishStateUnknown, item: &ManagedItem{ID: "1", Title: "标题", Status: "审核未通过"}, status: PublishStateFailed, wantErr: true},
+		{name: "找到卡片无状态", state: PublishStateUnknown, item: &ManagedItem{ID: "1", Title: "标题"}, status: PublishStatePublished},
+		{name: "标题含失败字样", state: PublishStatePublished, item: &ManagedItem{ID: "1", Title: "一次失败的尝试", Status: "已发布"}, status: PublishStatePublished},
+		{name: "有提示未找到卡片", state: PublishStatePublished, message: "发布成功", status: PublishStatePublished},
+		{name: "无提示未找到卡片", state: PublishStateUnknown, status: PublishStateFailed, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := VerifiedResponse("抖音图文", tt.state, tt.message, tt.item)
+			assert.Equal(t, string(tt.status), resp.Status)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, !tt.wantErr, resp.Success)
+			if tt.wantErr {
+				assert.NotEmpty(t, resp.Error)
+			}
+		})
+	}
+}
+
+func TestClassifyFeedback(t *testing.T) {
+	seen := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		elems   []feedbackElem
+		want    PublishState
+		message string
+	}{
+		{name: "新出现的成功提示", elems: []feedbackElem{{Text: "发布成功"}}, want: PublishStatePublished, message: "发布成功"},
+		{name: "新出现的错误提示", elems: []feedbackElem{{Text: "标题超过20个字"}}, want: PublishStateFailed, message: "标题超过20个字"},
+		{name: "点击前已有的公告", elems: []feedbackElem{{Text: "系统异常公告：部分功能维护中", Seen: seen("系统异常公告：部分功能维护中")}}, want: PublishStateUnknown},
+		{name: "点击前已有的弹窗", elems: []feedbackElem{{Text: "请先完善账号资料", Seen: seen("请先完善账号资料")}}, want: PublishStateUnknown},
+		{name: "旧提示之后的新提示", elems: []feedbackElem{
+			{Text: "上传失败的视频可重新上传", Seen: seen("上传失败的视频可重新上传")},
+			{Text: "发布成功"},
+		}, want: PublishStatePublished, message: "发布成功"},
+		{name: "复用的提示容器文字变化", elems: []feedbackElem{{Text: "提交成功，等待审核", Seen: seen("")}}, want: PublishStateReviewing, message: "提交成功，等待审核"},
+		{name: "没有能判断的文案", elems: []feedbackElem{{Text: "正在发布"}}, want: PublishStateUnknown},
+		{name: "没有提示", want: PublishStateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message := classifyFeedback(tt.elems)
+			assert.Equal(t, tt.want, state)
+			assert.Equal(t, tt.message, message)
+		})
+	}
+}
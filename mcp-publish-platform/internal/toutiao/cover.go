@@ -0,0 +1,12 @@
+package toutiao
+
+import "github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+
+// toutiaoCoverDialog 视频封面设置弹窗
+var toutiaoCoverDialog = platform.CoverDialog{
+	EntrySelector:  `[class*="cover"]`,
+	EntryPattern:   "上传封面|设置封面|编辑封面|更换封面",
+	ModalSelector:  `[class*="semi-modal-content"], [class*="modal"], [role="dialog"]`,
+	UploadTab:      "^本地上传$|^上传封面$",
+	ConfirmPattern: "^完成$|^确定$",
+}
@@ -0,0 +1,190 @@
+package toutiao
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+const toutiaoDraftItemSelector = `[class*="draft"] [class*="item"], [class*="draft"] [class*="card"]`
+
+// toutiaoDraft 草稿箱中的草稿，ID 为空时由标题、类型和封面生成
+type toutiaoDraft struct {
+	Index    int    `json:"index"`
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	SavedAt  string `json:"saved_at"`
+	CoverURL string `json:"cover_url"`
+	Type     string `json:"type"`
+}
+
+// draftItemsToutiao 转换为通用草稿列表，顺序与草稿卡片一致
+func draftItemsToutiao(drafts []toutiaoDraft) []platform.DraftItem {
+	items := make([]platform.DraftItem, 0, len(drafts))
+	for _, d := range drafts {
+		items = append(items, platform.DraftItem{
+			DraftID:   platform.DraftID(d.ID, d.Title, d.Type, d.CoverURL),
+			FeedType:  d.Type,
+			Title:     d.Title,
+			CoverURL:  d.CoverURL,
+			UpdatedAt: d.SavedAt,
+		})
+	}
+	return items
+}
+
+// saveDraftToutiao 点击"存草稿"保存草稿（编辑器也会自动保存，这里显式保存确保最新内容落盘）
+func saveDraftToutiao(page *rod.Page) error {
+	btn, err := page.Timeout(10*time.Second).ElementR(`button`, `^\s*(存草稿|保存草稿|暂存离开)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "查找存草稿按钮失败")
+	}
+
+	clickEmptyPositionToutiao(page)
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击存草稿按钮失败")
+	}
+
+	logrus.Info("已点击存草稿按钮")
+	time.Sleep(2 * time.Second)
+	return nil
+}
+
+// draftSavedToutiao 保存草稿后从草稿箱读取对应草稿的ID，有多个同名草稿时不返回ID
+func draftSavedToutiao(ctx context.Context, page *rod.Page, title, feedType string) *platform.PublishResponse {
+	resp := &platform.PublishResponse{
+		Success: true,
+		Message: "今日头条草稿保存成功",
+	}
+
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+	drafts, err := listDraftsToutiao(pp)
+	if err != nil {
+		logrus.Warnf("读取今日头条草稿ID失败: %v", err)
+		return resp
+	}
+
+	if draft, ok := platform.FindSavedDraft(draftItemsToutiao(drafts), title, feedType); ok {
+		resp.DraftID = draft.DraftID
+	} else {
+		resp.Message += "，但未能确定草稿ID，请通过草稿列表查询"
+	}
+	return resp
+}
+
+func (t *ToutiaoPlatform) ListDrafts(ctx context.Context, page *rod.Page) ([]platform.DraftItem, error) {
+	pp := page.Timeout(60 * time.Second).Context(ctx)
+
+	drafts, err := listDraftsToutiao(pp)
+	if err != nil {
+		return nil, err
+	}
+	return draftItemsToutiao(drafts), nil
+}
+
+func (t *ToutiaoPlatform) PublishDraft(ctx context.Context, page *rod.Page, draftID string) (*platform.PublishResponse, error) {
+	logrus.Infof("发布今日头条草稿: %s", draftID)
+
+	pp := page.Timeout(300 * time.Second).Context(ctx)
+
+	drafts, err := listDraftsToutiao(pp)
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := platform.FindDraft(draftItemsToutiao(drafts), draftID)
+	if err != nil {
+		return nil, err
+	}
+	target := &drafts[index]
+
+	cards, err := pp.Elements(toutiaoDraftItemSelector)
+	if err != nil || target.Index >= len(cards) {
+		return nil, errors.New("草稿列表已变化，请重新获取")
+	}
+
+	if err := platform.ClickCardAction(pp, cards[target.Index], `^\s*(继续编辑|编辑)\s*$`); err != nil {
+		return nil, errors.Wrap(err, "打开草稿失败")
+	}
+
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	publishBtn, err := pp.Timeout(30*time.Second).ElementR(`button`, `^\s*(发布|发表|预览并发布)\s*$`)
+	if err != nil {
+		return nil, errors.Wrap(err, "查找发布按钮失败")
+	}
+	clickEmptyPositionToutiao(pp)
+	if err := platform.ClickSubmit(pp, publishBtn, toutiaoPublishCheck.FeedbackSelector); err != nil {
+		return nil, errors.Wrap(err, "点击发布按钮失败")
+	}
+
+	// "预览并发布"会弹出确认框，没有弹窗时直接忽略
+	time.Sleep(1 * time.Second)
+	if confirmBtn, err := pp.Timeout(5*time.Second).ElementR(
+		`[role="dialog"] button, [class*="modal"] button`, `^\s*(确认发布|确定|发布)\s*$`); err == nil {
+		if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			logrus.Warnf("点击发布确认按钮失败: %v", err)
+		}
+	}
+
+	logrus.Infof("今日头条草稿已提交发布: %s", target.Title)
+
+	return toutiaoPublishCheck.Verify(ctx, pp, "今日头条草稿", target.Title)
+}
+
+// listDraftsToutiao 打开内容管理页的草稿箱并读取草稿列表
+func listDraftsToutiao(pp *rod.Page) ([]toutiaoDraft, error) {
+	if err := pp.Navigate(toutiaoContentManage); err != nil {
+		return nil, errors.Wrap(err, "导航到内容管理页面失败")
+	}
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	tab, err := pp.Timeout(15*time.Second).ElementR(`span, div, button`, `^\s*(草稿箱|草稿)\s*(\d+)?\s*$`)
+	if err != nil {
+		return nil, errors.Wrap(err, "未找到草稿箱入口")
+	}
+	if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, errors.Wrap(err, "打开草稿箱失败")
+	}
+	time.Sleep(2 * time.Second)
+
+	result, err := pp.Eval(`(selector) => {
+		const text = (el, sel) => {
+			const found = el.querySelector(sel);
+			return found ? found.innerText.trim() : '';
+		};
+		const items = Array.from(document.querySelectorAll(selector));
+		return JSON.stringify(items.map((el, index) => {
+			const img = el.querySelector('img');
+			return {
+				index: index,
+				id: el.getAttribute('data-id') || el.getAttribute('data-article-id') || el.getAttribute('data-draft-id') || '',
+				title: text(el, '[class*="title"]'),
+				saved_at: text(el, '[class*="time"], [class*="date"]'),
+				cover_url: img ? img.src : '',
+				type: el.querySelector('[class*="duration"], video') ? 'video' : 'article',
+			};
+		}));
+	}`, toutiaoDraftItemSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "读取草稿列表失败")
+	}
+
+	var drafts []toutiaoDraft
+	if err := json.Unmarshal([]byte(result.Value.Str()), &drafts); err != nil {
+		return nil, errors.Wrap(err, "解析草稿列表失败")
+	}
+	return drafts, nil
+}
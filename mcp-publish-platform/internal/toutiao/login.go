@@ -15,6 +15,7 @@ const (
 	toutiaoPublishURL  = "https://mp.toutiao.com/publish"
 	toutiaoArticleURL  = "https://mp.toutiao.com/publish/article"
 	toutiaoVideoURL    = "https://mp.toutiao.com/publish/video"
+	toutiaoMicroURL    = "https://mp.toutiao.com/profile_v4/weitoutiao/publish"
 )
 
 type LoginAction struct {
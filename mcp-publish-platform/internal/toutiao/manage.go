@@ -0,0 +1,134 @@
+package toutiao
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+const toutiaoContentManage = "https://mp.toutiao.com/content/manage"
+
+// toutiaoManagePage 内容管理页中的卡片通过这些 data 属性携带内容ID
+var toutiaoManagePage = platform.ManagePage{
+	URL:     toutiaoContentManage,
+	IDAttrs: []string{"data-id", "data-article-id", "data-content-id", "data-item-id"},
+}
+
+func (t *ToutiaoPlatform) EditPost(ctx context.Context, page *rod.Page, feedID string, req *platform.EditPostRequest) error {
+	logrus.Infof("编辑今日头条内容: %s", feedID)
+
+	pp := page.Timeout(300 * time.Second).Context(ctx)
+
+	card, err := toutiaoManagePage.OpenCard(pp, feedID)
+	if err != nil {
+		return err
+	}
+
+	if err := platform.ClickCardAction(pp, card, `^\s*(修改|编辑)\s*$`); err != nil {
+		return errors.Wrap(err, "打开内容编辑页失败")
+	}
+
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	if req.Title != "" {
+		titleElem := platform.FindFirstElement(pp, []string{
+			`textarea[placeholder*="标题"]`,
+			`input[placeholder*="标题"]`,
+			`.title-input input`,
+			`[class*="title"] input`,
+			`#title`,
+		})
+		if titleElem == nil {
+			return errors.New("查找标题输入框失败")
+		}
+		if err := platform.ClearEditable(titleElem); err != nil {
+			return errors.Wrap(err, "清空原标题失败")
+		}
+		if err := titleElem.Input(req.Title); err != nil {
+			return errors.Wrap(err, "输入标题失败")
+		}
+		logrus.Info("标题修改完成")
+	}
+
+	if req.Content != "" {
+		contentElem := platform.FindFirstElement(pp, []string{
+			`.ProseMirror`,
+			`.ql-editor`,
+			`textarea[placeholder*="描述"]`,
+			`textarea[placeholder*="简介"]`,
+			`[contenteditable="true"]`,
+		})
+		if contentElem == nil {
+			return errors.New("查找正文输入框失败")
+		}
+		if err := platform.ClearEditable(contentElem); err != nil {
+			return errors.Wrap(err, "清空原正文失败")
+		}
+		if err := contentElem.Input(req.Content); err != nil {
+			return errors.Wrap(err, "输入正文失败")
+		}
+		logrus.Info("正文修改完成")
+
+		if len(req.Tags) > 0 {
+			if err := inputArticleTags(pp, req.Tags); err != nil {
+				logrus.Warnf("添加标签失败: %v", err)
+			}
+		}
+	}
+
+	time.Sleep(1 * time.Second)
+
+	publishBtn, err := pp.Timeout(10*time.Second).ElementR(`button`, `^\s*(发布|更新|保存修改|确认修改)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "查找发布按钮失败")
+	}
+	clickEmptyPositionToutiao(pp)
+	if err := platform.ClickSubmit(pp, publishBtn, ""); err != nil {
+		return errors.Wrap(err, "点击发布按钮失败")
+	}
+
+	if err := toutiaoManagePage.VerifyEdit(pp, feedID, req); err != nil {
+		return err
+	}
+	logrus.Info("今日头条内容编辑完成")
+	return nil
+}
+
+func (t *ToutiaoPlatform) DeletePost(ctx context.Context, page *rod.Page, feedID string) error {
+	logrus.Infof("删除今日头条内容: %s", feedID)
+
+	pp := page.Timeout(120 * time.Second).Context(ctx)
+
+	card, err := toutiaoManagePage.OpenCard(pp, feedID)
+	if err != nil {
+		return err
+	}
+
+	if err := platform.ClickCardAction(pp, card, `^\s*删除\s*$`); err != nil {
+		return err
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	confirmBtn, err := pp.Timeout(10*time.Second).ElementR(
+		`[role="dialog"] button, [class*="modal"] button, [class*="popconfirm"] button`, `^\s*(确定|确认|删除)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "未找到删除确认按钮")
+	}
+	if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击删除确认按钮失败")
+	}
+
+	if err := toutiaoManagePage.WaitCardGone(pp, feedID, 10*time.Second); err != nil {
+		return err
+	}
+	logrus.Info("今日头条内容已删除")
+	return nil
+}
@@ -0,0 +1,214 @@
+package toutiao
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// maxMicroImages 微头条最多 9 张图片
+const maxMicroImages = 9
+
+var toutiaoMicroEditorSelectors = []string{
+	`.ProseMirror`,
+	`textarea[placeholder*="分享"]`,
+	`[contenteditable="true"]`,
+}
+
+// PublishMicroContent 微头条内容，微头条没有标题和草稿
+type PublishMicroContent struct {
+	Content    string
+	Tags       []string // 以 #话题# 形式追加在正文末尾
+	ImagePaths []string
+}
+
+func NewPublishMicroAction(page *rod.Page) (*PublishAction, error) {
+	pp := page.Timeout(300 * time.Second)
+
+	logrus.Info("正在导航到微头条发布页面...")
+	if err := pp.Navigate(toutiaoMicroURL); err != nil {
+		return nil, errors.Wrap(err, "导航到微头条发布页面失败")
+	}
+
+	if err := pp.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v", err)
+	}
+
+	time.Sleep(2 * time.Second)
+
+	if err := pp.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v", err)
+	}
+
+	return &PublishAction{
+		page: pp,
+	}, nil
+}
+
+// PublishMicro 发布微头条：输入正文和话题，上传图片后点击发布
+func (p *PublishAction) PublishMicro(ctx context.Context, content PublishMicroContent) error {
+	if strings.TrimSpace(content.Content) == "" {
+		return errors.New("内容不能为空")
+	}
+	if len(content.ImagePaths) > maxMicroImages {
+		return errors.Errorf("微头条最多 %d 张图片，当前 %d 张", maxMicroImages, len(content.ImagePaths))
+	}
+
+	page := p.page.Context(ctx)
+
+	var editor *rod.Element
+	for _, selector := range toutiaoMicroEditorSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			editor = elem
+			break
+		}
+	}
+	if editor == nil {
+		return errors.New("查找微头条输入框失败")
+	}
+
+	if err := editor.Input(microText(content.Content, content.Tags)); err != nil {
+		return errors.Wrap(err, "输入微头条内容失败")
+	}
+	logrus.Info("微头条内容输入完成")
+
+	time.Sleep(500 * time.Millisecond)
+
+	if len(content.ImagePaths) > 0 {
+		if err := uploadMicroImages(page, content.ImagePaths); err != nil {
+			return errors.Wrap(err, "上传微头条图片失败")
+		}
+	}
+
+	time.Sleep(1 * time.Second)
+
+	publishBtn, err := page.Timeout(10*time.Second).ElementR("button", "^发布$")
+	if err != nil {
+		return errors.Wrap(err, "查找发布按钮失败")
+	}
+	clickEmptyPositionToutiao(page)
+	if err := platform.ClickSubmit(page, publishBtn, toutiaoPublishCheck.FeedbackSelector); err != nil {
+		return errors.Wrap(err, "点击发布按钮失败")
+	}
+	logrus.Info("已点击发布按钮")
+	return nil
+}
+
+// microText 微头条的话题写在正文中，格式为 #话题#
+func microText(content string, tags []string) string {
+	var b strings.Builder
+	b.WriteString(content)
+	sep := "\n"
+	for _, tag := range tags {
+		tag = strings.Trim(strings.TrimSpace(tag), "#")
+		if tag == "" {
+			continue
+		}
+		b.WriteString(sep + "#" + tag + "#")
+		sep = " "
+	}
+	return b.String()
+}
+
+// uploadMicroImages 点击编辑器的图片按钮，一次性上传全部图片并确认
+func uploadMicroImages(page *rod.Page, imagePaths []string) error {
+	for _, path := range imagePaths {
+		if _, err := os.Stat(path); err != nil {
+			return errors.Wrapf(err, "图片文件不存在: %s", path)
+		}
+	}
+
+	tool, err := page.Timeout(10*time.Second).ElementR(`button, [class*="toolbar"] span, [class*="image"]`, "^图片$")
+	if err != nil {
+		return errors.Wrap(err, "未找到图片按钮")
+	}
+	if err := tool.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击图片按钮失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	var drawer *rod.Element
+	for _, selector := range toutiaoImageDrawerSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			drawer = elem
+			break
+		}
+	}
+	if drawer == nil {
+		return errors.New("图片上传面板未出现")
+	}
+
+	fileInput, err := drawer.Element(`input[type="file"]`)
+	if err != nil {
+		return errors.Wrap(err, "未找到图片上传输入框")
+	}
+	if err := fileInput.SetFiles(imagePaths); err != nil {
+		return errors.Wrap(err, "上传图片失败")
+	}
+
+	// 全部上传完成后确认按钮才可点击
+	confirm, err := drawer.Timeout(time.Duration(30*len(imagePaths))*time.Second).ElementR("button:not([disabled])", "^确定$|^完成$")
+	if err != nil {
+		return errors.Wrap(err, "等待图片上传完成超时")
+	}
+	if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "确认上传图片失败")
+	}
+	logrus.Infof("微头条图片已上传: %d 张", len(imagePaths))
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// publishMicro 以微头条形式发布图文请求，标题作为正文首行
+func (t *ToutiaoPlatform) publishMicro(ctx context.Context, page *rod.Page, req *platform.ImageTextRequest) (*platform.PublishResponse, error) {
+	if req.Draft {
+		return nil, fmt.Errorf("微头条不支持保存草稿")
+	}
+	if req.ContentFormat.IsRich() {
+		return nil, fmt.Errorf("微头条只支持纯文本正文，不支持 %s", req.ContentFormat)
+	}
+	if req.Collection != "" || req.Original {
+		return nil, fmt.Errorf("微头条不支持合集和原创声明")
+	}
+	if len(req.Mentions) > 0 {
+		return nil, fmt.Errorf("微头条不支持 @提及用户")
+	}
+	if req.ScheduleAt != "" {
+		return nil, fmt.Errorf("微头条不支持定时发布")
+	}
+
+	publishAction, err := NewPublishMicroAction(page)
+	if err != nil {
+		return nil, err
+	}
+
+	text := req.Content
+	if title := strings.TrimSpace(req.Title); title != "" {
+		text = title + "\n" + text
+	}
+
+	content := PublishMicroContent{
+		Content:    text,
+		Tags:       req.Tags,
+		ImagePaths: req.Images,
+	}
+	if err := publishAction.PublishMicro(ctx, content); err != nil {
+		return &platform.PublishResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, err
+	}
+
+	// 微头条没有标题，列表中显示的是正文开头
+	return toutiaoPublishCheck.Verify(ctx, page, "微头条", strings.SplitN(text, "\n", 2)[0])
+}
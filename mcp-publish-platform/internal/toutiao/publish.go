@@ -45,12 +45,15 @@ func NewPublishArticleAction(page *rod.Page) (*PublishAction, error) {
 }
 
 type PublishArticleContent struct {
-	Title      string
-	Content    string
-	Tags       []string
-	ImagePaths []string
-	CoverPath  string
-	Category   string
+	Title         string
+	Content       string
+	ContentFormat platform.ContentFormat // 正文格式，markdown/html 按富文本写入
+	Tags          []string
+	ImagePaths    []string
+	CoverPath     string
+	Category      string
+	Draft         bool                     // 仅保存草稿，不发布
+	Settings      platform.PublishSettings // 合集、原创声明
 }
 
 func (p *PublishAction) PublishArticle(ctx context.Context, content PublishArticleContent) error {
@@ -72,7 +75,7 @@ func (p *PublishAction) PublishArticle(ctx context.Context, content PublishArtic
 	time.Sleep(500 * time.Millisecond)
 
 	logrus.Info("开始填写文章内容...")
-	if err := inputArticleContent(page, content.Content); err != nil {
+	if err := inputArticleContent(page, content.Content, content.ContentFormat); err != nil {
 		return errors.Wrap(err, "填写内容失败")
 	}
 
@@ -96,12 +99,20 @@ func (p *PublishAction) PublishArticle(ctx context.Context, content PublishArtic
 
 	time.Sleep(1 * time.Second)
 
+	if err := applyPublishSettingsToutiao(page, content.Settings); err != nil {
+		return err
+	}
+
 	logrus.Info("开始提交文章...")
-	if err := submitArticle(page); err != nil {
+	if err := submitArticle(page, content.Draft); err != nil {
 		return errors.Wrap(err, "提交文章失败")
 	}
 
-	logrus.Info("今日头条文章发布成功！")
+	if content.Draft {
+		logrus.Info("今日头条文章草稿保存成功！")
+		return nil
+	}
+	logrus.Info("今日头条文章已提交发布")
 	return nil
 }
 
@@ -135,13 +146,14 @@ func inputArticleTitle(page *rod.Page, title string) error {
 	return nil
 }
 
-func inputArticleContent(page *rod.Page, content string) error {
+func inputArticleContent(page *rod.Page, content string, format platform.ContentFormat) error {
 	contentSelectors := []string{
 		`#content`,
 		`textarea[placeholder*="正文"]`,
 		`textarea[placeholder*="内容"]`,
 		`.content-input textarea`,
 		`.ql-editor`,
+		`.ProseMirror`,
 		`[contenteditable="true"]`,
 	}
 
@@ -158,6 +170,10 @@ func inputArticleContent(page *rod.Page, content string) error {
 		return errors.New("查找内容输入框失败")
 	}
 
+	if format.IsRich() {
+		return inputArticleRichContent(page, contentElem, content, format)
+	}
+
 	if err := contentElem.Input(content); err != nil {
 		return errors.Wrap(err, "输入内容失败")
 	}
@@ -242,7 +258,11 @@ func inputArticleTags(page *rod.Page, tags []string) error {
 	return nil
 }
 
-func submitArticle(page *rod.Page) error {
+func submitArticle(page *rod.Page, draft bool) error {
+	if draft {
+		return saveDraftToutiao(page)
+	}
+
 	publishSelectors := []string{
 		`button[class*="publish"]`,
 		`button[class*="submit"]`,
@@ -267,14 +287,12 @@ func submitArticle(page *rod.Page) error {
 
 	clickEmptyPositionToutiao(page)
 
-	if err := publishBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+	if err := platform.ClickSubmit(page, publishBtn, toutiaoPublishCheck.FeedbackSelector); err != nil {
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
 
 	logrus.Info("已点击发布按钮")
 
-	time.Sleep(3 * time.Second)
-
 	return nil
 }
 
@@ -312,7 +330,11 @@ type PublishVideoContent struct {
 	Description string
 	Tags        []string
 	VideoPath   string
-	CoverPath   string
+	CoverPath   string                   // 自定义封面图片路径
+	CoverTime   float64                  // 截取视频第几秒的画面作为封面，CoverPath 为空时生效
+	Draft       bool                     // 仅保存草稿，不发布
+	Settings    platform.PublishSettings // 合集、原创声明
+	Progress    platform.ProgressFunc    // 上传和转码进度回调（可选）
 }
 
 func (p *VideoPublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) error {
@@ -327,22 +349,28 @@ func (p *VideoPublishAction) PublishVideo(ctx context.Context, content PublishVi
 	page := p.page.Context(ctx)
 
 	logrus.Info("开始上传视频...")
+	tracker := platform.NewProgressTracker(content.VideoPath, content.Progress)
+	tracker.Start()
 	if err := uploadVideoToutiao(page, content.VideoPath); err != nil {
 		return errors.Wrap(err, "上传视频失败")
 	}
 
 	logrus.Info("等待视频处理完成...")
-	if err := waitForVideoProcessingToutiao(page); err != nil {
+	if err := waitForVideoProcessingToutiao(page, tracker); err != nil {
 		return errors.Wrap(err, "视频处理失败")
 	}
 
 	logrus.Infof("填写视频信息: title=%s", content.Title)
 
-	if err := fillVideoInfoToutiao(page, content.Title, content.Description, content.Tags); err != nil {
+	if err := fillVideoInfoToutiao(page, content); err != nil {
 		return errors.Wrap(err, "填写视频信息失败")
 	}
 
-	logrus.Info("今日头条视频发布成功！")
+	if content.Draft {
+		logrus.Info("今日头条视频草稿保存成功！")
+		return nil
+	}
+	logrus.Info("今日头条视频已提交发布")
 	return nil
 }
 
@@ -374,9 +402,13 @@ func uploadVideoToutiao(page *rod.Page, videoPath string) error {
 	return nil
 }
 
-func waitForVideoProcessingToutiao(page *rod.Page) error {
+// toutiaoUploadProgressSelector 上传区域中的进度条和进度文案
+const toutiaoUploadProgressSelector = `[class*="upload"] [class*="progress"], [class*="upload"] [class*="percent"], [class*="uploading"]`
+
+// waitForVideoProcessingToutiao 等待上传和转码完成，期间把页面上的进度交给 tracker 上报
+func waitForVideoProcessingToutiao(page *rod.Page, tracker *platform.ProgressTracker) error {
 	maxWaitTime := 10 * time.Minute
-	checkInterval := 3 * time.Second
+	checkInterval := 1 * time.Second
 	start := time.Now()
 
 	for time.Since(start) < maxWaitTime {
@@ -391,17 +423,23 @@ func waitForVideoProcessingToutiao(page *rod.Page) error {
 			has, _, err := page.Has(selector)
 			if err == nil && has {
 				logrus.Info("视频处理完成")
+				tracker.Done()
 				return nil
 			}
 		}
 
+		tracker.Update(platform.ProgressText(page, toutiaoUploadProgressSelector))
 		time.Sleep(checkInterval)
 	}
 
 	return errors.New("视频处理超时(10分钟)")
 }
 
-func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []string) error {
+func fillVideoInfoToutiao(page *rod.Page, content PublishVideoContent) error {
+	if err := toutiaoCoverDialog.Set(page, content.CoverPath, content.CoverTime); err != nil {
+		return errors.Wrap(err, "设置封面失败")
+	}
+
 	titleSelectors := []string{
 		`input[placeholder*="标题"]`,
 		`input[placeholder*="填写标题"]`,
@@ -419,7 +457,7 @@ func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []stri
 	}
 
 	if titleElem != nil {
-		if err := titleElem.Input(title); err != nil {
+		if err := titleElem.Input(content.Title); err != nil {
 			logrus.Warnf("输入标题失败: %v", err)
 		} else {
 			logrus.Info("标题输入完成")
@@ -428,7 +466,7 @@ func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []stri
 
 	time.Sleep(500 * time.Millisecond)
 
-	if description != "" {
+	if content.Description != "" {
 		descSelectors := []string{
 			`textarea[placeholder*="描述"]`,
 			`textarea[placeholder*="简介"]`,
@@ -445,7 +483,7 @@ func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []stri
 		}
 
 		if descElem != nil {
-			if err := descElem.Input(description); err != nil {
+			if err := descElem.Input(content.Description); err != nil {
 				logrus.Warnf("输入描述失败: %v", err)
 			} else {
 				logrus.Info("描述输入完成")
@@ -455,14 +493,22 @@ func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []stri
 
 	time.Sleep(500 * time.Millisecond)
 
-	if len(tags) > 0 {
-		if err := inputArticleTags(page, tags); err != nil {
+	if len(content.Tags) > 0 {
+		if err := inputArticleTags(page, content.Tags); err != nil {
 			logrus.Warnf("添加标签失败: %v", err)
 		}
 	}
 
 	time.Sleep(1 * time.Second)
 
+	if err := applyPublishSettingsToutiao(page, content.Settings); err != nil {
+		return err
+	}
+
+	if content.Draft {
+		return saveDraftToutiao(page)
+	}
+
 	publishSelectors := []string{
 		`button[class*="publish"]`,
 		`button[class*="submit"]`,
@@ -480,29 +526,43 @@ func fillVideoInfoToutiao(page *rod.Page, title, description string, tags []stri
 
 	if publishBtn != nil {
 		clickEmptyPositionToutiao(page)
-		if err := publishBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		if err := platform.ClickSubmit(page, publishBtn, toutiaoPublishCheck.FeedbackSelector); err != nil {
 			logrus.Warnf("点击发布按钮失败: %v", err)
 		} else {
 			logrus.Info("已点击发布按钮")
 		}
 	}
 
-	time.Sleep(3 * time.Second)
-
 	return nil
 }
 
 func (t *ToutiaoPlatform) PublishImageText(ctx context.Context, page *rod.Page, req *platform.ImageTextRequest) (*platform.PublishResponse, error) {
+	if req.ExistingTopicsOnly {
+		logrus.Warn("今日头条标签为自由输入，不校验话题是否存在，忽略 existing_topics_only")
+	}
+	if err := checkPublishSettingsToutiao(req.PublishSettings); err != nil {
+		return nil, err
+	}
+	if req.Music != "" {
+		logrus.Warn("今日头条不支持背景音乐，忽略 music")
+	}
+	if req.Micro {
+		return t.publishMicro(ctx, page, req)
+	}
+
 	publishAction, err := NewPublishArticleAction(page)
 	if err != nil {
 		return nil, err
 	}
 
 	content := PublishArticleContent{
-		Title:      req.Title,
-		Content:    req.Content,
-		Tags:       req.Tags,
-		ImagePaths: req.Images,
+		Title:         req.Title,
+		Content:       req.Content,
+		ContentFormat: req.ContentFormat,
+		Tags:          req.Tags,
+		ImagePaths:    req.Images,
+		Draft:         req.Draft,
+		Settings:      req.PublishSettings,
 	}
 
 	if err := publishAction.PublishArticle(ctx, content); err != nil {
@@ -512,13 +572,27 @@ func (t *ToutiaoPlatform) PublishImageText(ctx context.Context, page *rod.Page,
 		}, err
 	}
 
-	return &platform.PublishResponse{
-		Success: true,
-		Message: "今日头条文章发布成功",
-	}, nil
+	if req.Draft {
+		return draftSavedToutiao(ctx, page, req.Title, "article"), nil
+	}
+
+	return toutiaoPublishCheck.Verify(ctx, page, "今日头条文章", req.Title)
 }
 
 func (t *ToutiaoPlatform) PublishVideo(ctx context.Context, page *rod.Page, req *platform.VideoRequest) (*platform.PublishResponse, error) {
+	if req.ExistingTopicsOnly {
+		logrus.Warn("今日头条标签为自由输入，不校验话题是否存在，忽略 existing_topics_only")
+	}
+	if err := checkPublishSettingsToutiao(req.PublishSettings); err != nil {
+		return nil, err
+	}
+
+	if req.CoverPath != "" {
+		if err := platform.CheckCoverSize(req.CoverPath, platform.VideoCoverSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	publishAction, err := NewPublishVideoAction(page)
 	if err != nil {
 		return nil, err
@@ -530,6 +604,10 @@ func (t *ToutiaoPlatform) PublishVideo(ctx context.Context, page *rod.Page, req
 		Tags:        req.Tags,
 		VideoPath:   req.VideoPath,
 		CoverPath:   req.CoverPath,
+		CoverTime:   req.CoverTime,
+		Draft:       req.Draft,
+		Settings:    req.PublishSettings,
+		Progress:    req.Progress,
 	}
 
 	if err := publishAction.PublishVideo(ctx, content); err != nil {
@@ -539,8 +617,9 @@ func (t *ToutiaoPlatform) PublishVideo(ctx context.Context, page *rod.Page, req
 		}, err
 	}
 
-	return &platform.PublishResponse{
-		Success: true,
-		Message: "今日头条视频发布成功",
-	}, nil
+	if req.Draft {
+		return draftSavedToutiao(ctx, page, req.Title, "video"), nil
+	}
+
+	return toutiaoPublishCheck.Verify(ctx, page, "今日头条视频", req.Title)
 }
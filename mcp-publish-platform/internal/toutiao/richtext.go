@@ -0,0 +1,160 @@
+package toutiao
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/richtext"
+)
+
+var (
+	toutiaoImageToolSelectors = []string{
+		`.syl-toolbar-tool.image`,
+		`[class*="toolbar"] [class*="image"]`,
+		`button[aria-label*="图片"]`,
+	}
+
+	toutiaoImageDrawerSelectors = []string{
+		`.upload-image-panel`,
+		`[class*="drawer"]`,
+		`[class*="modal"]`,
+	}
+)
+
+// inputArticleRichContent 把 Markdown/HTML 转换为 HTML，按本地图片切分后逐段粘贴到编辑器，
+// 本地图片在对应位置通过编辑器的图片上传插入，保留标题、列表、粗体等格式
+func inputArticleRichContent(page *rod.Page, editor *rod.Element, content string, format platform.ContentFormat) error {
+	htmlContent := content
+	if format == platform.ContentFormatMarkdown {
+		htmlContent = richtext.MarkdownToHTML(content)
+	}
+
+	segments, err := richtext.SplitLocalImages(htmlContent, configs.GetContentImageDir())
+	if err != nil {
+		return err
+	}
+	for _, seg := range segments {
+		if seg.ImagePath == "" {
+			continue
+		}
+		if _, err := os.Stat(seg.ImagePath); err != nil {
+			return errors.Wrapf(err, "正文图片不存在: %s", seg.ImagePath)
+		}
+	}
+
+	if err := editor.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击正文编辑器失败")
+	}
+
+	for i, seg := range segments {
+		if strings.TrimSpace(seg.HTML) != "" {
+			if err := pasteHTML(editor, seg.HTML); err != nil {
+				return errors.Wrapf(err, "粘贴第%d段正文失败", i+1)
+			}
+			time.Sleep(500 * time.Millisecond)
+		}
+		if seg.ImagePath != "" {
+			if err := insertArticleImage(page, seg.ImagePath); err != nil {
+				return errors.Wrapf(err, "插入正文图片失败: %s", seg.ImagePath)
+			}
+			moveCaretToEnd(editor)
+		}
+	}
+
+	logrus.Infof("富文本内容输入完成: %d 段", len(segments))
+	return nil
+}
+
+// pasteHTML 向编辑器派发携带 text/html 的粘贴事件，由编辑器自己的粘贴逻辑解析为富文本
+// 编辑器没有处理粘贴事件时退回 insertHTML
+func pasteHTML(editor *rod.Element, html string) error {
+	_, err := editor.Eval(`function (html) {
+		this.focus();
+		const tmp = document.createElement('div');
+		tmp.innerHTML = html;
+		const data = new DataTransfer();
+		data.setData('text/html', html);
+		data.setData('text/plain', tmp.innerText);
+		const event = new ClipboardEvent('paste', { clipboardData: data, bubbles: true, cancelable: true });
+		this.dispatchEvent(event);
+		if (!event.defaultPrevented) {
+			document.execCommand('insertHTML', false, html);
+		}
+	}`, html)
+	return err
+}
+
+// moveCaretToEnd 把光标移到正文末尾，保证后续片段追加在图片之后
+func moveCaretToEnd(editor *rod.Element) {
+	_, err := editor.Eval(`function () {
+		this.focus();
+		const range = document.createRange();
+		range.selectNodeContents(this);
+		range.collapse(false);
+		const selection = window.getSelection();
+		selection.removeAllRanges();
+		selection.addRange(range);
+	}`)
+	if err != nil {
+		logrus.Warnf("移动光标失败: %v", err)
+	}
+}
+
+// insertArticleImage 通过编辑器工具栏的图片按钮上传本地图片，插入到当前光标处
+func insertArticleImage(page *rod.Page, imagePath string) error {
+	var tool *rod.Element
+	for _, selector := range toutiaoImageToolSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			tool = elem
+			break
+		}
+	}
+	if tool == nil {
+		return errors.New("未找到编辑器图片按钮")
+	}
+	if err := tool.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击图片按钮失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	var drawer *rod.Element
+	for _, selector := range toutiaoImageDrawerSelectors {
+		has, elem, err := page.Has(selector)
+		if err == nil && has && elem != nil {
+			drawer = elem
+			break
+		}
+	}
+	if drawer == nil {
+		return errors.New("图片上传面板未出现")
+	}
+
+	fileInput, err := drawer.Element(`input[type="file"]`)
+	if err != nil {
+		return errors.Wrap(err, "未找到图片上传输入框")
+	}
+	if err := fileInput.SetFiles([]string{imagePath}); err != nil {
+		return errors.Wrap(err, "上传图片失败")
+	}
+
+	// 上传完成后确认按钮才可点击
+	confirm, err := drawer.Timeout(60*time.Second).ElementR("button:not([disabled])", "^确定$|^插入$|^完成$")
+	if err != nil {
+		return errors.Wrap(err, "等待图片上传完成超时")
+	}
+	if err := confirm.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "确认插入图片失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	logrus.Infof("正文图片已插入: %s", imagePath)
+	return nil
+}
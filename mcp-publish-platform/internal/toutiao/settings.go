@@ -0,0 +1,91 @@
+package toutiao
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// checkPublishSettingsToutiao 今日头条发布页没有地点和可见范围设置，提前拒绝而不是静默公开发布
+func checkPublishSettingsToutiao(s platform.PublishSettings) error {
+	if s.Location != "" {
+		return fmt.Errorf("今日头条不支持设置地点")
+	}
+	if s.Visibility != "" && s.Visibility != platform.VisibilityPublic {
+		return fmt.Errorf("今日头条不支持设置可见范围: %s", s.Visibility)
+	}
+	return nil
+}
+
+// applyPublishSettingsToutiao 设置合集和原创声明
+func applyPublishSettingsToutiao(page *rod.Page, s platform.PublishSettings) error {
+	if s.Collection != "" {
+		if err := setCollectionToutiao(page, s.Collection); err != nil {
+			return errors.Wrap(err, "加入合集失败")
+		}
+	}
+	if s.Original {
+		if err := declareOriginalToutiao(page); err != nil {
+			return errors.Wrap(err, "声明原创失败")
+		}
+	}
+	return nil
+}
+
+// setCollectionToutiao 点击"添加至合集"，在弹窗中选中同名合集并确认
+func setCollectionToutiao(page *rod.Page, name string) error {
+	trigger, err := page.Timeout(5*time.Second).ElementR(`button, [class*="collection"], [class*="pgc-select"]`, "合集")
+	if err != nil {
+		return errors.Wrap(err, "未找到合集入口")
+	}
+	if err := trigger.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击合集入口失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	item, err := page.Timeout(5*time.Second).ElementR(`[class*="collection"] [class*="item"], [class*="option"], li`, "^"+regexp.QuoteMeta(name)+"$")
+	if err != nil {
+		return errors.Errorf("未找到合集: %s", name)
+	}
+	if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择合集失败")
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	if btn, err := page.Timeout(3*time.Second).ElementR(`[class*="modal"] button, [class*="dialog"] button`, "确定|确认"); err == nil {
+		if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "确认合集失败")
+		}
+	}
+	logrus.Infof("已加入合集: %s", name)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// declareOriginalToutiao 在"作品声明"中勾选原创（头条首发），已勾选时跳过
+func declareOriginalToutiao(page *rod.Page) error {
+	checkbox, err := page.Timeout(5*time.Second).ElementR(`label, [class*="checkbox"]`, "原创|头条首发")
+	if err != nil {
+		return errors.Wrap(err, "未找到原创声明选项")
+	}
+	checked, err := platform.IsChecked(checkbox)
+	if err != nil {
+		return err
+	}
+	if checked {
+		logrus.Info("原创声明已勾选")
+		return nil
+	}
+	if err := checkbox.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击原创声明失败")
+	}
+	logrus.Info("已声明原创")
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
@@ -30,13 +30,17 @@ func New() *ToutiaoPlatform {
 			MaxVideoSize: 1024,
 			SupportedTypes: []string{"article", "video"},
 			Features: platform.PlatformFeatures{
-				SupportImageText: true,
-				SupportVideo:     true,
-				SupportSchedule:  true,
-				SupportTags:      true,
-				SupportComment:   true,
-				SupportLike:      true,
-				SupportCollect:   true,
+				SupportImageText:   true,
+				SupportVideo:       true,
+				SupportSchedule:    true,
+				SupportTags:        true,
+				SupportComment:     true,
+				SupportLike:        true,
+				SupportCollect:     true,
+				SupportDraft:       true,
+				SupportMessages:    false,
+				SupportTopicSearch: false,
+				SupportRichText:    true,
 			},
 		},
 	}
@@ -269,9 +273,13 @@ func (t *ToutiaoPlatform) Like(ctx context.Context, page *rod.Page, feedID strin
 	return errors.New("未找到点赞按钮")
 }
 
-func (t *ToutiaoPlatform) Comment(ctx context.Context, page *rod.Page, feedID string, content string) error {
+func (t *ToutiaoPlatform) Comment(ctx context.Context, page *rod.Page, feedID string, content string, mentions []string) error {
 	logrus.Infof("今日头条评论: %s", feedID)
 
+	if len(mentions) > 0 {
+		logrus.Warnf("今日头条评论暂不支持 @提及，忽略 mentions: %v", mentions)
+	}
+
 	pp := page.Context(ctx)
 
 	articleURL := fmt.Sprintf("https://www.toutiao.com/article/%s", feedID)
@@ -366,6 +374,22 @@ func (t *ToutiaoPlatform) Collect(ctx context.Context, page *rod.Page, feedID st
 	return errors.New("未找到收藏按钮")
 }
 
+func (t *ToutiaoPlatform) ListConversations(ctx context.Context, page *rod.Page) ([]platform.Conversation, error) {
+	return nil, errors.New("今日头条不支持私信功能")
+}
+
+func (t *ToutiaoPlatform) GetMessages(ctx context.Context, page *rod.Page, conversationID string) ([]platform.Message, error) {
+	return nil, errors.New("今日头条不支持私信功能")
+}
+
+func (t *ToutiaoPlatform) SendMessage(ctx context.Context, page *rod.Page, conversationID string, content string) error {
+	return errors.New("今日头条不支持私信功能")
+}
+
+func (t *ToutiaoPlatform) SearchTopics(ctx context.Context, page *rod.Page, keyword string) ([]platform.Topic, error) {
+	return nil, errors.New("今日头条不支持话题查询功能")
+}
+
 func (t *ToutiaoPlatform) GetPlatformConfig() *platform.PlatformConfig {
 	return t.config
 }
@@ -0,0 +1,10 @@
+package toutiao
+
+import "github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+
+// toutiaoPublishCheck 发布成功后页面会跳转到内容管理页，跳转本身即视为提交成功
+var toutiaoPublishCheck = platform.PublishCheck{
+	SuccessURL:   toutiaoContentManage,
+	ManageURL:    toutiaoContentManage,
+	ItemSelector: `[class*="article-card"], [class*="content-card"], [class*="content-item"]`,
+}
@@ -2,13 +2,22 @@ package xiaohongshu
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
 	xhs "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
+// feedIDSeparator 通用 FeedID 中笔记ID与 xsec_token 的分隔符
+const feedIDSeparator = ":"
+
 type XiaohongshuAdapter struct {
 	config *platform.PlatformConfig
 }
@@ -16,24 +25,28 @@ type XiaohongshuAdapter struct {
 func NewXiaohongshuAdapter() *XiaohongshuAdapter {
 	return &XiaohongshuAdapter{
 		config: &platform.PlatformConfig{
-			ID:           platform.PlatformXiaohongshu,
-			Name:         "小红书",
-			BaseURL:      "https://www.xiaohongshu.com",
-			LoginURL:     "https://www.xiaohongshu.com",
-			PublishURL:   "https://creator.xiaohongshu.com/publish/publish",
-			UserAgent:    "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
-			Timeout:      60,
-			MaxImages:    18,
-			MaxVideoSize: 1024,
+			ID:             platform.PlatformXiaohongshu,
+			Name:           "小红书",
+			BaseURL:        "https://www.xiaohongshu.com",
+			LoginURL:       "https://www.xiaohongshu.com",
+			PublishURL:     "https://creator.xiaohongshu.com/publish/publish",
+			UserAgent:      "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36",
+			Timeout:        60,
+			MaxImages:      18,
+			MaxVideoSize:   1024,
 			SupportedTypes: []string{"image_text", "video"},
 			Features: platform.PlatformFeatures{
-				SupportImageText: true,
-				SupportVideo:     true,
-				SupportSchedule:  true,
-				SupportTags:      true,
-				SupportComment:   true,
-				SupportLike:      true,
-				SupportCollect:   true,
+				SupportImageText:   true,
+				SupportVideo:       true,
+				SupportSchedule:    true,
+				SupportTags:        true,
+				SupportComment:     true,
+				SupportLike:        true,
+				SupportCollect:     true,
+				SupportDraft:       true,
+				SupportMessages:    true,
+				SupportTopicSearch: true,
+				SupportRichText:    false,
 			},
 		},
 	}
@@ -66,6 +79,10 @@ func (x *XiaohongshuAdapter) Logout(ctx context.Context, page *rod.Page) error {
 }
 
 func (x *XiaohongshuAdapter) PublishImageText(ctx context.Context, page *rod.Page, req *platform.ImageTextRequest) (*platform.PublishResponse, error) {
+	if req.Micro || req.Music != "" {
+		logrus.Warn("小红书图文不支持微头条和背景音乐，忽略 micro/music")
+	}
+
 	publishAction, err := xhs.NewPublishImageAction(page)
 	if err != nil {
 		return &platform.PublishResponse{
@@ -75,30 +92,46 @@ func (x *XiaohongshuAdapter) PublishImageText(ctx context.Context, page *rod.Pag
 	}
 
 	content := xhs.PublishImageContent{
-		Title:      req.Title,
-		Content:    req.Content,
-		ImagePaths: req.Images,
-		Tags:       req.Tags,
+		Title:              req.Title,
+		Content:            req.Content,
+		ImagePaths:         req.Images,
+		Tags:               req.Tags,
+		Draft:              req.Draft,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		Settings:           req.PublishSettings,
 	}
 
 	if req.ScheduleAt != "" {
 		// TODO: parse schedule time
 	}
 
-	if err := publishAction.Publish(ctx, content); err != nil {
-		return &platform.PublishResponse{
+	result, err := publishAction.Publish(ctx, content)
+	if err != nil {
+		resp := &platform.PublishResponse{
 			Success: false,
 			Error:   err.Error(),
-		}, err
+		}
+		if result != nil {
+			resp.Status = string(result.State)
+		}
+		return resp, err
 	}
 
-	return &platform.PublishResponse{
-		Success: true,
-		Message: "发布成功",
-	}, nil
+	if req.Draft {
+		return x.draftSaved(ctx, page, req.Title, "image_text"), nil
+	}
+
+	return publishedResponse(result), nil
 }
 
 func (x *XiaohongshuAdapter) PublishVideo(ctx context.Context, page *rod.Page, req *platform.VideoRequest) (*platform.PublishResponse, error) {
+	if req.CoverPath != "" {
+		if err := platform.CheckCoverSize(req.CoverPath, platform.VideoCoverSpec); err != nil {
+			return nil, err
+		}
+	}
+
 	publishAction, err := xhs.NewPublishVideoAction(page)
 	if err != nil {
 		return &platform.PublishResponse{
@@ -108,50 +141,325 @@ func (x *XiaohongshuAdapter) PublishVideo(ctx context.Context, page *rod.Page, r
 	}
 
 	content := xhs.PublishVideoContent{
-		Title:     req.Title,
-		Content:   req.Description,
-		VideoPath: req.VideoPath,
-		Tags:      req.Tags,
+		Title:              req.Title,
+		Content:            req.Description,
+		VideoPath:          req.VideoPath,
+		Tags:               req.Tags,
+		Draft:              req.Draft,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		CoverPath:          req.CoverPath,
+		CoverTime:          req.CoverTime,
+		Settings:           req.PublishSettings,
+		Progress:           req.Progress,
 	}
 
-	if err := publishAction.PublishVideo(ctx, content); err != nil {
-		return &platform.PublishResponse{
+	result, err := publishAction.PublishVideo(ctx, content)
+	if err != nil {
+		resp := &platform.PublishResponse{
 			Success: false,
 			Error:   err.Error(),
-		}, err
+		}
+		if result != nil {
+			resp.Status = string(result.State)
+		}
+		return resp, err
+	}
+
+	if req.Draft {
+		return x.draftSaved(ctx, page, req.Title, "video"), nil
 	}
 
-	return &platform.PublishResponse{
+	return publishedResponse(result), nil
+}
+
+// publishedResponse 转换发布核对结果，找到新笔记时返回其 FeedID
+func publishedResponse(result *xhs.PublishResult) *platform.PublishResponse {
+	resp := &platform.PublishResponse{
 		Success: true,
-		Message: "视频发布成功",
-	}, nil
+		Status:  string(result.State),
+		Message: result.Message,
+	}
+	if result.NoteID != "" {
+		resp.FeedID = EncodeFeedID(result.NoteID, result.XsecToken)
+	}
+	return resp
+}
+
+// draftSaved 保存草稿后从草稿箱读取对应草稿的ID，有多个同名草稿时不返回ID
+func (x *XiaohongshuAdapter) draftSaved(ctx context.Context, page *rod.Page, title, feedType string) *platform.PublishResponse {
+	resp := &platform.PublishResponse{
+		Success: true,
+		Message: "草稿保存成功",
+	}
+
+	drafts, err := xhs.NewDraftAction(page).ListDrafts(ctx)
+	if err != nil {
+		logrus.Warnf("读取小红书草稿ID失败: %v", err)
+	}
+	if draft, ok := platform.FindSavedDraft(xhsDraftItems(drafts), title, feedType); ok {
+		resp.DraftID = draft.DraftID
+	} else {
+		resp.Message = "草稿保存成功，但未能确定草稿ID，请通过草稿列表查询"
+	}
+	return resp
+}
+
+func (x *XiaohongshuAdapter) ListDrafts(ctx context.Context, page *rod.Page) ([]platform.DraftItem, error) {
+	drafts, err := xhs.NewDraftAction(page).ListDrafts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取小红书草稿列表失败: %w", err)
+	}
+	return xhsDraftItems(drafts), nil
+}
+
+func (x *XiaohongshuAdapter) PublishDraft(ctx context.Context, page *rod.Page, draftID string) (*platform.PublishResponse, error) {
+	_, result, err := xhs.NewDraftAction(page).PublishDraft(ctx, func(drafts []xhs.Draft) (int, error) {
+		return platform.FindDraft(xhsDraftItems(drafts), draftID)
+	})
+	if err != nil {
+		resp := &platform.PublishResponse{
+			Success: false,
+			Error:   err.Error(),
+		}
+		if result != nil {
+			resp.Status = string(result.State)
+		}
+		return resp, err
+	}
+
+	return publishedResponse(result), nil
+}
+
+// xhsDraftItems 转换为通用草稿列表，小红书草稿没有服务端ID，由标题、类型和封面生成
+func xhsDraftItems(drafts []xhs.Draft) []platform.DraftItem {
+	items := make([]platform.DraftItem, 0, len(drafts))
+	for _, d := range drafts {
+		items = append(items, platform.DraftItem{
+			DraftID:   platform.DraftID("", d.Title, d.Type, d.CoverURL),
+			FeedType:  d.Type,
+			Title:     d.Title,
+			CoverURL:  d.CoverURL,
+			UpdatedAt: d.SavedAt,
+		})
+	}
+	return items
+}
+
+// EncodeFeedID 将笔记ID与 xsec_token 编码为通用 FeedID
+// 小红书详情、点赞、评论等操作都需要 xsec_token，通用接口只传递 FeedID，
+// 因此把令牌以 URL 安全的 base64 附在笔记ID之后：<noteID>:<token>
+func EncodeFeedID(noteID, xsecToken string) string {
+	if xsecToken == "" {
+		return noteID
+	}
+	return noteID + feedIDSeparator + base64.RawURLEncoding.EncodeToString([]byte(xsecToken))
+}
+
+// DecodeFeedID 从通用 FeedID 中解析笔记ID与 xsec_token
+func DecodeFeedID(feedID string) (noteID, xsecToken string, err error) {
+	noteID, encoded, found := strings.Cut(feedID, feedIDSeparator)
+	if noteID == "" {
+		return "", "", fmt.Errorf("无效的笔记ID: %q", feedID)
+	}
+	if !found {
+		return noteID, "", nil
+	}
+
+	token, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", fmt.Errorf("无效的笔记ID %q: xsec_token 解码失败: %w", feedID, err)
+	}
+	return noteID, string(token), nil
 }
 
+// GetFeeds 获取自己发布的笔记（创作中心笔记管理）
 func (x *XiaohongshuAdapter) GetFeeds(ctx context.Context, page *rod.Page, req *platform.GetFeedsRequest) (*platform.GetFeedsResponse, error) {
-	return &platform.GetFeedsResponse{
-		Total:    0,
+	status, err := xhs.ParseCreatorNoteStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := xhs.NewCreatorNotesAction(page).ListNotes(ctx, xhs.CreatorNotesRequest{
 		Page:     req.Page,
 		PageSize: req.PageSize,
-		Feeds:    []platform.FeedItem{},
+		Status:   status,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("获取小红书笔记列表失败: %w", err)
+	}
+
+	items := make([]platform.FeedItem, 0, len(result.Notes))
+	for i := range result.Notes {
+		items = append(items, convertCreatorNote(&result.Notes[i]))
+	}
+
+	totalPages := 0
+	if result.PageSize > 0 {
+		totalPages = (result.Total + result.PageSize - 1) / result.PageSize
+	}
+
+	return &platform.GetFeedsResponse{
+		Total:      result.Total,
+		Page:       result.Page,
+		PageSize:   result.PageSize,
+		Feeds:      items,
+		TotalPages: totalPages,
 	}, nil
 }
 
 func (x *XiaohongshuAdapter) GetFeedDetail(ctx context.Context, page *rod.Page, feedID string) (*platform.FeedDetail, error) {
-	return nil, fmt.Errorf("功能开发中")
+	noteID, xsecToken, err := DecodeFeedID(feedID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := xhs.NewFeedDetailAction(page).GetFeedDetail(ctx, noteID, xsecToken, false, xhs.DefaultCommentLoadConfig())
+	if err != nil {
+		return nil, fmt.Errorf("获取小红书笔记详情失败: %w", err)
+	}
+
+	return convertFeedDetail(&resp.Note, feedID), nil
+}
+
+func (x *XiaohongshuAdapter) EditPost(ctx context.Context, page *rod.Page, feedID string, req *platform.EditPostRequest) error {
+	noteID, _, err := DecodeFeedID(feedID)
+	if err != nil {
+		return err
+	}
+	return xhs.NewNoteManageAction(page).EditNote(ctx, noteID, xhs.EditNoteContent{
+		Title:   req.Title,
+		Content: req.Content,
+		Tags:    req.Tags,
+	})
+}
+
+func (x *XiaohongshuAdapter) DeletePost(ctx context.Context, page *rod.Page, feedID string) error {
+	noteID, _, err := DecodeFeedID(feedID)
+	if err != nil {
+		return err
+	}
+	return xhs.NewNoteManageAction(page).DeleteNote(ctx, noteID)
 }
 
 func (x *XiaohongshuAdapter) Like(ctx context.Context, page *rod.Page, feedID string) error {
-	return fmt.Errorf("功能开发中")
+	noteID, xsecToken, err := DecodeFeedID(feedID)
+	if err != nil {
+		return err
+	}
+	return xhs.NewLikeAction(page).Like(ctx, noteID, xsecToken)
 }
 
-func (x *XiaohongshuAdapter) Comment(ctx context.Context, page *rod.Page, feedID string, content string) error {
-	return fmt.Errorf("功能开发中")
+func (x *XiaohongshuAdapter) Comment(ctx context.Context, page *rod.Page, feedID string, content string, mentions []string) error {
+	noteID, xsecToken, err := DecodeFeedID(feedID)
+	if err != nil {
+		return err
+	}
+	return xhs.NewCommentFeedAction(page).PostComment(ctx, noteID, xsecToken, content, mentions)
 }
 
 func (x *XiaohongshuAdapter) Collect(ctx context.Context, page *rod.Page, feedID string) error {
-	return fmt.Errorf("功能开发中")
+	noteID, xsecToken, err := DecodeFeedID(feedID)
+	if err != nil {
+		return err
+	}
+	return xhs.NewFavoriteAction(page).Favorite(ctx, noteID, xsecToken)
 }
 
 func (x *XiaohongshuAdapter) GetPlatformConfig() *platform.PlatformConfig {
 	return x.config
 }
+
+// convertCreatorNote 将创作中心笔记转换为通用内容项
+func convertCreatorNote(note *xhs.CreatorNote) platform.FeedItem {
+	status := string(note.Status)
+	if status == "" {
+		status = "published"
+	}
+
+	return platform.FeedItem{
+		FeedID:       EncodeFeedID(note.ID, note.XsecToken),
+		FeedType:     feedType(note.Type),
+		Title:        note.Title,
+		CoverURL:     note.CoverURL(),
+		PublishedAt:  note.PublishedAt(),
+		ViewCount:    int(note.ViewCount),
+		LikeCount:    int(note.LikeCount),
+		CommentCount: int(note.CommentCount),
+		ShareCount:   int(note.ShareCount),
+		CollectCount: int(note.CollectCount),
+		Status:       status,
+	}
+}
+
+// convertFeedDetail 将小红书笔记详情转换为通用内容详情
+func convertFeedDetail(note *xhs.FeedDetail, feedID string) *platform.FeedDetail {
+	if note.XsecToken != "" {
+		feedID = EncodeFeedID(note.NoteID, note.XsecToken)
+	}
+
+	var publishTime time.Time
+	if note.Time > 0 {
+		publishTime = time.UnixMilli(note.Time)
+	}
+
+	images := make([]string, 0, len(note.ImageList))
+	for _, img := range note.ImageList {
+		if img.URLDefault != "" {
+			images = append(images, img.URLDefault)
+		}
+	}
+
+	var cover string
+	if len(images) > 0 {
+		cover = images[0]
+	}
+
+	metrics := platform.FeedMetrics{
+		LikeCount:    xhsutil.ParseCount(note.InteractInfo.LikedCount),
+		CommentCount: xhsutil.ParseCount(note.InteractInfo.CommentCount),
+		ShareCount:   xhsutil.ParseCount(note.InteractInfo.SharedCount),
+		CollectCount: xhsutil.ParseCount(note.InteractInfo.CollectedCount),
+	}
+
+	return &platform.FeedDetail{
+		FeedItem: platform.FeedItem{
+			FeedID:       feedID,
+			FeedType:     feedType(note.Type),
+			Title:        note.Title,
+			CoverURL:     cover,
+			PublishedAt:  publishTime,
+			LikeCount:    metrics.LikeCount,
+			CommentCount: metrics.CommentCount,
+			ShareCount:   metrics.ShareCount,
+			CollectCount: metrics.CollectCount,
+			Status:       "published",
+		},
+		Content:     note.Desc,
+		Tags:        extractTags(note.Desc),
+		Images:      images,
+		PublishTime: publishTime,
+		Metrics:     metrics,
+	}
+}
+
+// feedType 小红书笔记类型：video 为视频，normal 为图文
+func feedType(t string) string {
+	if t == "video" {
+		return "video"
+	}
+	return "image_text"
+}
+
+// topicPattern 匹配正文中的话题，如 "#旅行[话题]#"
+var topicPattern = regexp.MustCompile(`#([^#\[\s]+)\[话题\]#`)
+
+// extractTags 从笔记正文中提取话题标签
+func extractTags(desc string) []string {
+	matches := topicPattern.FindAllStringSubmatch(desc, -1)
+	tags := make([]string, 0, len(matches))
+	for _, m := range matches {
+		tags = append(tags, m[1])
+	}
+	return tags
+}
@@ -0,0 +1,33 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	xhs "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func (x *XiaohongshuAdapter) ListConversations(ctx context.Context, page *rod.Page) ([]platform.Conversation, error) {
+	conversations, err := xhs.NewMessageAction(page).ListConversations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("获取小红书私信会话失败: %w", err)
+	}
+	return conversations, nil
+}
+
+func (x *XiaohongshuAdapter) GetMessages(ctx context.Context, page *rod.Page, conversationID string) ([]platform.Message, error) {
+	messages, err := xhs.NewMessageAction(page).Messages(ctx, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("获取小红书私信消息失败: %w", err)
+	}
+	return messages, nil
+}
+
+func (x *XiaohongshuAdapter) SendMessage(ctx context.Context, page *rod.Page, conversationID string, content string) error {
+	if err := xhs.NewMessageAction(page).SendMessage(ctx, conversationID, content); err != nil {
+		return fmt.Errorf("发送小红书私信失败: %w", err)
+	}
+	return nil
+}
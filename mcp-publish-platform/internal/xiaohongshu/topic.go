@@ -0,0 +1,18 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	xhs "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+func (x *XiaohongshuAdapter) SearchTopics(ctx context.Context, page *rod.Page, keyword string) ([]platform.Topic, error) {
+	topics, err := xhs.NewTopicAction(page).SearchTopics(ctx, keyword)
+	if err != nil {
+		return nil, fmt.Errorf("查询小红书话题失败: %w", err)
+	}
+	return topics, nil
+}
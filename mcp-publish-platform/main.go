@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"os"
 
@@ -47,6 +48,8 @@ func main() {
 
 func startXiaohongshuMode(port string) {
 	xiaohongshuService := NewXiaohongshuService()
+	go xiaohongshuService.RunMonitor(context.Background())
+
 	appServer := NewAppServer(xiaohongshuService)
 	if err := appServer.Start(port); err != nil {
 		logrus.Fatalf("服务器启动失败: %v", err)
@@ -82,8 +85,11 @@ func startMultiPlatformMode(port string) {
 		logrus.Infof("  - %s", p)
 	}
 
+	xiaohongshuService := NewXiaohongshuService()
+	go xiaohongshuService.RunMonitor(context.Background())
+
 	service := NewMultiPlatformService(platformManager)
-	appServer := NewMultiPlatformAppServer(service)
+	appServer := NewMultiPlatformAppServer(service, xiaohongshuService)
 	if err := appServer.Start(port); err != nil {
 		logrus.Fatalf("服务器启动失败: %v", err)
 	}
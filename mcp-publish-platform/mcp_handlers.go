@@ -10,6 +10,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
@@ -135,15 +136,30 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 	// 解析定时发布参数
 	scheduleAt, _ := args["schedule_at"].(string)
 
+	existingTopicsOnly, _ := args["existing_topics_only"].(bool)
+	mentions := interfacesToStrings(args["mentions"])
+	location, _ := args["location"].(string)
+	visibility, _ := args["visibility"].(string)
+	collection, _ := args["collection"].(string)
+	original, _ := args["original"].(bool)
+
 	logrus.Infof("MCP: 发布内容 - 标题: %s, 图片数量: %d, 标签数量: %d, 定时: %s", title, len(imagePaths), len(tags), scheduleAt)
 
 	// 构建发布请求
 	req := &PublishRequest{
-		Title:      title,
-		Content:    content,
-		Images:     imagePaths,
-		Tags:       tags,
-		ScheduleAt: scheduleAt,
+		Title:              title,
+		Content:            content,
+		Images:             imagePaths,
+		Tags:               tags,
+		ScheduleAt:         scheduleAt,
+		ExistingTopicsOnly: existingTopicsOnly,
+		Mentions:           mentions,
+		PublishSettings: platform.PublishSettings{
+			Location:   location,
+			Visibility: platform.Visibility(visibility),
+			Collection: collection,
+			Original:   original,
+		},
 	}
 
 	// 执行发布
@@ -158,7 +174,7 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 		}
 	}
 
-	resultText := fmt.Sprintf("内容发布成功: %+v", result)
+	resultText := fmt.Sprintf("%s: %+v", publishStatusText("内容", result.Status), result)
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -168,7 +184,7 @@ func (s *AppServer) handlePublishContent(ctx context.Context, args map[string]in
 }
 
 // handlePublishVideo 处理发布视频内容（仅本地单个视频文件）
-func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]interface{}) *MCPToolResult {
+func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]interface{}, progress platform.ProgressFunc) *MCPToolResult {
 	logrus.Info("MCP: 发布视频内容（本地）")
 
 	title, _ := args["title"].(string)
@@ -196,15 +212,35 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 	// 解析定时发布参数
 	scheduleAt, _ := args["schedule_at"].(string)
 
+	existingTopicsOnly, _ := args["existing_topics_only"].(bool)
+	mentions := interfacesToStrings(args["mentions"])
+	location, _ := args["location"].(string)
+	visibility, _ := args["visibility"].(string)
+	collection, _ := args["collection"].(string)
+	original, _ := args["original"].(bool)
+	cover, _ := args["cover"].(string)
+	coverTime, _ := args["cover_time"].(float64)
+
 	logrus.Infof("MCP: 发布视频 - 标题: %s, 标签数量: %d, 定时: %s", title, len(tags), scheduleAt)
 
 	// 构建发布请求
 	req := &PublishVideoRequest{
-		Title:      title,
-		Content:    content,
-		Video:      videoPath,
-		Tags:       tags,
-		ScheduleAt: scheduleAt,
+		Title:              title,
+		Content:            content,
+		Video:              videoPath,
+		Tags:               tags,
+		ScheduleAt:         scheduleAt,
+		ExistingTopicsOnly: existingTopicsOnly,
+		Mentions:           mentions,
+		Cover:              cover,
+		CoverTime:          coverTime,
+		PublishSettings: platform.PublishSettings{
+			Location:   location,
+			Visibility: platform.Visibility(visibility),
+			Collection: collection,
+			Original:   original,
+		},
+		Progress: progress,
 	}
 
 	// 执行发布
@@ -219,7 +255,7 @@ func (s *AppServer) handlePublishVideo(ctx context.Context, args map[string]inte
 		}
 	}
 
-	resultText := fmt.Sprintf("视频发布成功: %+v", result)
+	resultText := fmt.Sprintf("%s: %+v", publishStatusText("视频", result.Status), result)
 	return &MCPToolResult{
 		Content: []MCPContent{{
 			Type: "text",
@@ -288,7 +324,20 @@ func (s *AppServer) handleSearchFeeds(ctx context.Context, args SearchFeedsArgs)
 		Location:    args.Filters.Location,
 	}
 
-	result, err := s.xiaohongshuService.SearchFeeds(ctx, args.Keyword, filter)
+	var result any
+	var err error
+	req := &SearchFeedsRequest{
+		Keyword:    args.Keyword,
+		Filters:    filter,
+		MaxResults: args.MaxResults,
+		Page:       args.Page,
+		Cursor:     args.Cursor,
+	}
+	if req.deep() {
+		result, err = s.xiaohongshuService.DeepSearchFeeds(ctx, req)
+	} else {
+		result, err = s.xiaohongshuService.SearchFeeds(ctx, args.Keyword, filter)
+	}
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -607,10 +656,12 @@ func (s *AppServer) handlePostComment(ctx context.Context, args map[string]inter
 		}
 	}
 
-	logrus.Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d", feedID, len(content))
+	mentions := interfacesToStrings(args["mentions"])
+
+	logrus.Infof("MCP: 发表评论 - Feed ID: %s, 内容长度: %d, 提及: %v", feedID, len(content), mentions)
 
 	// 发表评论
-	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, feedID, xsecToken, content)
+	result, err := s.xiaohongshuService.PostCommentToFeed(ctx, feedID, xsecToken, content, mentions)
 	if err != nil {
 		return &MCPToolResult{
 			Content: []MCPContent{{
@@ -704,3 +755,351 @@ func (s *AppServer) handleReplyComment(ctx context.Context, args map[string]inte
 		}},
 	}
 }
+
+// handleListMyNotes 处理获取我的笔记
+func (s *AppServer) handleListMyNotes(ctx context.Context, args ListMyNotesArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取我的笔记 - page=%d, page_size=%d, status=%s", args.Page, args.PageSize, args.Status)
+
+	result, err := s.xiaohongshuService.ListMyNotes(ctx, &MyNotesRequest{
+		Page:     args.Page,
+		PageSize: args.PageSize,
+		Status:   args.Status,
+	})
+	if err != nil {
+		return mcpErrorResult("获取我的笔记失败: " + err.Error())
+	}
+
+	return mcpJSONResult("获取我的笔记", result)
+}
+
+// handleSyncInbox 处理同步评论收件箱
+func (s *AppServer) handleSyncInbox(ctx context.Context, args SyncInboxArgs) *MCPToolResult {
+	logrus.Infof("MCP: 同步评论收件箱 - max_notes=%d", args.MaxNotes)
+
+	result, err := s.xiaohongshuService.SyncInbox(ctx, &InboxSyncRequest{
+		MaxNotes:           args.MaxNotes,
+		MaxCommentsPerNote: args.MaxCommentsPerNote,
+		SkipNotes:          args.SkipNotes,
+		SkipNotifications:  args.SkipNotifications,
+	})
+	if err != nil {
+		return mcpErrorResult("同步评论收件箱失败: " + err.Error())
+	}
+
+	return mcpJSONResult("同步评论收件箱", result)
+}
+
+// handleListInbox 处理查询评论收件箱
+func (s *AppServer) handleListInbox(ctx context.Context, args ListInboxArgs) *MCPToolResult {
+	result, err := s.xiaohongshuService.ListInbox(&InboxListRequest{
+		NoteID:    args.NoteID,
+		Unread:    args.Unread,
+		Unreplied: args.Unreplied,
+		Keyword:   args.Keyword,
+		Limit:     args.Limit,
+	})
+	if err != nil {
+		return mcpErrorResult("查询评论收件箱失败: " + err.Error())
+	}
+
+	return mcpJSONResult("查询评论收件箱", result)
+}
+
+// handleReplyInboxComment 处理回复收件箱评论
+func (s *AppServer) handleReplyInboxComment(ctx context.Context, args ReplyInboxArgs) *MCPToolResult {
+	logrus.Infof("MCP: 回复收件箱评论 - comment_id=%s", args.CommentID)
+
+	if args.CommentID == "" || args.Content == "" {
+		return mcpErrorResult("回复评论失败: comment_id 和 content 不能为空")
+	}
+
+	result, err := s.xiaohongshuService.ReplyInboxComment(ctx, args.CommentID, args.Content)
+	if err != nil {
+		return mcpErrorResult("回复评论失败: " + err.Error())
+	}
+
+	return mcpJSONResult("回复评论", result)
+}
+
+// handleMarkInboxRead 处理标记收件箱评论已读
+func (s *AppServer) handleMarkInboxRead(ctx context.Context, args MarkInboxReadArgs) *MCPToolResult {
+	changed, err := s.xiaohongshuService.MarkInboxRead(args.CommentIDs)
+	if err != nil {
+		return mcpErrorResult("标记已读失败: " + err.Error())
+	}
+
+	return mcpJSONResult("标记已读", map[string]any{"marked": changed})
+}
+
+// mcpErrorResult 构造错误结果
+func mcpErrorResult(text string) *MCPToolResult {
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: text,
+		}},
+		IsError: true,
+	}
+}
+
+// mcpJSONResult 将结果格式化为 JSON 文本
+func mcpJSONResult(action string, result any) *MCPToolResult {
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return mcpErrorResult(fmt.Sprintf("%s成功，但序列化失败: %v", action, err))
+	}
+
+	return &MCPToolResult{
+		Content: []MCPContent{{
+			Type: "text",
+			Text: string(jsonData),
+		}},
+	}
+}
+
+// handleListFeedComments 处理按游标分页获取评论
+func (s *AppServer) handleListFeedComments(ctx context.Context, args ListFeedCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取评论 - feed_id=%s, cursor=%q", args.FeedID, args.Cursor)
+
+	if args.FeedID == "" || args.XsecToken == "" {
+		return mcpErrorResult("获取评论失败: feed_id 和 xsec_token 不能为空")
+	}
+
+	result, err := s.xiaohongshuService.ListFeedComments(ctx, &FeedCommentsRequest{
+		FeedID:          args.FeedID,
+		XsecToken:       args.XsecToken,
+		Cursor:          args.Cursor,
+		PageSize:        args.PageSize,
+		WithSubComments: args.WithSubComments,
+	})
+	if err != nil {
+		return mcpErrorResult("获取评论失败: " + err.Error())
+	}
+
+	return mcpJSONResult("获取评论", result)
+}
+
+// handleExportFeedComments 处理导出评论
+func (s *AppServer) handleExportFeedComments(ctx context.Context, args ExportFeedCommentsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 导出评论 - feed_id=%s, format=%s", args.FeedID, args.Format)
+
+	if args.FeedID == "" || args.XsecToken == "" {
+		return mcpErrorResult("导出评论失败: feed_id 和 xsec_token 不能为空")
+	}
+
+	result, err := s.xiaohongshuService.ExportFeedComments(ctx, &CommentExportRequest{
+		FeedID:          args.FeedID,
+		XsecToken:       args.XsecToken,
+		Format:          args.Format,
+		Output:          args.Output,
+		WithSubComments: args.WithSubComments,
+		MaxComments:     args.MaxComments,
+		Restart:         args.Restart,
+	})
+	if err != nil {
+		return mcpErrorResult("导出评论失败: " + err.Error())
+	}
+
+	return mcpJSONResult("导出评论", result)
+}
+
+// handleAddWatch 新增监控项
+func (s *AppServer) handleAddWatch(ctx context.Context, args AddWatchArgs) *MCPToolResult {
+	logrus.Infof("MCP: 新增监控项 - type=%s, target=%s", args.Type, args.Target)
+
+	watch, err := s.xiaohongshuService.AddWatch(&WatchRequest{
+		Type:            args.Type,
+		Target:          args.Target,
+		XsecToken:       args.XsecToken,
+		Name:            args.Name,
+		SortBy:          args.SortBy,
+		MaxResults:      args.MaxResults,
+		IntervalMinutes: args.IntervalMinutes,
+		SpikeMinDelta:   args.SpikeMinDelta,
+		SpikeMinGrowth:  args.SpikeMinGrowth,
+		Webhook:         args.Webhook,
+	})
+	if err != nil {
+		return mcpErrorResult("新增监控项失败: " + err.Error())
+	}
+
+	return mcpJSONResult("新增监控项", watch)
+}
+
+// handleListWatches 列出监控项
+func (s *AppServer) handleListWatches(ctx context.Context) *MCPToolResult {
+	logrus.Info("MCP: 列出监控项")
+
+	watches, err := s.xiaohongshuService.ListWatches()
+	if err != nil {
+		return mcpErrorResult("列出监控项失败: " + err.Error())
+	}
+
+	return mcpJSONResult("监控项", map[string]any{"watches": watches, "count": len(watches)})
+}
+
+// handleUpdateWatch 修改监控项
+func (s *AppServer) handleUpdateWatch(ctx context.Context, args UpdateWatchArgs) *MCPToolResult {
+	logrus.Infof("MCP: 修改监控项 - id=%s", args.ID)
+
+	if args.ID == "" {
+		return mcpErrorResult("修改监控项失败: id 不能为空")
+	}
+
+	watch, err := s.xiaohongshuService.UpdateWatch(args.ID, &WatchUpdateRequest{
+		Target:          args.Target,
+		XsecToken:       args.XsecToken,
+		Name:            args.Name,
+		SortBy:          args.SortBy,
+		MaxResults:      args.MaxResults,
+		IntervalMinutes: args.IntervalMinutes,
+		SpikeMinDelta:   args.SpikeMinDelta,
+		SpikeMinGrowth:  args.SpikeMinGrowth,
+		Webhook:         args.Webhook,
+		Enabled:         args.Enabled,
+	})
+	if err != nil {
+		return mcpErrorResult("修改监控项失败: " + err.Error())
+	}
+
+	return mcpJSONResult("修改监控项", watch)
+}
+
+// handleRemoveWatch 删除监控项
+func (s *AppServer) handleRemoveWatch(ctx context.Context, args WatchIDArgs) *MCPToolResult {
+	logrus.Infof("MCP: 删除监控项 - id=%s", args.ID)
+
+	if args.ID == "" {
+		return mcpErrorResult("删除监控项失败: id 不能为空")
+	}
+
+	if err := s.xiaohongshuService.DeleteWatch(args.ID); err != nil {
+		return mcpErrorResult("删除监控项失败: " + err.Error())
+	}
+
+	return mcpJSONResult("删除监控项", map[string]any{"id": args.ID, "deleted": true})
+}
+
+// handleCheckWatch 立即检查监控项
+func (s *AppServer) handleCheckWatch(ctx context.Context, args WatchIDArgs) *MCPToolResult {
+	logrus.Infof("MCP: 检查监控项 - id=%s", args.ID)
+
+	if args.ID == "" {
+		return mcpErrorResult("检查监控项失败: id 不能为空")
+	}
+
+	result, err := s.xiaohongshuService.CheckWatch(ctx, args.ID)
+	if err != nil {
+		return mcpErrorResult("检查监控项失败: " + err.Error())
+	}
+
+	return mcpJSONResult("监控检查", result)
+}
+
+// handleListMonitorAlerts 查询监控告警
+func (s *AppServer) handleListMonitorAlerts(ctx context.Context, args ListMonitorAlertsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 查询监控告警 - watch_id=%s, type=%s", args.WatchID, args.Type)
+
+	alerts, err := s.xiaohongshuService.ListMonitorAlerts(&AlertListRequest{
+		WatchID: args.WatchID,
+		Type:    args.Type,
+		Limit:   args.Limit,
+	})
+	if err != nil {
+		return mcpErrorResult("查询监控告警失败: " + err.Error())
+	}
+
+	return mcpJSONResult("监控告警", map[string]any{"alerts": alerts, "count": len(alerts)})
+}
+
+// handleDownloadFeedMedia 下载笔记媒体
+func (s *AppServer) handleDownloadFeedMedia(ctx context.Context, args DownloadFeedMediaArgs) *MCPToolResult {
+	logrus.Infof("MCP: 下载笔记媒体 - feed_id=%s", args.FeedID)
+
+	if args.FeedID == "" || args.XsecToken == "" {
+		return mcpErrorResult("下载笔记媒体失败: feed_id 和 xsec_token 不能为空")
+	}
+
+	result, err := s.xiaohongshuService.DownloadFeedMedia(ctx, &MediaDownloadRequest{
+		FeedID:        args.FeedID,
+		XsecToken:     args.XsecToken,
+		OutputDir:     args.OutputDir,
+		SkipLivePhoto: args.SkipLivePhoto,
+		SkipVideo:     args.SkipVideo,
+	})
+	if err != nil {
+		return mcpErrorResult("下载笔记媒体失败: " + err.Error())
+	}
+
+	return mcpJSONResult("下载笔记媒体", result)
+}
+
+// handleFollowUser 关注或取消关注用户
+func (s *AppServer) handleFollowUser(ctx context.Context, args FollowUserArgs) *MCPToolResult {
+	logrus.Infof("MCP: 关注用户 - user_id=%s, unfollow=%v", args.UserID, args.Unfollow)
+
+	if args.UserID == "" || args.XsecToken == "" {
+		return mcpErrorResult("关注用户失败: user_id 和 xsec_token 不能为空")
+	}
+
+	if args.Unfollow {
+		result, err := s.xiaohongshuService.UnfollowUser(ctx, args.UserID, args.XsecToken)
+		if err != nil {
+			return mcpErrorResult("取消关注失败: " + err.Error())
+		}
+		return mcpJSONResult("取消关注", result)
+	}
+
+	result, err := s.xiaohongshuService.FollowUser(ctx, args.UserID, args.XsecToken)
+	if err != nil {
+		return mcpErrorResult("关注用户失败: " + err.Error())
+	}
+	return mcpJSONResult("关注用户", result)
+}
+
+// handleListFollowing 我关注的人
+func (s *AppServer) handleListFollowing(ctx context.Context, args ListFollowsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取关注列表 - cursor=%q", args.Cursor)
+
+	result, err := s.xiaohongshuService.ListFollowing(ctx, &FollowListRequest{Cursor: args.Cursor, PageSize: args.PageSize})
+	if err != nil {
+		return mcpErrorResult("获取关注列表失败: " + err.Error())
+	}
+
+	return mcpJSONResult("关注列表", result)
+}
+
+// handleListFollowers 我的粉丝
+func (s *AppServer) handleListFollowers(ctx context.Context, args ListFollowsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取粉丝列表 - cursor=%q", args.Cursor)
+
+	result, err := s.xiaohongshuService.ListFollowers(ctx, &FollowListRequest{Cursor: args.Cursor, PageSize: args.PageSize})
+	if err != nil {
+		return mcpErrorResult("获取粉丝列表失败: " + err.Error())
+	}
+
+	return mcpJSONResult("粉丝列表", result)
+}
+
+// handleSearchTopics 查询话题
+func (s *AppServer) handleSearchTopics(ctx context.Context, args SearchTopicsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 查询话题 - keyword=%s", args.Keyword)
+
+	if args.Keyword == "" {
+		return mcpErrorResult("查询话题失败: 缺少keyword参数")
+	}
+
+	result, err := s.xiaohongshuService.SearchTopics(ctx, args.Keyword)
+	if err != nil {
+		return mcpErrorResult("查询话题失败: " + err.Error())
+	}
+	return mcpJSONResult("查询话题", result)
+}
+
+// publishStatusText 按核对后的发布状态生成提示，审核中的内容不能说成已发布
+func publishStatusText(kind, status string) string {
+	if status == string(platform.PublishStateReviewing) {
+		return kind + "已提交，正在审核"
+	}
+	return kind + "发布成功"
+}
@@ -0,0 +1,425 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-rod/rod"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// 多平台 MCP 工具，仅在多平台模式下注册
+
+// EditPostArgs 编辑已发布内容的参数
+type EditPostArgs struct {
+	Platform string   `json:"platform" jsonschema:"平台: xiaohongshu|douyin|toutiao"`
+	FeedID   string   `json:"feed_id" jsonschema:"内容ID，从平台内容列表获取"`
+	Title    string   `json:"title,omitempty" jsonschema:"新标题（可选，不填则不修改）"`
+	Content  string   `json:"content,omitempty" jsonschema:"新正文/描述（可选，不填则不修改）"`
+	Tags     []string `json:"tags,omitempty" jsonschema:"新标签列表（可选，需同时提供content）"`
+}
+
+// DeletePostArgs 删除已发布内容的参数
+type DeletePostArgs struct {
+	Platform     string `json:"platform" jsonschema:"平台: xiaohongshu|douyin|toutiao"`
+	FeedID       string `json:"feed_id" jsonschema:"内容ID，从平台内容列表获取"`
+	ConfirmToken string `json:"confirm_token,omitempty" jsonschema:"删除确认令牌。首次调用不填，会返回令牌；向用户确认后携带令牌再次调用才会真正删除"`
+}
+
+// ListDraftsArgs 获取草稿列表的参数
+type ListDraftsArgs struct {
+	Platform string `json:"platform" jsonschema:"平台: xiaohongshu|douyin|toutiao"`
+}
+
+// PublishDraftArgs 发布草稿的参数
+type PublishDraftArgs struct {
+	Platform string `json:"platform" jsonschema:"平台: xiaohongshu|douyin|toutiao"`
+	DraftID  string `json:"draft_id" jsonschema:"草稿ID，保存草稿时返回或从 list_drafts 获取"`
+}
+
+// ListConversationsArgs 获取私信会话的参数
+type ListConversationsArgs struct {
+	Platform string `json:"platform" jsonschema:"平台: xiaohongshu|douyin"`
+}
+
+// GetMessagesArgs 获取私信消息的参数
+type GetMessagesArgs struct {
+	Platform       string `json:"platform" jsonschema:"平台: xiaohongshu|douyin"`
+	ConversationID string `json:"conversation_id" jsonschema:"会话ID，从 list_conversations 获取"`
+}
+
+// SendMessageArgs 发送私信的参数
+type SendMessageArgs struct {
+	Platform       string `json:"platform" jsonschema:"平台: xiaohongshu|douyin"`
+	ConversationID string `json:"conversation_id" jsonschema:"会话ID，从 list_conversations 获取"`
+	Content        string `json:"content" jsonschema:"私信文本内容"`
+}
+
+// SearchPlatformTopicsArgs 查询平台话题的参数
+type SearchPlatformTopicsArgs struct {
+	Platform string `json:"platform" jsonschema:"平台: xiaohongshu|douyin"`
+	Keyword  string `json:"keyword" jsonschema:"话题关键词，不需要带#"`
+}
+
+// registerPlatformTools 注册多平台工具
+func registerPlatformTools(server *mcp.Server, appServer *AppServer) int {
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "edit_post",
+			Description: "编辑已发布的笔记/视频/文章的标题、正文或标签（支持小红书、抖音、今日头条）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Edit Post",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("edit_post", func(ctx context.Context, req *mcp.CallToolRequest, args EditPostArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleEditPost(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "delete_post",
+			Description: "删除已发布的内容（不可恢复）。需要两步：先不带 confirm_token 调用获取令牌，确认后携带令牌再次调用",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Delete Post",
+				DestructiveHint: boolPtr(true),
+			},
+		},
+		withPanicRecovery("delete_post", func(ctx context.Context, req *mcp.CallToolRequest, args DeletePostArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleDeletePost(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_drafts",
+			Description: "获取平台草稿箱中的草稿列表（支持小红书、抖音、今日头条）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Drafts",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_drafts", func(ctx context.Context, req *mcp.CallToolRequest, args ListDraftsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListDrafts(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "publish_draft",
+			Description: "发布草稿箱中的指定草稿",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Publish Draft",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("publish_draft", func(ctx context.Context, req *mcp.CallToolRequest, args PublishDraftArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handlePublishDraft(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_conversations",
+			Description: "获取私信会话列表，包含对方昵称、最后一条消息和未读数（支持小红书、抖音）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Conversations",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_conversations", func(ctx context.Context, req *mcp.CallToolRequest, args ListConversationsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListConversations(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "get_messages",
+			Description: "获取指定私信会话的消息记录（支持小红书、抖音）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Get Messages",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("get_messages", func(ctx context.Context, req *mcp.CallToolRequest, args GetMessagesArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleGetMessages(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "send_message",
+			Description: "在指定私信会话中回复文本消息（支持小红书、抖音）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Send Message",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("send_message", func(ctx context.Context, req *mcp.CallToolRequest, args SendMessageArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSendMessage(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "search_platform_topics",
+			Description: "按关键词查询平台话题联想候选及浏览量，发布时配合 existing_topics_only 只使用已有话题（支持小红书、抖音）",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Search Platform Topics",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("search_platform_topics", func(ctx context.Context, req *mcp.CallToolRequest, args SearchPlatformTopicsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSearchPlatformTopics(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	return 8
+}
+
+// platformPage 获取多平台模式下的浏览器页面
+func (s *AppServer) platformPage() (*rod.Page, error) {
+	page, err := s.getBrowserPage()
+	if err != nil {
+		return nil, err
+	}
+	if page == nil {
+		return nil, errors.New("浏览器未初始化")
+	}
+	return page, nil
+}
+
+// handleEditPost 处理编辑已发布内容
+func (s *AppServer) handleEditPost(ctx context.Context, args EditPostArgs) *MCPToolResult {
+	logrus.Infof("MCP: 编辑内容 - platform=%s, feed_id=%s", args.Platform, args.FeedID)
+
+	if args.FeedID == "" {
+		return mcpErrorResult("编辑内容失败: 缺少feed_id参数")
+	}
+
+	req := &platform.EditPostRequest{
+		Title:   args.Title,
+		Content: args.Content,
+		Tags:    args.Tags,
+	}
+	if req.IsEmpty() {
+		return mcpErrorResult("编辑内容失败: title、content、tags 至少填写一项")
+	}
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("编辑内容失败: " + err.Error())
+	}
+	defer page.Close()
+
+	if err := s.multiPlatformService.EditPost(ctx, platform.PlatformID(args.Platform), page, args.FeedID, req); err != nil {
+		return mcpErrorResult("编辑内容失败: " + err.Error())
+	}
+
+	return mcpJSONResult("编辑内容", map[string]any{
+		"success":  true,
+		"platform": args.Platform,
+		"feed_id":  args.FeedID,
+	})
+}
+
+// handleDeletePost 处理删除已发布内容
+func (s *AppServer) handleDeletePost(ctx context.Context, args DeletePostArgs) *MCPToolResult {
+	platformID := platform.PlatformID(args.Platform)
+
+	if args.FeedID == "" {
+		return mcpErrorResult("删除内容失败: 缺少feed_id参数")
+	}
+
+	if args.ConfirmToken == "" {
+		confirm, err := s.multiPlatformService.RequestDeleteConfirmation(platformID, args.FeedID)
+		if err != nil {
+			return mcpErrorResult("获取删除确认令牌失败: " + err.Error())
+		}
+		return mcpJSONResult("获取删除确认令牌", map[string]any{
+			"confirm_required": true,
+			"message":          "删除不可恢复，请向用户确认后携带 confirm_token 再次调用 delete_post",
+			"data":             confirm,
+		})
+	}
+
+	logrus.Infof("MCP: 删除内容 - platform=%s, feed_id=%s", args.Platform, args.FeedID)
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("删除内容失败: " + err.Error())
+	}
+	defer page.Close()
+
+	if err := s.multiPlatformService.DeletePost(ctx, platformID, page, args.FeedID, args.ConfirmToken); err != nil {
+		return mcpErrorResult("删除内容失败: " + err.Error())
+	}
+
+	return mcpJSONResult("删除内容", map[string]any{
+		"success":  true,
+		"platform": args.Platform,
+		"feed_id":  args.FeedID,
+	})
+}
+
+// handleListDrafts 处理获取草稿列表
+func (s *AppServer) handleListDrafts(ctx context.Context, args ListDraftsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取草稿列表 - platform=%s", args.Platform)
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("获取草稿列表失败: " + err.Error())
+	}
+	defer page.Close()
+
+	drafts, err := s.multiPlatformService.ListDrafts(ctx, platform.PlatformID(args.Platform), page)
+	if err != nil {
+		return mcpErrorResult("获取草稿列表失败: " + err.Error())
+	}
+
+	return mcpJSONResult("获取草稿列表", map[string]any{
+		"platform": args.Platform,
+		"count":    len(drafts),
+		"drafts":   drafts,
+	})
+}
+
+// handlePublishDraft 处理发布草稿
+func (s *AppServer) handlePublishDraft(ctx context.Context, args PublishDraftArgs) *MCPToolResult {
+	logrus.Infof("MCP: 发布草稿 - platform=%s, draft_id=%s", args.Platform, args.DraftID)
+
+	if args.DraftID == "" {
+		return mcpErrorResult("发布草稿失败: 缺少draft_id参数")
+	}
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("发布草稿失败: " + err.Error())
+	}
+	defer page.Close()
+
+	resp, err := s.multiPlatformService.PublishDraft(ctx, platform.PlatformID(args.Platform), page, args.DraftID)
+	if err != nil {
+		return mcpErrorResult("发布草稿失败: " + err.Error())
+	}
+
+	return mcpJSONResult("发布草稿", map[string]any{
+		"success":  resp.Success,
+		"status":   resp.Status,
+		"message":  resp.Message,
+		"platform": args.Platform,
+		"draft_id": args.DraftID,
+	})
+}
+
+// handleListConversations 处理获取私信会话
+func (s *AppServer) handleListConversations(ctx context.Context, args ListConversationsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取私信会话 - platform=%s", args.Platform)
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("获取私信会话失败: " + err.Error())
+	}
+	defer page.Close()
+
+	conversations, err := s.multiPlatformService.ListConversations(ctx, platform.PlatformID(args.Platform), page)
+	if err != nil {
+		return mcpErrorResult("获取私信会话失败: " + err.Error())
+	}
+
+	return mcpJSONResult("获取私信会话", map[string]any{
+		"platform":      args.Platform,
+		"count":         len(conversations),
+		"conversations": conversations,
+	})
+}
+
+// handleGetMessages 处理获取私信消息
+func (s *AppServer) handleGetMessages(ctx context.Context, args GetMessagesArgs) *MCPToolResult {
+	logrus.Infof("MCP: 获取私信消息 - platform=%s, conversation_id=%s", args.Platform, args.ConversationID)
+
+	if args.ConversationID == "" {
+		return mcpErrorResult("获取私信消息失败: 缺少conversation_id参数")
+	}
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("获取私信消息失败: " + err.Error())
+	}
+	defer page.Close()
+
+	messages, err := s.multiPlatformService.GetMessages(ctx, platform.PlatformID(args.Platform), page, args.ConversationID)
+	if err != nil {
+		return mcpErrorResult("获取私信消息失败: " + err.Error())
+	}
+
+	return mcpJSONResult("获取私信消息", map[string]any{
+		"platform":        args.Platform,
+		"conversation_id": args.ConversationID,
+		"count":           len(messages),
+		"messages":        messages,
+	})
+}
+
+// handleSendMessage 处理发送私信
+func (s *AppServer) handleSendMessage(ctx context.Context, args SendMessageArgs) *MCPToolResult {
+	logrus.Infof("MCP: 发送私信 - platform=%s, conversation_id=%s", args.Platform, args.ConversationID)
+
+	if args.ConversationID == "" || args.Content == "" {
+		return mcpErrorResult("发送私信失败: conversation_id 和 content 不能为空")
+	}
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("发送私信失败: " + err.Error())
+	}
+	defer page.Close()
+
+	if err := s.multiPlatformService.SendMessage(ctx, platform.PlatformID(args.Platform), page, args.ConversationID, args.Content); err != nil {
+		return mcpErrorResult("发送私信失败: " + err.Error())
+	}
+
+	return mcpJSONResult("发送私信", map[string]any{
+		"success":         true,
+		"platform":        args.Platform,
+		"conversation_id": args.ConversationID,
+	})
+}
+
+// handleSearchPlatformTopics 处理查询平台话题
+func (s *AppServer) handleSearchPlatformTopics(ctx context.Context, args SearchPlatformTopicsArgs) *MCPToolResult {
+	logrus.Infof("MCP: 查询平台话题 - platform=%s, keyword=%s", args.Platform, args.Keyword)
+
+	if args.Keyword == "" {
+		return mcpErrorResult("查询话题失败: 缺少keyword参数")
+	}
+
+	page, err := s.platformPage()
+	if err != nil {
+		return mcpErrorResult("查询话题失败: " + err.Error())
+	}
+	defer page.Close()
+
+	topics, err := s.multiPlatformService.SearchTopics(ctx, platform.PlatformID(args.Platform), page, args.Keyword)
+	if err != nil {
+		return mcpErrorResult("查询话题失败: " + err.Error())
+	}
+
+	return mcpJSONResult("查询话题", map[string]any{
+		"platform": args.Platform,
+		"keyword":  args.Keyword,
+		"count":    len(topics),
+		"topics":   topics,
+	})
+}
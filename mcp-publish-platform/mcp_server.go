@@ -8,6 +8,7 @@ import (
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
 )
 
 // Helper functions for annotation pointers
@@ -17,26 +18,48 @@ func boolPtr(b bool) *bool { return &b }
 
 // PublishContentArgs 发布内容的参数
 type PublishContentArgs struct {
-	Title      string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
-	Content    string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
-	Images     []string `json:"images" jsonschema:"图片路径列表（至少需要1张图片）。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）"`
-	Tags       []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
-	ScheduleAt string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	Title              string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
+	Content            string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
+	Images             []string `json:"images" jsonschema:"图片路径列表（至少需要1张图片）。支持两种方式：1. HTTP/HTTPS图片链接（自动下载）；2. 本地图片绝对路径（推荐，如:/Users/user/image.jpg）"`
+	Tags               []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
+	ScheduleAt         string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	ExistingTopicsOnly bool     `json:"existing_topics_only,omitempty" jsonschema:"只使用能匹配到已有话题的标签（可选），匹配不到的标签会被跳过，可先用 search_topics 查询"`
+	Mentions           []string `json:"mentions,omitempty" jsonschema:"正文末尾要@提及的用户昵称或小红书号列表（可选），通过提及选择器插入，找不到的用户会被跳过"`
+	Location           string   `json:"location,omitempty" jsonschema:"地点关键词（可选），选择地点搜索的第一个结果"`
+	Visibility         string   `json:"visibility,omitempty" jsonschema:"可见范围（可选）：public 公开（默认）、private 仅自己可见、friends 仅互关好友可见"`
+	Collection         string   `json:"collection,omitempty" jsonschema:"加入的合集名称（可选），需已在小红书创建"`
+	Original           bool     `json:"original,omitempty" jsonschema:"是否声明原创（可选）"`
 }
 
 // PublishVideoArgs 发布视频的参数（仅支持本地单个视频文件）
 type PublishVideoArgs struct {
-	Title      string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
-	Content    string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
-	Video      string   `json:"video" jsonschema:"本地视频绝对路径（仅支持单个视频文件，如:/Users/user/video.mp4）"`
-	Tags       []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
-	ScheduleAt string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	Title              string   `json:"title" jsonschema:"内容标题（小红书限制：最多20个中文字或英文单词）"`
+	Content            string   `json:"content" jsonschema:"正文内容，不包含以#开头的标签内容，所有话题标签都用tags参数来生成和提供即可"`
+	Video              string   `json:"video" jsonschema:"本地视频绝对路径（仅支持单个视频文件，如:/Users/user/video.mp4）"`
+	Tags               []string `json:"tags,omitempty" jsonschema:"话题标签列表（可选参数），如 [美食, 旅行, 生活]"`
+	ScheduleAt         string   `json:"schedule_at,omitempty" jsonschema:"定时发布时间（可选），ISO8601格式如 2024-01-20T10:30:00+08:00，支持1小时至14天内。不填则立即发布"`
+	ExistingTopicsOnly bool     `json:"existing_topics_only,omitempty" jsonschema:"只使用能匹配到已有话题的标签（可选），匹配不到的标签会被跳过，可先用 search_topics 查询"`
+	Mentions           []string `json:"mentions,omitempty" jsonschema:"正文末尾要@提及的用户昵称或小红书号列表（可选），通过提及选择器插入，找不到的用户会被跳过"`
+	Cover              string   `json:"cover,omitempty" jsonschema:"自定义封面（可选），本地图片绝对路径或 HTTP/HTTPS 链接，短边至少720像素，宽高比在9:16到16:9之间"`
+	CoverTime          float64  `json:"cover_time,omitempty" jsonschema:"截取视频第几秒的画面作为封面（可选），与 cover 二选一"`
+	Location           string   `json:"location,omitempty" jsonschema:"地点关键词（可选），选择地点搜索的第一个结果"`
+	Visibility         string   `json:"visibility,omitempty" jsonschema:"可见范围（可选）：public 公开（默认）、private 仅自己可见、friends 仅互关好友可见"`
+	Collection         string   `json:"collection,omitempty" jsonschema:"加入的合集名称（可选），需已在小红书创建"`
+	Original           bool     `json:"original,omitempty" jsonschema:"是否声明原创（可选）"`
+}
+
+// SearchTopicsArgs 查询话题的参数
+type SearchTopicsArgs struct {
+	Keyword string `json:"keyword" jsonschema:"话题关键词，不需要带#"`
 }
 
 // SearchFeedsArgs 搜索内容的参数
 type SearchFeedsArgs struct {
-	Keyword string       `json:"keyword" jsonschema:"搜索关键词"`
-	Filters FilterOption `json:"filters,omitempty" jsonschema:"筛选选项"`
+	Keyword    string       `json:"keyword" jsonschema:"搜索关键词"`
+	Filters    FilterOption `json:"filters,omitempty" jsonschema:"筛选选项"`
+	MaxResults int          `json:"max_results,omitempty" jsonschema:"返回的结果数，默认只返回首屏结果；设置后滚动加载直到满足数量，最多200"`
+	Page       int          `json:"page,omitempty" jsonschema:"页码，从1开始，每页max_results条"`
+	Cursor     string       `json:"cursor,omitempty" jsonschema:"上一次返回的cursor，用于继续获取后面的结果，提供时忽略page"`
 }
 
 // FilterOption 筛选选项结构体
@@ -67,9 +90,10 @@ type UserProfileArgs struct {
 
 // PostCommentArgs 发表评论的参数
 type PostCommentArgs struct {
-	FeedID    string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
-	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
-	Content   string `json:"content" jsonschema:"评论内容"`
+	FeedID    string   `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken string   `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Content   string   `json:"content" jsonschema:"评论内容"`
+	Mentions  []string `json:"mentions,omitempty" jsonschema:"评论末尾要@提及的用户昵称或小红书号列表（可选）"`
 }
 
 // ReplyCommentArgs 回复评论的参数
@@ -95,6 +119,124 @@ type FavoriteFeedArgs struct {
 	Unfavorite bool   `json:"unfavorite,omitempty" jsonschema:"是否取消收藏，true为取消收藏，false或未设置则为收藏"`
 }
 
+// ListMyNotesArgs 获取我的笔记的参数
+type ListMyNotesArgs struct {
+	Page     int    `json:"page,omitempty" jsonschema:"页码，从1开始，默认1"`
+	PageSize int    `json:"page_size,omitempty" jsonschema:"每页数量，默认20"`
+	Status   string `json:"status,omitempty" jsonschema:"笔记状态: all|published|reviewing|rejected，默认all"`
+}
+
+// SyncInboxArgs 同步评论收件箱的参数
+type SyncInboxArgs struct {
+	MaxNotes           int  `json:"max_notes,omitempty" jsonschema:"最多遍历的已发布笔记数，默认10"`
+	MaxCommentsPerNote int  `json:"max_comments_per_note,omitempty" jsonschema:"每篇笔记最多加载的评论数，默认50"`
+	SkipNotes          bool `json:"skip_notes,omitempty" jsonschema:"为true时不遍历笔记，只同步通知中心"`
+	SkipNotifications  bool `json:"skip_notifications,omitempty" jsonschema:"为true时不同步通知中心"`
+}
+
+// ListInboxArgs 查询评论收件箱的参数
+type ListInboxArgs struct {
+	NoteID    string `json:"note_id,omitempty" jsonschema:"只看某篇笔记的评论"`
+	Unread    bool   `json:"unread,omitempty" jsonschema:"为true时只返回未读评论"`
+	Unreplied bool   `json:"unreplied,omitempty" jsonschema:"为true时只返回未回复评论"`
+	Keyword   string `json:"keyword,omitempty" jsonschema:"评论内容或昵称包含的关键词"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"返回数量上限，默认不限制"`
+}
+
+// ReplyInboxArgs 回复收件箱评论的参数
+type ReplyInboxArgs struct {
+	CommentID string `json:"comment_id" jsonschema:"收件箱中的评论ID"`
+	Content   string `json:"content" jsonschema:"回复内容"`
+}
+
+// MarkInboxReadArgs 标记收件箱评论已读的参数
+type MarkInboxReadArgs struct {
+	CommentIDs []string `json:"comment_ids,omitempty" jsonschema:"要标记已读的评论ID列表，为空时标记全部"`
+}
+
+// ListFeedCommentsArgs 按游标分页获取评论的参数
+type ListFeedCommentsArgs struct {
+	FeedID          string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken       string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Cursor          string `json:"cursor,omitempty" jsonschema:"上一次返回的next_cursor，为空从第一页开始"`
+	PageSize        int    `json:"page_size,omitempty" jsonschema:"期望的一级评论数量，默认20，按接口分页可能略多"`
+	WithSubComments bool   `json:"with_sub_comments,omitempty" jsonschema:"为true时展开并返回楼中楼回复"`
+}
+
+// ExportFeedCommentsArgs 导出评论的参数
+type ExportFeedCommentsArgs struct {
+	FeedID          string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken       string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Format          string `json:"format,omitempty" jsonschema:"导出格式: jsonl|csv，默认jsonl"`
+	Output          string `json:"output,omitempty" jsonschema:"导出目录（默认 exports，可通过 EXPORT_DIR 设置）下的相对路径，默认 comments_<feed_id>.<format>"`
+	WithSubComments bool   `json:"with_sub_comments,omitempty" jsonschema:"为true时同时导出楼中楼回复"`
+	MaxComments     int    `json:"max_comments,omitempty" jsonschema:"本次最多导出的一级评论数，0表示全部"`
+	Restart         bool   `json:"restart,omitempty" jsonschema:"为true时忽略已有进度重新导出"`
+}
+
+// DownloadFeedMediaArgs 下载笔记媒体的参数
+type DownloadFeedMediaArgs struct {
+	FeedID        string `json:"feed_id" jsonschema:"小红书笔记ID，从Feed列表获取"`
+	XsecToken     string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	OutputDir     string `json:"output_dir,omitempty" jsonschema:"下载目录，默认 downloads/<作者ID>/<笔记ID>"`
+	SkipLivePhoto bool   `json:"skip_live_photo,omitempty" jsonschema:"为true时不下载实况图的视频部分"`
+	SkipVideo     bool   `json:"skip_video,omitempty" jsonschema:"为true时不下载视频笔记的视频"`
+}
+
+// FollowUserArgs 关注用户的参数
+type FollowUserArgs struct {
+	UserID    string `json:"user_id" jsonschema:"小红书用户ID，从Feed列表获取"`
+	XsecToken string `json:"xsec_token" jsonschema:"访问令牌，从Feed列表的xsecToken字段获取"`
+	Unfollow  bool   `json:"unfollow,omitempty" jsonschema:"是否取消关注，true为取消关注，false或未设置则为关注"`
+}
+
+// ListFollowsArgs 关注/粉丝列表的参数
+type ListFollowsArgs struct {
+	Cursor   string `json:"cursor,omitempty" jsonschema:"分页游标，传入上次返回的next_cursor获取下一页，为空从第一页开始"`
+	PageSize int    `json:"page_size,omitempty" jsonschema:"每页用户数量，默认20"`
+}
+
+// AddWatchArgs 新增监控项的参数
+type AddWatchArgs struct {
+	Type            string  `json:"type" jsonschema:"监控类型: keyword(关键词搜索结果)|user(竞品账号主页)"`
+	Target          string  `json:"target" jsonschema:"监控目标: 关键词或用户ID"`
+	XsecToken       string  `json:"xsec_token,omitempty" jsonschema:"监控用户时必填，从Feed列表获取"`
+	Name            string  `json:"name,omitempty" jsonschema:"备注名，用于告警展示"`
+	SortBy          string  `json:"sort_by,omitempty" jsonschema:"关键词搜索排序: 综合|最新|最多点赞|最多评论|最多收藏"`
+	MaxResults      int     `json:"max_results,omitempty" jsonschema:"关键词每次检查的结果数，默认只看首屏"`
+	IntervalMinutes int     `json:"interval_minutes,omitempty" jsonschema:"检查间隔（分钟），默认60，最小10"`
+	SpikeMinDelta   int     `json:"spike_min_delta,omitempty" jsonschema:"爆款判定：两次检查间互动总数的最小增量，默认500"`
+	SpikeMinGrowth  float64 `json:"spike_min_growth,omitempty" jsonschema:"爆款判定：最小增幅，0.5表示增长50%，默认0.5"`
+	Webhook         string  `json:"webhook,omitempty" jsonschema:"告警推送地址，为空时使用 MONITOR_WEBHOOK_URL"`
+}
+
+// UpdateWatchArgs 修改监控项的参数，只修改提供的字段
+type UpdateWatchArgs struct {
+	ID              string   `json:"id" jsonschema:"监控项ID"`
+	Target          *string  `json:"target,omitempty" jsonschema:"监控目标"`
+	XsecToken       *string  `json:"xsec_token,omitempty" jsonschema:"用户访问令牌"`
+	Name            *string  `json:"name,omitempty" jsonschema:"备注名"`
+	SortBy          *string  `json:"sort_by,omitempty" jsonschema:"关键词搜索排序"`
+	MaxResults      *int     `json:"max_results,omitempty" jsonschema:"关键词每次检查的结果数"`
+	IntervalMinutes *int     `json:"interval_minutes,omitempty" jsonschema:"检查间隔（分钟）"`
+	SpikeMinDelta   *int     `json:"spike_min_delta,omitempty" jsonschema:"爆款判定的最小互动增量"`
+	SpikeMinGrowth  *float64 `json:"spike_min_growth,omitempty" jsonschema:"爆款判定的最小增幅"`
+	Webhook         *string  `json:"webhook,omitempty" jsonschema:"告警推送地址"`
+	Enabled         *bool    `json:"enabled,omitempty" jsonschema:"是否启用"`
+}
+
+// WatchIDArgs 只需要监控项ID的参数
+type WatchIDArgs struct {
+	ID string `json:"id" jsonschema:"监控项ID"`
+}
+
+// ListMonitorAlertsArgs 查询监控告警的参数
+type ListMonitorAlertsArgs struct {
+	WatchID string `json:"watch_id,omitempty" jsonschema:"只看某个监控项的告警"`
+	Type    string `json:"type,omitempty" jsonschema:"告警类型: new_note(新笔记)|spike(互动暴涨)"`
+	Limit   int    `json:"limit,omitempty" jsonschema:"返回数量上限，默认不限制"`
+}
+
 // InitMCPServer 初始化 MCP Server
 func InitMCPServer(appServer *AppServer) *mcp.Server {
 	// 创建 MCP Server
@@ -146,6 +288,29 @@ func withPanicRecovery[T any](
 	}
 }
 
+// progressNotifier 把视频上传和转码进度转发为 MCP 进度通知，客户端没有提供 progressToken 时返回 nil
+func progressNotifier(ctx context.Context, req *mcp.CallToolRequest) platform.ProgressFunc {
+	if req == nil || req.Session == nil || req.Params == nil {
+		return nil
+	}
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return nil
+	}
+
+	return func(p platform.UploadProgress) {
+		err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Progress:      p.Overall(),
+			Total:         100,
+			Message:       p.String(),
+		})
+		if err != nil {
+			logrus.Warnf("发送进度通知失败: %v", err)
+		}
+	}
+}
+
 // registerTools 注册所有 MCP 工具
 func registerTools(server *mcp.Server, appServer *AppServer) {
 	// 工具 1: 检查登录状态
@@ -209,11 +374,17 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		withPanicRecovery("publish_content", func(ctx context.Context, req *mcp.CallToolRequest, args PublishContentArgs) (*mcp.CallToolResult, any, error) {
 			// 转换参数格式到现有的 handler
 			argsMap := map[string]interface{}{
-				"title":       args.Title,
-				"content":     args.Content,
-				"images":      convertStringsToInterfaces(args.Images),
-				"tags":        convertStringsToInterfaces(args.Tags),
-				"schedule_at": args.ScheduleAt,
+				"title":                args.Title,
+				"content":              args.Content,
+				"images":               convertStringsToInterfaces(args.Images),
+				"tags":                 convertStringsToInterfaces(args.Tags),
+				"schedule_at":          args.ScheduleAt,
+				"existing_topics_only": args.ExistingTopicsOnly,
+				"mentions":             convertStringsToInterfaces(args.Mentions),
+				"location":             args.Location,
+				"visibility":           args.Visibility,
+				"collection":           args.Collection,
+				"original":             args.Original,
 			}
 			result := appServer.handlePublishContent(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -240,7 +411,7 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 	mcp.AddTool(server,
 		&mcp.Tool{
 			Name:        "search_feeds",
-			Description: "搜索小红书内容（需要已登录）",
+			Description: "搜索小红书内容（需要已登录）。默认返回首屏结果；设置max_results/page/cursor时滚动加载并去重，返回cursor用于继续获取",
 			Annotations: &mcp.ToolAnnotations{
 				Title:        "Search Feeds",
 				ReadOnlyHint: true,
@@ -332,6 +503,7 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 				"feed_id":    args.FeedID,
 				"xsec_token": args.XsecToken,
 				"content":    args.Content,
+				"mentions":   convertStringsToInterfaces(args.Mentions),
 			}
 			result := appServer.handlePostComment(ctx, argsMap)
 			return convertToMCPResult(result), nil, nil
@@ -380,13 +552,21 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		},
 		withPanicRecovery("publish_with_video", func(ctx context.Context, req *mcp.CallToolRequest, args PublishVideoArgs) (*mcp.CallToolResult, any, error) {
 			argsMap := map[string]interface{}{
-				"title":       args.Title,
-				"content":     args.Content,
-				"video":       args.Video,
-				"tags":        convertStringsToInterfaces(args.Tags),
-				"schedule_at": args.ScheduleAt,
+				"title":                args.Title,
+				"content":              args.Content,
+				"video":                args.Video,
+				"tags":                 convertStringsToInterfaces(args.Tags),
+				"schedule_at":          args.ScheduleAt,
+				"existing_topics_only": args.ExistingTopicsOnly,
+				"mentions":             convertStringsToInterfaces(args.Mentions),
+				"cover":                args.Cover,
+				"cover_time":           args.CoverTime,
+				"location":             args.Location,
+				"visibility":           args.Visibility,
+				"collection":           args.Collection,
+				"original":             args.Original,
 			}
-			result := appServer.handlePublishVideo(ctx, argsMap)
+			result := appServer.handlePublishVideo(ctx, argsMap, progressNotifier(ctx, req))
 			return convertToMCPResult(result), nil, nil
 		}),
 	)
@@ -433,7 +613,297 @@ func registerTools(server *mcp.Server, appServer *AppServer) {
 		}),
 	)
 
-	logrus.Infof("Registered %d MCP tools", 13)
+	// 工具 14: 获取我的笔记
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_my_notes",
+			Description: "获取创作中心中自己发布的笔记，包含浏览、点赞、评论、收藏、分享数据及审核状态，支持分页和状态筛选",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List My Notes",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_my_notes", func(ctx context.Context, req *mcp.CallToolRequest, args ListMyNotesArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListMyNotes(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 15: 同步评论收件箱
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "sync_inbox",
+			Description: "同步评论收件箱：遍历自己已发布的笔记和通知中心，把上次同步之后的新评论保存到本地收件箱",
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Sync Comment Inbox",
+			},
+		},
+		withPanicRecovery("sync_inbox", func(ctx context.Context, req *mcp.CallToolRequest, args SyncInboxArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSyncInbox(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 16: 查询评论收件箱
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_inbox",
+			Description: "查询本地评论收件箱，支持按笔记、未读、未回复、关键词筛选，返回评论及已读/已回复状态",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Comment Inbox",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_inbox", func(ctx context.Context, req *mcp.CallToolRequest, args ListInboxArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListInbox(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 17: 回复收件箱评论
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "reply_inbox_comment",
+			Description: "回复收件箱中的评论，只需提供评论ID和回复内容，回复成功后自动标记为已回复",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Reply Inbox Comment",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("reply_inbox_comment", func(ctx context.Context, req *mcp.CallToolRequest, args ReplyInboxArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleReplyInboxComment(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 18: 标记收件箱评论已读
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "mark_inbox_read",
+			Description: "将收件箱中的评论标记为已读，不提供评论ID时标记全部",
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Mark Inbox Read",
+			},
+		},
+		withPanicRecovery("mark_inbox_read", func(ctx context.Context, req *mcp.CallToolRequest, args MarkInboxReadArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleMarkInboxRead(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 19: 按游标分页获取评论
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_feed_comments",
+			Description: "按游标分页获取笔记评论，返回 next_cursor 和 has_more，传入 next_cursor 可在下一次调用中继续翻页",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Feed Comments",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_feed_comments", func(ctx context.Context, req *mcp.CallToolRequest, args ListFeedCommentsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFeedComments(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 20: 导出评论
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "export_feed_comments",
+			Description: "逐页导出笔记的全部评论和楼中楼回复到 JSONL/CSV 文件，每页保存进度，中断后以相同参数再次调用即从断点继续",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Export Feed Comments",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("export_feed_comments", func(ctx context.Context, req *mcp.CallToolRequest, args ExportFeedCommentsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleExportFeedComments(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 21: 新增监控项
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "add_watch",
+			Description: "新增关键词或竞品账号监控，后台按间隔检查，发现新笔记或互动暴涨时记录告警并推送到 webhook",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Add Watch",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("add_watch", func(ctx context.Context, req *mcp.CallToolRequest, args AddWatchArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleAddWatch(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 22: 列出监控项
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_watches",
+			Description: "列出所有监控项及上次检查时间和错误",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Watches",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_watches", func(ctx context.Context, req *mcp.CallToolRequest, _ any) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListWatches(ctx)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 23: 修改监控项
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "update_watch",
+			Description: "修改监控项的目标、间隔、爆款规则、webhook 或启用状态，只修改提供的字段",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Update Watch",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("update_watch", func(ctx context.Context, req *mcp.CallToolRequest, args UpdateWatchArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleUpdateWatch(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 24: 删除监控项
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "remove_watch",
+			Description: "删除监控项及其历史快照",
+			Annotations: &mcp.ToolAnnotations{
+				Title: "Remove Watch",
+			},
+		},
+		withPanicRecovery("remove_watch", func(ctx context.Context, req *mcp.CallToolRequest, args WatchIDArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleRemoveWatch(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 25: 立即检查监控项
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "check_watch",
+			Description: "立即执行一次监控检查，返回本次发现的告警；首次检查只建立基线",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Check Watch",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("check_watch", func(ctx context.Context, req *mcp.CallToolRequest, args WatchIDArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleCheckWatch(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 26: 查询监控告警
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_monitor_alerts",
+			Description: "查询监控告警（新笔记、互动暴涨），按时间倒序",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Monitor Alerts",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_monitor_alerts", func(ctx context.Context, req *mcp.CallToolRequest, args ListMonitorAlertsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListMonitorAlerts(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 27: 下载笔记媒体
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "download_feed_media",
+			Description: "下载笔记的原图、实况图视频和视频到本地目录，并写入包含笔记信息的 meta.json，已下载的文件会被跳过",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Download Feed Media",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("download_feed_media", func(ctx context.Context, req *mcp.CallToolRequest, args DownloadFeedMediaArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleDownloadFeedMedia(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 28: 关注用户
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "follow_user",
+			Description: "关注或取消关注小红书用户，已处于目标状态时不重复操作",
+			Annotations: &mcp.ToolAnnotations{
+				Title:           "Follow User",
+				DestructiveHint: boolPtr(false),
+			},
+		},
+		withPanicRecovery("follow_user", func(ctx context.Context, req *mcp.CallToolRequest, args FollowUserArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleFollowUser(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 29: 我关注的人
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_following",
+			Description: "按游标分页获取当前账号关注的人",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Following",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_following", func(ctx context.Context, req *mcp.CallToolRequest, args ListFollowsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFollowing(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 30: 我的粉丝
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "list_followers",
+			Description: "按游标分页获取当前账号的粉丝，可用于回关",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "List Followers",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("list_followers", func(ctx context.Context, req *mcp.CallToolRequest, args ListFollowsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleListFollowers(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	// 工具 31: 查询话题
+	mcp.AddTool(server,
+		&mcp.Tool{
+			Name:        "search_topics",
+			Description: "按关键词查询小红书话题联想候选及浏览量，用于挑选发布时的话题标签",
+			Annotations: &mcp.ToolAnnotations{
+				Title:        "Search Topics",
+				ReadOnlyHint: true,
+			},
+		},
+		withPanicRecovery("search_topics", func(ctx context.Context, req *mcp.CallToolRequest, args SearchTopicsArgs) (*mcp.CallToolResult, any, error) {
+			result := appServer.handleSearchTopics(ctx, args)
+			return convertToMCPResult(result), nil, nil
+		}),
+	)
+
+	count := 31
+	if appServer.multiPlatformService != nil {
+		count += registerPlatformTools(server, appServer)
+	}
+
+	logrus.Infof("Registered %d MCP tools", count)
 }
 
 // convertToMCPResult 将自定义的 MCPToolResult 转换为官方 SDK 的格式
@@ -475,3 +945,15 @@ func convertStringsToInterfaces(strs []string) []interface{} {
 	}
 	return result
 }
+
+// interfacesToStrings 将 argsMap 中的 []interface{} 参数还原为字符串切片，忽略非字符串元素
+func interfacesToStrings(v interface{}) []string {
+	items, _ := v.([]interface{})
+	var result []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
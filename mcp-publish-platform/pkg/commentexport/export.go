@@ -0,0 +1,206 @@
+package commentexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// 导出格式
+const (
+	FormatJSONL = "jsonl"
+	FormatCSV   = "csv"
+)
+
+// Record 导出的一条评论，一级评论和楼中楼回复平铺，楼中楼通过 ParentID 关联
+type Record struct {
+	ID         string    `json:"id"`
+	ParentID   string    `json:"parent_id,omitempty"`
+	NoteID     string    `json:"note_id"`
+	UserID     string    `json:"user_id"`
+	Nickname   string    `json:"nickname"`
+	Content    string    `json:"content"`
+	LikeCount  string    `json:"like_count"`
+	IPLocation string    `json:"ip_location,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+var csvHeader = []string{"id", "parent_id", "note_id", "user_id", "nickname", "content", "like_count", "ip_location", "created_at"}
+
+func (r *Record) csvRow() []string {
+	return []string{
+		r.ID, r.ParentID, r.NoteID, r.UserID, r.Nickname, r.Content,
+		r.LikeCount, r.IPLocation, r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// ParseFormat 解析导出格式，默认 jsonl
+func ParseFormat(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", FormatJSONL, "json":
+		return FormatJSONL, nil
+	case FormatCSV:
+		return FormatCSV, nil
+	}
+	return "", errors.Errorf("不支持的导出格式: %s（可选 jsonl|csv）", s)
+}
+
+// Writer 逐条写入评论
+type Writer interface {
+	Write(r *Record) error
+	Flush() error
+}
+
+// NewWriter 创建指定格式的 Writer，header 为 true 时 CSV 先写表头
+func NewWriter(w io.Writer, format string, header bool) (Writer, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{enc: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		cw := &csvWriter{w: csv.NewWriter(w)}
+		if header {
+			if err := cw.w.Write(csvHeader); err != nil {
+				return nil, errors.Wrap(err, "写入表头失败")
+			}
+		}
+		return cw, nil
+	}
+	return nil, errors.Errorf("不支持的导出格式: %s", format)
+}
+
+type jsonlWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonlWriter) Write(r *Record) error {
+	return w.enc.Encode(r)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(r *Record) error {
+	return w.w.Write(r.csvRow())
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+// Progress 导出进度，每写完一页保存一次，中断后可从 Cursor 继续
+type Progress struct {
+	FeedID    string    `json:"feed_id"`
+	Format    string    `json:"format"`
+	Output    string    `json:"output"`
+	Cursor    string    `json:"cursor"` // 下一页游标
+	Offset    int64     `json:"offset"` // 已确认写入的文件长度，恢复时截断到这里
+	Pages     int       `json:"pages"`
+	Comments  int       `json:"comments"` // 一级评论数
+	Records   int       `json:"records"`  // 含楼中楼的总条数
+	Done      bool      `json:"done"`
+	StartedAt time.Time `json:"started_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProgressPath 导出文件对应的进度文件路径
+func ProgressPath(output string) string {
+	return output + ".progress.json"
+}
+
+// LoadProgress 读取进度文件，不存在时返回 nil
+func LoadProgress(path string) (*Progress, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "读取导出进度失败")
+	}
+
+	var p Progress
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, errors.Wrap(err, "解析导出进度失败")
+	}
+	return &p, nil
+}
+
+// SaveProgress 先写临时文件再重命名保存进度
+func SaveProgress(path string, p *Progress) error {
+	p.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "序列化导出进度失败")
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrap(err, "写入导出进度失败")
+	}
+	return errors.Wrap(os.Rename(tmp, path), "保存导出进度失败")
+}
+
+// OpenOutput 打开导出文件并截断到 offset，丢弃上次中断时未确认的内容
+func OpenOutput(path string, offset int64) (*os.File, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, errors.Wrap(err, "创建导出目录失败")
+		}
+	}
+
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "打开导出文件失败")
+	}
+	if err := f.Truncate(offset); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "截断导出文件失败")
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		f.Close()
+		return nil, errors.Wrap(err, "定位导出文件失败")
+	}
+	return f, nil
+}
+
+// ResolveOutput 将调用方指定的导出文件限制在导出目录 dir 内
+// output 须为相对路径且不能跳出 dir；为空时使用默认文件名
+func ResolveOutput(dir, output, feedID, format string) (string, error) {
+	if output == "" {
+		return DefaultOutput(dir, feedID, format), nil
+	}
+	if filepath.IsAbs(output) || !filepath.IsLocal(output) {
+		return "", errors.Errorf("导出路径 %q 无效：只能是导出目录下的相对路径", output)
+	}
+	return filepath.Join(dir, output), nil
+}
+
+// DefaultOutput 默认导出文件名，feedID 中路径分隔符等字符替换为 _
+func DefaultOutput(dir, feedID, format string) string {
+	return filepath.Join(dir, "comments_"+safeFileName(feedID)+"."+format)
+}
+
+func safeFileName(s string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, s)
+	if name == "" {
+		return "_"
+	}
+	return name
+}
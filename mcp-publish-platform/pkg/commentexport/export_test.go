@@ -0,0 +1,96 @@
+package commentexport
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterFormats(t *testing.T) {
+	r := &Record{ID: "c1", NoteID: "n1", Nickname: "小红", Content: "好看, \"真的\"", CreatedAt: time.Unix(0, 0).UTC()}
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, FormatCSV, true)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(r))
+	require.NoError(t, w.Flush())
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, strings.Join(csvHeader, ","), lines[0])
+	assert.Contains(t, lines[1], `"好看, ""真的"""`)
+
+	buf.Reset()
+	w, err = NewWriter(&buf, FormatJSONL, true)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(r))
+	require.NoError(t, w.Write(r))
+	assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+	_, err = ParseFormat("xml")
+	assert.Error(t, err)
+	f, err := ParseFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSONL, f)
+}
+
+func TestResumeTruncatesUnconfirmedData(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "out", "comments.jsonl")
+
+	f, err := OpenOutput(output, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("{\"id\":\"c1\"}\n")
+	require.NoError(t, err)
+	offset, err := f.Seek(0, io.SeekCurrent)
+	require.NoError(t, err)
+	require.NoError(t, SaveProgress(ProgressPath(output), &Progress{FeedID: "n1", Cursor: "next", Offset: offset}))
+
+	// 进度保存之后写入的半页数据在恢复时被丢弃
+	_, err = f.WriteString("{\"id\":\"c2\"")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	p, err := LoadProgress(ProgressPath(output))
+	require.NoError(t, err)
+	require.NotNil(t, p)
+	assert.Equal(t, "next", p.Cursor)
+
+	f, err = OpenOutput(output, p.Offset)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	data, err := os.ReadFile(output)
+	require.NoError(t, err)
+	assert.Equal(t, "{\"id\":\"c1\"}\n", string(data))
+
+	missing, err := LoadProgress(filepath.Join(t.TempDir(), "none.json"))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestResolveOutput(t *testing.T) {
+	dir := "exports"
+
+	got, err := ResolveOutput(dir, "", "abc123", FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "comments_abc123.csv"), got)
+
+	got, err = ResolveOutput(dir, "", "../../etc/x", FormatJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "comments_______etc_x.jsonl"), got)
+
+	got, err = ResolveOutput(dir, "notes/a.jsonl", "n1", FormatJSONL)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "notes", "a.jsonl"), got)
+
+	for _, output := range []string{"/tmp/a.jsonl", "../a.jsonl", "notes/../../a.jsonl"} {
+		_, err := ResolveOutput(dir, output, "n1", FormatJSONL)
+		assert.Error(t, err, output)
+	}
+}
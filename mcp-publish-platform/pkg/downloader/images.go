@@ -49,15 +49,7 @@ func (d *ImageDownloader) DownloadImage(imageURL string) (string, error) {
 	if err != nil {
 		return "", errors.Wrap(err, "failed to create request")
 	}
-
-	// 设置 User-Agent，模拟浏览器请求
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
-
-	// 设置 Referer，使用图片 URL 的域名
-	parsedURL, _ := url.Parse(imageURL)
-	if parsedURL != nil {
-		req.Header.Set("Referer", fmt.Sprintf("%s://%s/", parsedURL.Scheme, parsedURL.Host))
-	}
+	setAntiHotlinkHeaders(req)
 
 	// 下载图片数据
 	resp, err := d.httpClient.Do(req)
@@ -124,6 +116,17 @@ func (d *ImageDownloader) DownloadImages(imageURLs []string) ([]string, error) {
 	return localPaths, nil
 }
 
+// setAntiHotlinkHeaders 设置防盗链需要的请求头
+func setAntiHotlinkHeaders(req *http.Request) {
+	// 设置 User-Agent，模拟浏览器请求
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36")
+
+	// 设置 Referer，使用资源 URL 的域名
+	if req.URL != nil {
+		req.Header.Set("Referer", fmt.Sprintf("%s://%s/", req.URL.Scheme, req.URL.Host))
+	}
+}
+
 // isValidImageURL 检查是否为有效的图片URL
 func (d *ImageDownloader) isValidImageURL(rawURL string) bool {
 	// 检查是否以http/https开头
@@ -0,0 +1,147 @@
+package downloader
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/h2non/filetype"
+	"github.com/pkg/errors"
+)
+
+// sniffSize 识别文件类型需要的头部字节数
+const sniffSize = 262
+
+// MediaFile 下载到本地的媒体文件
+type MediaFile struct {
+	Path        string `json:"path"`
+	URL         string `json:"url"`
+	Size        int64  `json:"size"`
+	ContentType string `json:"content_type,omitempty"`
+	Skipped     bool   `json:"skipped,omitempty"` // 文件已存在，未重新下载
+}
+
+// MediaDownloader 图片、视频等媒体下载器
+// 与 ImageDownloader 使用相同的防盗链请求头，但以流式写入，适合大文件
+type MediaDownloader struct {
+	httpClient *http.Client
+}
+
+// NewMediaDownloader 创建媒体下载器
+func NewMediaDownloader() *MediaDownloader {
+	return &MediaDownloader{
+		// 视频可能很大，整体超时交给 ctx 控制，这里只限制等待响应头的时间
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				ResponseHeaderTimeout: 30 * time.Second,
+			},
+		},
+	}
+}
+
+// Download 下载 mediaURL 到 dir/name.<扩展名>，扩展名按文件内容识别
+// 同名文件已存在时直接返回，便于中断后重新执行
+func (d *MediaDownloader) Download(ctx context.Context, mediaURL, dir, name string) (*MediaFile, error) {
+	if !IsImageURL(mediaURL) {
+		return nil, errors.Errorf("invalid media URL: %s", mediaURL)
+	}
+	if existing := findExisting(dir, name); existing != "" {
+		info, err := os.Stat(existing)
+		if err == nil {
+			return &MediaFile{Path: existing, URL: mediaURL, Size: info.Size(), Skipped: true}, nil
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "failed to create media dir")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, mediaURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
+	}
+	setAntiHotlinkHeaders(req)
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to download media from %s", mediaURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download failed with status %d for URL: %s", resp.StatusCode, mediaURL)
+	}
+
+	// 先写临时文件，识别类型后再重命名，避免留下不完整的文件
+	tmpPath := filepath.Join(dir, name+".part")
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create media file")
+	}
+
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(resp.Body, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		f.Close()
+		os.Remove(tmpPath)
+		return nil, errors.Wrap(err, "failed to read media data")
+	}
+	head = head[:n]
+
+	size, err := f.Write(head)
+	if err == nil {
+		var rest int64
+		rest, err = io.Copy(f, resp.Body)
+		size += int(rest)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, errors.Wrap(err, "failed to save media")
+	}
+
+	ext, contentType := detectExtension(head, resp.Header.Get("Content-Type"))
+	path := filepath.Join(dir, name+"."+ext)
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return nil, errors.Wrap(err, "failed to save media")
+	}
+
+	return &MediaFile{Path: path, URL: mediaURL, Size: int64(size), ContentType: contentType}, nil
+}
+
+// detectExtension 按文件内容识别扩展名，识别不了时参考 Content-Type
+func detectExtension(head []byte, contentType string) (string, string) {
+	if kind, err := filetype.Match(head); err == nil && kind != filetype.Unknown {
+		return kind.Extension, kind.MIME.Value
+	}
+	contentType = strings.TrimSpace(strings.Split(contentType, ";")[0])
+	switch {
+	case strings.HasPrefix(contentType, "video/"):
+		return "mp4", contentType
+	case contentType == "image/jpeg":
+		return "jpg", contentType
+	case strings.HasPrefix(contentType, "image/"):
+		return strings.TrimPrefix(contentType, "image/"), contentType
+	}
+	return "bin", contentType
+}
+
+// findExisting 查找已下载的同名文件（不含未完成的 .part）
+func findExisting(dir, name string) string {
+	matches, _ := filepath.Glob(filepath.Join(dir, name+".*"))
+	for _, m := range matches {
+		if !strings.HasSuffix(m, ".part") {
+			return m
+		}
+	}
+	return ""
+}
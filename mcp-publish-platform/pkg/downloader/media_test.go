@@ -0,0 +1,92 @@
+package downloader
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// pngHeader 最小的 PNG 文件头，足够 filetype 识别
+var pngHeader = []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A, 0, 0, 0, 0x0D, 0x49, 0x48, 0x44, 0x52}
+
+func TestMediaDownloader_Download(t *testing.T) {
+	var referer, userAgent string
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		referer = r.Header.Get("Referer")
+		userAgent = r.Header.Get("User-Agent")
+		w.Write(pngHeader)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	d := NewMediaDownloader()
+
+	file, err := d.Download(context.Background(), server.URL+"/a", dir, "image_01")
+	if err != nil {
+		t.Fatalf("Download failed: %v", err)
+	}
+	if filepath.Base(file.Path) != "image_01.png" {
+		t.Errorf("path = %s, want image_01.png", file.Path)
+	}
+	if file.Size != int64(len(pngHeader)) {
+		t.Errorf("size = %d, want %d", file.Size, len(pngHeader))
+	}
+	if referer != server.URL+"/" {
+		t.Errorf("referer = %q, want %q", referer, server.URL+"/")
+	}
+	if !strings.Contains(userAgent, "Mozilla") {
+		t.Errorf("user agent not set: %q", userAgent)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "image_01.part")); !os.IsNotExist(err) {
+		t.Errorf("temp file left behind")
+	}
+
+	// 再次下载时复用已有文件
+	again, err := d.Download(context.Background(), server.URL+"/a", dir, "image_01")
+	if err != nil {
+		t.Fatalf("second Download failed: %v", err)
+	}
+	if !again.Skipped || again.Path != file.Path || hits != 1 {
+		t.Errorf("expected existing file to be reused, got %+v, hits=%d", again, hits)
+	}
+}
+
+func TestMediaDownloader_DownloadError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	if _, err := NewMediaDownloader().Download(context.Background(), server.URL, dir, "video"); err == nil {
+		t.Fatal("expected error for 403 response")
+	}
+	if matches, _ := filepath.Glob(filepath.Join(dir, "video*")); len(matches) != 0 {
+		t.Errorf("unexpected files left: %v", matches)
+	}
+}
+
+func TestDetectExtension(t *testing.T) {
+	tests := []struct {
+		head        []byte
+		contentType string
+		want        string
+	}{
+		{pngHeader, "", "png"},
+		{[]byte("????"), "video/mp4", "mp4"},
+		{[]byte("????"), "image/jpeg; charset=binary", "jpg"},
+		{[]byte("????"), "image/webp", "webp"},
+		{[]byte("????"), "", "bin"},
+	}
+	for _, tt := range tests {
+		if got, _ := detectExtension(tt.head, tt.contentType); got != tt.want {
+			t.Errorf("detectExtension(%q) = %s, want %s", tt.contentType, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+package inbox
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound 评论不在收件箱中
+var ErrNotFound = errors.New("评论不存在")
+
+// 评论来源
+const (
+	SourceNote         = "note"         // 遍历自己的笔记获得
+	SourceNotification = "notification" // 通知中心获得
+)
+
+// Comment 收件箱中的一条评论
+type Comment struct {
+	ID            string    `json:"id"`
+	NoteID        string    `json:"note_id"`
+	XsecToken     string    `json:"xsec_token"`
+	NoteTitle     string    `json:"note_title,omitempty"`
+	UserID        string    `json:"user_id"`
+	Nickname      string    `json:"nickname"`
+	Content       string    `json:"content"`
+	ParentID      string    `json:"parent_id,omitempty"`      // 楼中楼回复所属的一级评论
+	TargetContent string    `json:"target_content,omitempty"` // 被回复的评论内容
+	Source        string    `json:"source"`
+	CreatedAt     time.Time `json:"created_at"`
+	FetchedAt     time.Time `json:"fetched_at"`
+	Read          bool      `json:"read"`
+	Replied       bool      `json:"replied"`
+	ReplyContent  string    `json:"reply_content,omitempty"`
+	RepliedAt     time.Time `json:"replied_at,omitempty"`
+	ReplyError    string    `json:"reply_error,omitempty"`
+}
+
+// Filter 收件箱查询条件
+type Filter struct {
+	NoteID    string // 只看某篇笔记
+	Unread    bool   // 只看未读
+	Unreplied bool   // 只看未回复
+	Keyword   string // 内容或昵称包含关键词
+	Limit     int    // 数量上限，0 表示不限制
+}
+
+func (f Filter) match(c *Comment) bool {
+	if f.NoteID != "" && c.NoteID != f.NoteID {
+		return false
+	}
+	if f.Unread && c.Read {
+		return false
+	}
+	if f.Unreplied && c.Replied {
+		return false
+	}
+	if f.Keyword != "" && !strings.Contains(c.Content, f.Keyword) && !strings.Contains(c.Nickname, f.Keyword) {
+		return false
+	}
+	return true
+}
+
+// Stats 收件箱统计
+type Stats struct {
+	Total     int       `json:"total"`
+	Unread    int       `json:"unread"`
+	Unreplied int       `json:"unreplied"`
+	LastSync  time.Time `json:"last_sync"`
+}
+
+// Store 基于 JSON 文件的评论收件箱
+type Store struct {
+	path string
+
+	mu       sync.Mutex
+	loaded   bool
+	comments map[string]*Comment
+	lastSync time.Time
+}
+
+// storeFile 收件箱文件格式
+type storeFile struct {
+	LastSync time.Time  `json:"last_sync"`
+	Comments []*Comment `json:"comments"`
+}
+
+// NewStore 创建收件箱，文件在首次访问时加载
+func NewStore(path string) *Store {
+	return &Store{
+		path:     path,
+		comments: make(map[string]*Comment),
+	}
+}
+
+// LastSync 上次同步时间
+func (s *Store) LastSync() (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return time.Time{}, err
+	}
+	return s.lastSync, nil
+}
+
+// Merge 合并新同步的评论，已存在的评论保留读取/回复状态，返回新增的评论
+// 只有 complete 为 true（本次同步无错误且未被数量上限截断）时才推进同步时间，
+// 否则下次同步仍从原来的时间开始，靠评论ID去重，避免漏掉本次没扫到的评论
+func (s *Store) Merge(comments []Comment, syncedAt time.Time, complete bool) ([]Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	var added []Comment
+	for i := range comments {
+		c := comments[i]
+		if c.ID == "" {
+			continue
+		}
+		if existing, ok := s.comments[c.ID]; ok {
+			// 通知中心拿不到笔记标题，以已有信息为准补全
+			if existing.NoteTitle == "" {
+				existing.NoteTitle = c.NoteTitle
+			}
+			if existing.XsecToken == "" {
+				existing.XsecToken = c.XsecToken
+			}
+			continue
+		}
+		if c.FetchedAt.IsZero() {
+			c.FetchedAt = syncedAt
+		}
+		s.comments[c.ID] = &c
+		added = append(added, c)
+	}
+	if complete && syncedAt.After(s.lastSync) {
+		s.lastSync = syncedAt
+	}
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	return added, nil
+}
+
+// List 按评论时间倒序列出评论
+func (s *Store) List(f Filter) ([]Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	result := make([]Comment, 0)
+	for _, c := range s.comments {
+		if f.match(c) {
+			result = append(result, *c)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID > result[j].ID
+		}
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if f.Limit > 0 && len(result) > f.Limit {
+		result = result[:f.Limit]
+	}
+	return result, nil
+}
+
+// Get 获取单条评论
+func (s *Store) Get(id string) (*Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	c, ok := s.comments[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNotFound, "comment_id=%s", id)
+	}
+	copied := *c
+	return &copied, nil
+}
+
+// MarkRead 标记评论为已读，ids 为空时标记全部，返回实际变更的数量
+func (s *Store) MarkRead(ids []string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	mark := func(c *Comment) {
+		if !c.Read {
+			c.Read = true
+			changed++
+		}
+	}
+	if len(ids) == 0 {
+		for _, c := range s.comments {
+			mark(c)
+		}
+	} else {
+		for _, id := range ids {
+			c, ok := s.comments[id]
+			if !ok {
+				return 0, errors.Wrapf(ErrNotFound, "comment_id=%s", id)
+			}
+			mark(c)
+		}
+	}
+
+	if changed == 0 {
+		return 0, nil
+	}
+	return changed, s.save()
+}
+
+// MarkReplied 记录回复结果，回复成功的评论同时标记为已读
+func (s *Store) MarkReplied(id, content string, replyErr error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	c, ok := s.comments[id]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "comment_id=%s", id)
+	}
+	if replyErr != nil {
+		c.ReplyError = replyErr.Error()
+	} else {
+		c.Read = true
+		c.Replied = true
+		c.ReplyContent = content
+		c.RepliedAt = time.Now()
+		c.ReplyError = ""
+	}
+	return s.save()
+}
+
+// Stats 统计收件箱
+func (s *Store) Stats() (*Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	stats := &Stats{Total: len(s.comments), LastSync: s.lastSync}
+	for _, c := range s.comments {
+		if !c.Read {
+			stats.Unread++
+		}
+		if !c.Replied {
+			stats.Unreplied++
+		}
+	}
+	return stats, nil
+}
+
+func (s *Store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "读取收件箱失败")
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return errors.Wrap(err, "解析收件箱失败")
+	}
+	for _, c := range file.Comments {
+		if c != nil && c.ID != "" {
+			s.comments[c.ID] = c
+		}
+	}
+	s.lastSync = file.LastSync
+	s.loaded = true
+	return nil
+}
+
+// save 先写临时文件再重命名，避免写入中断损坏收件箱
+func (s *Store) save() error {
+	file := storeFile{
+		LastSync: s.lastSync,
+		Comments: make([]*Comment, 0, len(s.comments)),
+	}
+	for _, c := range s.comments {
+		file.Comments = append(file.Comments, c)
+	}
+	sort.Slice(file.Comments, func(i, j int) bool {
+		return file.Comments[i].ID < file.Comments[j].ID
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "序列化收件箱失败")
+	}
+
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Wrap(err, "创建收件箱目录失败")
+		}
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrap(err, "写入收件箱失败")
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.Wrap(err, "保存收件箱失败")
+	}
+	return nil
+}
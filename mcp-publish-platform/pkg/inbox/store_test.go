@@ -0,0 +1,107 @@
+package inbox
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoreMergeKeepsState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inbox.json")
+	store := NewStore(path)
+
+	now := time.Now()
+	added, err := store.Merge([]Comment{
+		{ID: "c1", NoteID: "n1", Content: "第一条", CreatedAt: now.Add(-time.Hour)},
+		{ID: "c2", NoteID: "n1", Content: "第二条", CreatedAt: now},
+	}, now, true)
+	require.NoError(t, err)
+	assert.Len(t, added, 2)
+
+	require.NoError(t, store.MarkReplied("c1", "谢谢", nil))
+
+	// 重新打开，再次同步同一条评论不会覆盖状态
+	store = NewStore(path)
+	added, err = store.Merge([]Comment{{ID: "c1", NoteID: "n1", NoteTitle: "标题", Content: "第一条"}}, now.Add(time.Minute), true)
+	require.NoError(t, err)
+	assert.Empty(t, added)
+
+	c, err := store.Get("c1")
+	require.NoError(t, err)
+	assert.True(t, c.Read)
+	assert.True(t, c.Replied)
+	assert.Equal(t, "谢谢", c.ReplyContent)
+	assert.Equal(t, "标题", c.NoteTitle)
+
+	last, err := store.LastSync()
+	require.NoError(t, err)
+	assert.True(t, last.Equal(now.Add(time.Minute)))
+}
+
+func TestStorePartialSyncKeepsLastSync(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "inbox.json"))
+
+	first := time.Now().Add(-time.Hour)
+	_, err := store.Merge([]Comment{{ID: "c1", NoteID: "n1"}}, first, true)
+	require.NoError(t, err)
+
+	// 同步出错或被截断时只合并评论，不推进同步时间，下次仍从 first 开始扫描
+	added, err := store.Merge([]Comment{{ID: "c1", NoteID: "n1"}, {ID: "c2", NoteID: "n1"}}, time.Now(), false)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	assert.Equal(t, "c2", added[0].ID)
+
+	last, err := store.LastSync()
+	require.NoError(t, err)
+	assert.True(t, last.Equal(first))
+
+	// 下次完整同步再次拿到 c2 时按ID去重
+	added, err = store.Merge([]Comment{{ID: "c2", NoteID: "n1"}, {ID: "c3", NoteID: "n1"}}, time.Now(), true)
+	require.NoError(t, err)
+	require.Len(t, added, 1)
+	assert.Equal(t, "c3", added[0].ID)
+}
+
+func TestStoreListAndMarkRead(t *testing.T) {
+	store := NewStore(filepath.Join(t.TempDir(), "inbox.json"))
+
+	now := time.Now()
+	_, err := store.Merge([]Comment{
+		{ID: "c1", NoteID: "n1", Nickname: "小红", Content: "好看", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "c2", NoteID: "n2", Nickname: "小蓝", Content: "求链接", CreatedAt: now.Add(-time.Hour)},
+		{ID: "c3", NoteID: "n1", Nickname: "小绿", Content: "求教程", CreatedAt: now},
+	}, now, true)
+	require.NoError(t, err)
+
+	list, err := store.List(Filter{})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, "c3", list[0].ID)
+
+	list, err = store.List(Filter{NoteID: "n1", Keyword: "求"})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "c3", list[0].ID)
+
+	n, err := store.MarkRead([]string{"c1", "c2"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	list, err = store.List(Filter{Unread: true})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "c3", list[0].ID)
+
+	_, err = store.MarkRead([]string{"missing"})
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	stats, err := store.Stats()
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 1, stats.Unread)
+	assert.Equal(t, 3, stats.Unreplied)
+}
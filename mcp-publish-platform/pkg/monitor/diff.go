@@ -0,0 +1,96 @@
+package monitor
+
+import (
+	"fmt"
+	"time"
+)
+
+// 告警类型
+const (
+	AlertNewNote = "new_note" // 出现新笔记
+	AlertSpike   = "spike"    // 互动数暴涨
+)
+
+const (
+	defaultSpikeMinDelta  = 500
+	defaultSpikeMinGrowth = 0.5
+)
+
+// SpikeRule 爆款判定规则：两次检查之间互动总数同时满足增量和增幅才告警
+type SpikeRule struct {
+	MinDelta  int     `json:"min_delta"`  // 最小增量，默认 500
+	MinGrowth float64 `json:"min_growth"` // 最小增幅，0.5 表示增长 50%，默认 0.5
+}
+
+func (r SpikeRule) withDefaults() SpikeRule {
+	if r.MinDelta <= 0 {
+		r.MinDelta = defaultSpikeMinDelta
+	}
+	if r.MinGrowth <= 0 {
+		r.MinGrowth = defaultSpikeMinGrowth
+	}
+	return r
+}
+
+// Alert 一条监控告警
+type Alert struct {
+	ID        string    `json:"id"`
+	WatchID   string    `json:"watch_id"`
+	WatchName string    `json:"watch_name"`
+	Type      string    `json:"type"`
+	NoteID    string    `json:"note_id"`
+	XsecToken string    `json:"xsec_token,omitempty"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author,omitempty"`
+	Before    int       `json:"before,omitempty"` // 上次检查的互动总数
+	After     int       `json:"after"`            // 本次检查的互动总数
+	Message   string    `json:"message"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Diff 对比上次快照和本次结果，返回新笔记和爆款告警
+// 尚未建立基线（w.Baselined 为 false）时只建立基线，不产生新笔记告警
+func Diff(w *Watch, prev map[string]NoteSnapshot, current []NoteSnapshot, now time.Time) []Alert {
+	rule := w.Spike.withDefaults()
+	baseline := !w.Baselined
+
+	var alerts []Alert
+	for i := range current {
+		n := &current[i]
+		old, seen := prev[n.ID]
+		switch {
+		case !seen && !baseline:
+			alerts = append(alerts, newAlert(w, n, AlertNewNote, 0, now,
+				fmt.Sprintf("「%s」出现新笔记: %s", w.DisplayName(), n.Title)))
+		case seen:
+			before, after := old.Engagement(), n.Engagement()
+			delta := after - before
+			if delta < rule.MinDelta {
+				continue
+			}
+			if before > 0 && float64(delta)/float64(before) < rule.MinGrowth {
+				continue
+			}
+			alerts = append(alerts, newAlert(w, n, AlertSpike, before, now,
+				fmt.Sprintf("「%s」笔记互动暴涨: %s，%d → %d（+%d）", w.DisplayName(), n.Title, before, after, delta)))
+		}
+	}
+	return alerts
+}
+
+func newAlert(w *Watch, n *NoteSnapshot, typ string, before int, now time.Time, message string) Alert {
+	return Alert{
+		ID:        newID(),
+		WatchID:   w.ID,
+		WatchName: w.DisplayName(),
+		Type:      typ,
+		NoteID:    n.ID,
+		XsecToken: n.XsecToken,
+		Title:     n.Title,
+		Author:    n.Author,
+		Before:    before,
+		After:     n.Engagement(),
+		Message:   message,
+		CreatedAt: now,
+	}
+}
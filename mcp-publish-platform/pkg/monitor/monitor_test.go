@@ -0,0 +1,120 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	w := &Watch{ID: "w1", Type: TypeKeyword, Target: "露营"}
+	now := time.Now()
+
+	current := []NoteSnapshot{
+		{ID: "n1", Title: "旧笔记", Likes: 100},
+		{ID: "n2", Title: "新笔记", Likes: 10},
+	}
+
+	// 首次检查只建立基线
+	assert.Empty(t, Diff(w, nil, current, now))
+
+	// 基线为空时，之后出现的笔记仍然是新笔记
+	w.Baselined = true
+	require.Len(t, Diff(w, nil, current, now), 2)
+
+	prev := map[string]NoteSnapshot{
+		"n1": {ID: "n1", Likes: 100},
+	}
+	alerts := Diff(w, prev, current, now)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, AlertNewNote, alerts[0].Type)
+	assert.Equal(t, "n2", alerts[0].NoteID)
+
+	// 增量够但增幅不够不告警，两者都满足才告警
+	current = []NoteSnapshot{{ID: "n1", Title: "旧笔记", Likes: 100, Collects: 550}}
+	prev = map[string]NoteSnapshot{"n1": {ID: "n1", Likes: 100, Collects: 1000}}
+	assert.Empty(t, Diff(w, prev, current, now))
+
+	prev = map[string]NoteSnapshot{"n1": {ID: "n1", Likes: 100}}
+	alerts = Diff(w, prev, current, now)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, AlertSpike, alerts[0].Type)
+	assert.Equal(t, 100, alerts[0].Before)
+	assert.Equal(t, 650, alerts[0].After)
+}
+
+func TestStoreWatchLifecycle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "monitor.json")
+	store := NewStore(path)
+
+	_, err := store.AddWatch(Watch{Type: TypeUser, Target: "u1"})
+	assert.Error(t, err, "user watch requires xsec_token")
+
+	w, err := store.AddWatch(Watch{Type: TypeKeyword, Target: " 露营 ", Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, "露营", w.Target)
+	assert.Equal(t, defaultIntervalMinutes, w.IntervalMinutes)
+	assert.True(t, w.Due(time.Now()))
+
+	_, err = store.AddWatch(Watch{Type: TypeKeyword, Target: "露营"})
+	assert.Error(t, err, "duplicate watch")
+
+	now := time.Now()
+	require.NoError(t, store.RecordCheck(w.ID, nil, nil, errors.New("timeout"), now))
+	got, err := store.GetWatch(w.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Baselined, "failed check does not baseline")
+
+	require.NoError(t, store.RecordCheck(w.ID, []NoteSnapshot{{ID: "n1", FirstSeen: now}}, []Alert{{ID: "a1", WatchID: w.ID, Type: AlertNewNote}}, nil, now))
+
+	// 重新打开后数据仍在，首次出现时间保持不变
+	store = NewStore(path)
+	require.NoError(t, store.RecordCheck(w.ID, []NoteSnapshot{{ID: "n1", FirstSeen: now.Add(time.Hour)}}, nil, nil, now.Add(time.Hour)))
+	snapshot, err := store.Snapshot(w.ID)
+	require.NoError(t, err)
+	assert.True(t, snapshot["n1"].FirstSeen.Equal(now))
+
+	got, err = store.GetWatch(w.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Baselined)
+	assert.False(t, got.Due(now.Add(time.Hour+time.Minute)))
+
+	updated, err := store.UpdateWatch(w.ID, func(w *Watch) { w.Target = "徒步"; w.Type = TypeUser })
+	require.NoError(t, err)
+	assert.Equal(t, TypeKeyword, updated.Type)
+	assert.False(t, updated.Baselined, "new target needs a new baseline")
+	snapshot, err = store.Snapshot(w.ID)
+	require.NoError(t, err)
+	assert.Empty(t, snapshot)
+
+	alerts, err := store.ListAlerts(AlertFilter{WatchID: w.ID})
+	require.NoError(t, err)
+	assert.Len(t, alerts, 1)
+
+	require.NoError(t, store.DeleteWatch(w.ID))
+	_, err = store.GetWatch(w.ID)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestNotifierSend(t *testing.T) {
+	var got WebhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+	}))
+	defer srv.Close()
+
+	n := NewNotifier()
+	require.NoError(t, n.Send(context.Background(), srv.URL, []Alert{{Message: "a"}, {Message: "b"}}))
+	assert.Equal(t, "a\nb", got.Text)
+	assert.Len(t, got.Alerts, 2)
+
+	require.NoError(t, n.Send(context.Background(), "", []Alert{{Message: "a"}}))
+}
@@ -0,0 +1,426 @@
+package monitor
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNotFound 监控项不存在
+var ErrNotFound = errors.New("监控项不存在")
+
+// 监控类型
+const (
+	TypeKeyword = "keyword" // 关键词搜索结果
+	TypeUser    = "user"    // 竞品账号主页
+)
+
+const (
+	defaultIntervalMinutes = 60
+	minIntervalMinutes     = 10
+	maxAlerts              = 500
+)
+
+// Watch 一个监控项
+type Watch struct {
+	ID              string    `json:"id"`
+	Type            string    `json:"type"`                  // keyword|user
+	Target          string    `json:"target"`                // 关键词或用户 ID
+	XsecToken       string    `json:"xsec_token,omitempty"`  // 用户主页需要的访问令牌
+	Name            string    `json:"name,omitempty"`        // 备注名
+	SortBy          string    `json:"sort_by,omitempty"`     // 关键词搜索排序，如 最新
+	MaxResults      int       `json:"max_results,omitempty"` // 关键词每次检查的结果数，默认首屏
+	IntervalMinutes int       `json:"interval_minutes"`
+	Spike           SpikeRule `json:"spike"`
+	Webhook         string    `json:"webhook,omitempty"` // 为空时使用全局 webhook
+	Enabled         bool      `json:"enabled"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	LastCheckedAt   time.Time `json:"last_checked_at,omitempty"`
+	LastError       string    `json:"last_error,omitempty"`
+	Baselined       bool      `json:"baselined,omitempty"` // 已完成首次成功检查，之后出现的笔记才算新笔记
+}
+
+// DisplayName 告警中展示的名称
+func (w *Watch) DisplayName() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	return w.Target
+}
+
+// Due 是否到了检查时间
+func (w *Watch) Due(now time.Time) bool {
+	if !w.Enabled {
+		return false
+	}
+	return now.Sub(w.LastCheckedAt) >= time.Duration(w.IntervalMinutes)*time.Minute
+}
+
+// Validate 校验并补全默认值
+func (w *Watch) Validate() error {
+	w.Target = strings.TrimSpace(w.Target)
+	switch w.Type {
+	case TypeKeyword:
+	case TypeUser:
+		if w.XsecToken == "" {
+			return errors.New("监控用户需要提供 xsec_token")
+		}
+	default:
+		return errors.Errorf("不支持的监控类型: %s（可选 keyword|user）", w.Type)
+	}
+	if w.Target == "" {
+		return errors.New("监控目标不能为空")
+	}
+	if w.IntervalMinutes <= 0 {
+		w.IntervalMinutes = defaultIntervalMinutes
+	}
+	if w.IntervalMinutes < minIntervalMinutes {
+		return errors.Errorf("检查间隔不能小于 %d 分钟", minIntervalMinutes)
+	}
+	w.Spike = w.Spike.withDefaults()
+	return nil
+}
+
+// NoteSnapshot 笔记在某次检查时的数据
+type NoteSnapshot struct {
+	ID        string    `json:"id"`
+	XsecToken string    `json:"xsec_token,omitempty"`
+	Title     string    `json:"title"`
+	Author    string    `json:"author,omitempty"`
+	Likes     int       `json:"likes"`
+	Collects  int       `json:"collects"`
+	Comments  int       `json:"comments"`
+	Shares    int       `json:"shares"`
+	FirstSeen time.Time `json:"first_seen"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Engagement 互动总数
+func (n *NoteSnapshot) Engagement() int {
+	return n.Likes + n.Collects + n.Comments + n.Shares
+}
+
+// AlertFilter 告警查询条件
+type AlertFilter struct {
+	WatchID string
+	Type    string
+	Limit   int
+}
+
+// Store 基于 JSON 文件的监控存储，保存监控项、最近一次快照和告警
+type Store struct {
+	path string
+
+	mu        sync.Mutex
+	loaded    bool
+	watches   map[string]*Watch
+	snapshots map[string]map[string]*NoteSnapshot
+	alerts    []Alert
+}
+
+// storeFile 监控文件格式
+type storeFile struct {
+	Watches   []*Watch                            `json:"watches"`
+	Snapshots map[string]map[string]*NoteSnapshot `json:"snapshots"`
+	Alerts    []Alert                             `json:"alerts"`
+}
+
+// NewStore 创建监控存储，文件在首次访问时加载
+func NewStore(path string) *Store {
+	return &Store{
+		path:      path,
+		watches:   make(map[string]*Watch),
+		snapshots: make(map[string]map[string]*NoteSnapshot),
+	}
+}
+
+// AddWatch 新增监控项
+func (s *Store) AddWatch(w Watch) (*Watch, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	for _, existing := range s.watches {
+		if existing.Type == w.Type && existing.Target == w.Target {
+			return nil, errors.Errorf("已存在相同的监控项: id=%s", existing.ID)
+		}
+	}
+
+	now := time.Now()
+	w.ID = newID()
+	w.CreatedAt = now
+	w.UpdatedAt = now
+	w.LastCheckedAt = time.Time{}
+	w.LastError = ""
+	w.Baselined = false
+	s.watches[w.ID] = &w
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := w
+	return &copied, nil
+}
+
+// UpdateWatch 修改监控项，fn 在副本上修改，校验通过后保存
+func (s *Store) UpdateWatch(id string, fn func(w *Watch)) (*Watch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	existing, ok := s.watches[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNotFound, "id=%s", id)
+	}
+
+	w := *existing
+	fn(&w)
+	// ID、类型、创建时间和基线状态不允许修改
+	w.ID, w.Type, w.CreatedAt, w.Baselined = existing.ID, existing.Type, existing.CreatedAt, existing.Baselined
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	// 换了监控目标后旧快照不再有意义，需要重新建立基线
+	if w.Target != existing.Target {
+		delete(s.snapshots, id)
+		w.Baselined = false
+	}
+	w.UpdatedAt = time.Now()
+	s.watches[id] = &w
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+	copied := w
+	return &copied, nil
+}
+
+// DeleteWatch 删除监控项及其快照
+func (s *Store) DeleteWatch(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	if _, ok := s.watches[id]; !ok {
+		return errors.Wrapf(ErrNotFound, "id=%s", id)
+	}
+	delete(s.watches, id)
+	delete(s.snapshots, id)
+	return s.save()
+}
+
+// GetWatch 获取监控项
+func (s *Store) GetWatch(id string) (*Watch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	w, ok := s.watches[id]
+	if !ok {
+		return nil, errors.Wrapf(ErrNotFound, "id=%s", id)
+	}
+	copied := *w
+	return &copied, nil
+}
+
+// ListWatches 按创建时间列出监控项
+func (s *Store) ListWatches() ([]Watch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	result := make([]Watch, 0, len(s.watches))
+	for _, w := range s.watches {
+		result = append(result, *w)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result, nil
+}
+
+// Snapshot 获取监控项上一次的快照
+func (s *Store) Snapshot(id string) (map[string]NoteSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]NoteSnapshot, len(s.snapshots[id]))
+	for noteID, n := range s.snapshots[id] {
+		result[noteID] = *n
+	}
+	return result, nil
+}
+
+// RecordCheck 保存一次检查的结果：合并快照、追加告警、更新检查时间
+// 合并而不是替换快照，避免笔记暂时掉出搜索结果后再次出现时被当作新笔记；
+// 检查成功即标记为已建立基线，即使结果为空，之后出现的笔记都会告警
+func (s *Store) RecordCheck(id string, notes []NoteSnapshot, alerts []Alert, checkErr error, checkedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+
+	w, ok := s.watches[id]
+	if !ok {
+		return errors.Wrapf(ErrNotFound, "id=%s", id)
+	}
+	w.LastCheckedAt = checkedAt
+	w.LastError = ""
+	if checkErr != nil {
+		w.LastError = checkErr.Error()
+	} else {
+		w.Baselined = true
+	}
+
+	if len(notes) > 0 {
+		snapshot := s.snapshots[id]
+		if snapshot == nil {
+			snapshot = make(map[string]*NoteSnapshot)
+			s.snapshots[id] = snapshot
+		}
+		for i := range notes {
+			n := notes[i]
+			if prev, ok := snapshot[n.ID]; ok && !prev.FirstSeen.IsZero() {
+				n.FirstSeen = prev.FirstSeen
+			}
+			snapshot[n.ID] = &n
+		}
+	}
+
+	s.alerts = append(s.alerts, alerts...)
+	if len(s.alerts) > maxAlerts {
+		s.alerts = s.alerts[len(s.alerts)-maxAlerts:]
+	}
+
+	return s.save()
+}
+
+// ListAlerts 按时间倒序列出告警
+func (s *Store) ListAlerts(f AlertFilter) ([]Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	result := make([]Alert, 0)
+	for i := len(s.alerts) - 1; i >= 0; i-- {
+		a := s.alerts[i]
+		if f.WatchID != "" && a.WatchID != f.WatchID {
+			continue
+		}
+		if f.Type != "" && a.Type != f.Type {
+			continue
+		}
+		result = append(result, a)
+		if f.Limit > 0 && len(result) >= f.Limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *Store) load() error {
+	if s.loaded {
+		return nil
+	}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		s.loaded = true
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "读取监控数据失败")
+	}
+
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return errors.Wrap(err, "解析监控数据失败")
+	}
+	for _, w := range file.Watches {
+		if w != nil && w.ID != "" {
+			// 旧版本文件没有 baselined 字段，有快照即说明已建立过基线
+			if len(file.Snapshots[w.ID]) > 0 {
+				w.Baselined = true
+			}
+			s.watches[w.ID] = w
+		}
+	}
+	for id, snapshot := range file.Snapshots {
+		s.snapshots[id] = snapshot
+	}
+	s.alerts = file.Alerts
+	s.loaded = true
+	return nil
+}
+
+// save 先写临时文件再重命名，避免写入中断损坏数据
+func (s *Store) save() error {
+	file := storeFile{
+		Watches:   make([]*Watch, 0, len(s.watches)),
+		Snapshots: s.snapshots,
+		Alerts:    s.alerts,
+	}
+	for _, w := range s.watches {
+		file.Watches = append(file.Watches, w)
+	}
+	sort.Slice(file.Watches, func(i, j int) bool {
+		return file.Watches[i].CreatedAt.Before(file.Watches[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "序列化监控数据失败")
+	}
+
+	if dir := filepath.Dir(s.path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.Wrap(err, "创建监控数据目录失败")
+		}
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return errors.Wrap(err, "写入监控数据失败")
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return errors.Wrap(err, "保存监控数据失败")
+	}
+	return nil
+}
+
+func newID() string {
+	buf := make([]byte, 6)
+	if _, err := rand.Read(buf); err != nil {
+		return time.Now().Format("20060102150405.000000")
+	}
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookPayload 推送到 webhook 的内容
+type WebhookPayload struct {
+	Text   string  `json:"text"` // 汇总文本，便于直接接入群机器人
+	Alerts []Alert `json:"alerts"`
+}
+
+// Notifier 把告警推送到 webhook
+type Notifier struct {
+	client *http.Client
+}
+
+// NewNotifier 创建 webhook 推送器
+func NewNotifier() *Notifier {
+	return &Notifier{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Send 以 JSON POST 推送告警，url 为空时不推送
+func (n *Notifier) Send(ctx context.Context, url string, alerts []Alert) error {
+	if url == "" || len(alerts) == 0 {
+		return nil
+	}
+
+	payload := WebhookPayload{Alerts: alerts}
+	var text bytes.Buffer
+	for i, a := range alerts {
+		if i > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString(a.Message)
+	}
+	payload.Text = text.String()
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "序列化告警失败")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "创建 webhook 请求失败")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "推送 webhook 失败")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("webhook 返回 %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	return nil
+}
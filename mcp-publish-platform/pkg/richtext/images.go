@@ -0,0 +1,164 @@
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	imgTagPattern = regexp.MustCompile(`(?i)<img\b[^>]*?\bsrc\s*=\s*(?:"([^"]*)"|'([^']*)')[^>]*>`)
+	tagPattern    = regexp.MustCompile(`<(/?)([a-zA-Z][a-zA-Z0-9]*)\b[^>]*?(/?)>`)
+)
+
+// voidElements 没有结束标签的元素，不影响嵌套层级
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+// Segment 正文片段：先粘贴 HTML，再在光标处插入本地图片
+type Segment struct {
+	HTML      string
+	ImagePath string
+}
+
+// SplitLocalImages 在引用本地文件的 <img> 所在的顶层块之后切分 HTML
+// 编辑器无法读取本地路径，这些图片需要通过编辑器的上传入口逐张插入；网络图片保留在 HTML 中。
+// 图片位于段落、列表等块内部时，从块中移除并插入到整个块之后，避免把块切成不闭合的片段。
+// 相对路径按 baseDir 解析，baseDir 为空时只接受绝对路径
+func SplitLocalImages(s, baseDir string) ([]Segment, error) {
+	var (
+		segments []Segment
+		pending  []localImage // 当前顶层块内的本地图片
+		depth    int
+		last     int
+	)
+
+	// flush 输出 [last, end) 的 HTML（去掉其中的本地图片）以及这些图片
+	flush := func(end int) {
+		var b strings.Builder
+		from := last
+		for _, img := range pending {
+			b.WriteString(s[from:img.start])
+			from = img.end
+		}
+		b.WriteString(s[from:end])
+
+		for i, img := range pending {
+			seg := Segment{ImagePath: img.path}
+			if i == 0 {
+				seg.HTML = b.String()
+			}
+			segments = append(segments, seg)
+		}
+		pending = nil
+		last = end
+	}
+
+	for _, m := range tagPattern.FindAllStringSubmatchIndex(s, -1) {
+		closing := m[3] > m[2]
+		name := strings.ToLower(s[m[4]:m[5]])
+		selfClosing := m[7] > m[6] || voidElements[name]
+
+		if name == "img" {
+			path, local, err := localImagePath(s[m[0]:m[1]], baseDir)
+			if err != nil {
+				return nil, err
+			}
+			if local {
+				pending = append(pending, localImage{start: m[0], end: m[1], path: path})
+				if depth == 0 {
+					flush(m[1])
+				}
+			}
+			continue
+		}
+
+		switch {
+		case selfClosing:
+		case closing:
+			if depth > 0 {
+				depth--
+			}
+			if depth == 0 && len(pending) > 0 {
+				flush(m[1])
+			}
+		default:
+			depth++
+		}
+	}
+	if len(pending) > 0 {
+		flush(len(s))
+	}
+
+	if rest := s[last:]; strings.TrimSpace(rest) != "" || len(segments) == 0 {
+		segments = append(segments, Segment{HTML: rest})
+	}
+	return segments, nil
+}
+
+type localImage struct {
+	start, end int
+	path       string
+}
+
+// localImagePath 解析 <img> 标签的 src，返回本地文件路径；网络图片返回 local=false
+func localImagePath(tag, baseDir string) (path string, local bool, err error) {
+	m := imgTagPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return "", false, nil
+	}
+	src := m[1]
+	if src == "" {
+		src = m[2]
+	}
+	src = html.UnescapeString(strings.TrimSpace(src))
+	if !IsLocalPath(src) {
+		return "", false, nil
+	}
+
+	path = strings.TrimPrefix(src, "file://")
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path), true, nil
+	}
+	if baseDir == "" {
+		return "", false, fmt.Errorf("正文图片需使用绝对路径或配置图片目录: %s", src)
+	}
+	if !filepath.IsLocal(path) {
+		return "", false, fmt.Errorf("正文图片路径超出图片目录: %s", src)
+	}
+	return filepath.Join(baseDir, path), true, nil
+}
+
+// IsLocalPath 判断图片地址是否指向本地文件（不含网络地址、data URI 和其他协议）
+func IsLocalPath(src string) bool {
+	lower := strings.ToLower(src)
+	switch {
+	case src == "":
+		return false
+	case strings.HasPrefix(lower, "file://"):
+		return true
+	case strings.HasPrefix(lower, "//"), hasScheme(lower):
+		return false
+	default:
+		return true
+	}
+}
+
+// hasScheme 判断地址是否带有 http:、data:、javascript: 等协议前缀
+// 单个字母的前缀视为 Windows 盘符
+func hasScheme(lower string) bool {
+	i := strings.Index(lower, ":")
+	if i < 2 {
+		return false
+	}
+	for _, c := range lower[:i] {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,172 @@
+// Package richtext 把 Markdown/HTML 正文转换为编辑器可粘贴的 HTML 片段
+package richtext
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*$`)
+	orderedItemPattern = regexp.MustCompile(`^\d+[.)]\s+(.*)$`)
+	bulletItemPattern  = regexp.MustCompile(`^[-*+]\s+(.*)$`)
+	hrPattern          = regexp.MustCompile(`^(?:-{3,}|\*{3,}|_{3,})$`)
+
+	imagePattern  = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)(?:\s+"[^"]*")?\)`)
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	boldPattern   = regexp.MustCompile(`\*\*(.+?)\*\*|__(.+?)__`)
+	italicPattern = regexp.MustCompile(`\*([^*]+)\*|\b_([^_]+)_\b`)
+)
+
+// MarkdownToHTML 转换常用的 Markdown 语法：标题、段落、有序/无序列表、引用、代码块、分割线，
+// 以及行内的图片、链接、粗体、斜体和行内代码。不支持表格和嵌套列表
+func MarkdownToHTML(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	var (
+		out       strings.Builder
+		paragraph []string
+		listTag   string
+		quote     []string
+	)
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + inline(strings.Join(paragraph, " ")) + "</p>")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			out.WriteString("</" + listTag + ">")
+			listTag = ""
+		}
+	}
+	flushQuote := func() {
+		if len(quote) > 0 {
+			out.WriteString("<blockquote><p>" + inline(strings.Join(quote, " ")) + "</p></blockquote>")
+			quote = nil
+		}
+	}
+	flushAll := func() {
+		flushParagraph()
+		closeList()
+		flushQuote()
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			out.WriteString("<" + tag + ">")
+			listTag = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case line == "":
+			flushAll()
+
+		case strings.HasPrefix(line, "```"):
+			flushAll()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			out.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>")
+
+		case headingPattern.MatchString(line):
+			flushAll()
+			m := headingPattern.FindStringSubmatch(line)
+			level := len(m[1])
+			out.WriteString(fmt.Sprintf("<h%d>%s</h%d>", level, inline(m[2]), level))
+
+		case hrPattern.MatchString(line):
+			flushAll()
+			out.WriteString("<hr>")
+
+		case strings.HasPrefix(line, ">"):
+			flushParagraph()
+			closeList()
+			quote = append(quote, strings.TrimSpace(strings.TrimPrefix(line, ">")))
+
+		case bulletItemPattern.MatchString(line):
+			flushParagraph()
+			flushQuote()
+			openList("ul")
+			out.WriteString("<li>" + inline(bulletItemPattern.FindStringSubmatch(line)[1]) + "</li>")
+
+		case orderedItemPattern.MatchString(line):
+			flushParagraph()
+			flushQuote()
+			openList("ol")
+			out.WriteString("<li>" + inline(orderedItemPattern.FindStringSubmatch(line)[1]) + "</li>")
+
+		default:
+			closeList()
+			flushQuote()
+			paragraph = append(paragraph, line)
+		}
+	}
+	flushAll()
+
+	return out.String()
+}
+
+// inline 转换行内语法，先转义 HTML 再替换，图片需在链接之前处理
+func inline(s string) string {
+	s = html.EscapeString(s)
+
+	// 行内代码中的内容不再做其他替换，先换成占位符
+	var codes []string
+	s = codePattern.ReplaceAllStringFunc(s, func(m string) string {
+		codes = append(codes, "<code>"+codePattern.FindStringSubmatch(m)[1]+"</code>")
+		return fmt.Sprintf("\x00%d\x00", len(codes)-1)
+	})
+
+	s = imagePattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := imagePattern.FindStringSubmatch(m)
+		if !safeURL(sub[2]) {
+			return sub[1]
+		}
+		return `<img src="` + sub[2] + `" alt="` + sub[1] + `">`
+	})
+	s = linkPattern.ReplaceAllStringFunc(s, func(m string) string {
+		sub := linkPattern.FindStringSubmatch(m)
+		if !safeURL(sub[2]) {
+			return sub[1]
+		}
+		return `<a href="` + sub[2] + `">` + sub[1] + `</a>`
+	})
+	s = boldPattern.ReplaceAllString(s, "<strong>$1$2</strong>")
+	s = italicPattern.ReplaceAllString(s, "<em>$1$2</em>")
+
+	for i, code := range codes {
+		s = strings.Replace(s, fmt.Sprintf("\x00%d\x00", i), code, 1)
+	}
+	return s
+}
+
+// unsafeSchemes 粘贴后可能执行脚本的链接协议
+var unsafeSchemes = []string{"javascript:", "vbscript:", "data:text/html"}
+
+// safeURL 判断链接是否可以放入 href/src，url 已经过 HTML 转义
+// 浏览器解析协议时会忽略空白和控制字符，比较前先去掉
+func safeURL(url string) bool {
+	url = strings.Map(func(r rune) rune {
+		if r <= ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(html.UnescapeString(url)))
+	for _, scheme := range unsafeSchemes {
+		if strings.HasPrefix(url, scheme) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package richtext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want string
+	}{
+		{name: "标题", md: "# 标题一\n### 标题三 ###", want: "<h1>标题一</h1><h3>标题三</h3>"},
+		{name: "段落合并换行", md: "第一行\n第二行\n\n第二段", want: "<p>第一行 第二行</p><p>第二段</p>"},
+		{name: "无序列表", md: "- 苹果\n* 香蕉", want: "<ul><li>苹果</li><li>香蕉</li></ul>"},
+		{name: "有序列表", md: "1. 第一步\n2) 第二步\n\n结束", want: "<ol><li>第一步</li><li>第二步</li></ol><p>结束</p>"},
+		{name: "引用", md: "> 引用内容", want: "<blockquote><p>引用内容</p></blockquote>"},
+		{name: "代码块转义", md: "```go\nif a < b {}\n```", want: "<pre><code>if a &lt; b {}</code></pre>"},
+		{name: "分割线", md: "上\n\n---\n\n下", want: "<p>上</p><hr><p>下</p>"},
+		{name: "粗体斜体", md: "**粗** 和 *斜* 以及 __粗__", want: "<p><strong>粗</strong> 和 <em>斜</em> 以及 <strong>粗</strong></p>"},
+		{name: "链接和图片", md: "见[官网](https://a.com)\n\n![封面](/tmp/a.png)", want: `<p>见<a href="https://a.com">官网</a></p><p><img src="/tmp/a.png" alt="封面"></p>`},
+		{name: "行内代码不替换", md: "运行 `a*b*c`", want: "<p>运行 <code>a*b*c</code></p>"},
+		{name: "HTML 转义", md: "<script>", want: "<p>&lt;script&gt;</p>"},
+		{name: "下划线变量名", md: "snake_case_name", want: "<p>snake_case_name</p>"},
+		{name: "过滤脚本链接", md: "[点我](javascript:void0) ![图](JavaScript:x)", want: "<p>点我 图</p>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MarkdownToHTML(tt.md))
+		})
+	}
+}
+
+func TestSplitLocalImages(t *testing.T) {
+	s := `<p>开头</p><img src="/tmp/a.png"><p>中间<img src="https://cdn.com/b.png"></p><img alt="c" src='file:///tmp/c.jpg'/>`
+	got, err := SplitLocalImages(s, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{
+		{HTML: "<p>开头</p>", ImagePath: "/tmp/a.png"},
+		{HTML: `<p>中间<img src="https://cdn.com/b.png"></p>`, ImagePath: "/tmp/c.jpg"},
+	}, got)
+
+	got, err = SplitLocalImages("<p>纯文本</p>", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{{HTML: "<p>纯文本</p>"}}, got)
+}
+
+func TestSplitLocalImagesInsideBlock(t *testing.T) {
+	s := `<p>前<img src="/tmp/a.png">后<img src="/tmp/b.png"></p><ul><li>项</li></ul>`
+	got, err := SplitLocalImages(s, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{
+		{HTML: "<p>前后</p>", ImagePath: "/tmp/a.png"},
+		{ImagePath: "/tmp/b.png"},
+		{HTML: "<ul><li>项</li></ul>"},
+	}, got)
+}
+
+func TestSplitLocalImagesRelative(t *testing.T) {
+	s := `<p><img src="img/a.png"></p>`
+	_, err := SplitLocalImages(s, "")
+	assert.Error(t, err, "未配置图片目录时不接受相对路径")
+
+	got, err := SplitLocalImages(s, "/data/images")
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{{HTML: "<p></p>", ImagePath: "/data/images/img/a.png"}}, got)
+
+	_, err = SplitLocalImages(`<img src="../secret.png">`, "/data/images")
+	assert.Error(t, err, "不能跳出图片目录")
+
+	got, err = SplitLocalImages(`<img src="javascript:alert(1)">`, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []Segment{{HTML: `<img src="javascript:alert(1)">`}}, got)
+}
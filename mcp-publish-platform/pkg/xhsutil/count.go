@@ -0,0 +1,50 @@
+package xhsutil
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// countPattern 匹配展示文案中的数值部分，如 "1.2亿次浏览" 中的 "1.2亿"
+var countPattern = regexp.MustCompile(`\d[\d,]*(?:\.\d+)?\s*[万亿千wWkK]?`)
+
+// ParseCount 解析小红书展示的互动数
+// 支持 "1234"、"1,234"、"1.2万"、"3千"、"1.5w"、"10万+" 等格式，无法解析时返回 0
+func ParseCount(s string) int {
+	s = strings.TrimSpace(s)
+	s = strings.TrimSuffix(s, "+")
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return 0
+	}
+
+	multiplier := 1.0
+	switch {
+	case strings.HasSuffix(s, "亿"):
+		multiplier, s = 1e8, strings.TrimSuffix(s, "亿")
+	case strings.HasSuffix(s, "万"):
+		multiplier, s = 1e4, strings.TrimSuffix(s, "万")
+	case strings.HasSuffix(s, "w"), strings.HasSuffix(s, "W"):
+		multiplier, s = 1e4, s[:len(s)-1]
+	case strings.HasSuffix(s, "千"):
+		multiplier, s = 1e3, strings.TrimSuffix(s, "千")
+	case strings.HasSuffix(s, "k"), strings.HasSuffix(s, "K"):
+		multiplier, s = 1e3, s[:len(s)-1]
+	}
+
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return int(n*multiplier + 0.5)
+}
+
+// ExtractCount 从 "1.2亿次浏览"、"播放 3.4万" 等展示文案中提取第一个数值，无数值时返回 0
+func ExtractCount(s string) int {
+	m := countPattern.FindString(s)
+	if m == "" {
+		return 0
+	}
+	return ParseCount(m)
+}
@@ -0,0 +1,54 @@
+package xhsutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "空字符串", input: "", want: 0},
+		{name: "纯数字", input: "1234", want: 1234},
+		{name: "千分位", input: "1,234", want: 1234},
+		{name: "万", input: "1.2万", want: 12000},
+		{name: "整数万", input: "3万", want: 30000},
+		{name: "千", input: "3千", want: 3000},
+		{name: "小写w", input: "1.5w", want: 15000},
+		{name: "k", input: "2.3k", want: 2300},
+		{name: "亿", input: "1.1亿", want: 110000000},
+		{name: "加号后缀", input: "10万+", want: 100000},
+		{name: "前后空格", input: " 56 ", want: 56},
+		{name: "非数字文本", input: "赞", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseCount(tt.input))
+		})
+	}
+}
+
+func TestExtractCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{name: "空字符串", input: "", want: 0},
+		{name: "浏览量", input: "1.2亿次浏览", want: 120000000},
+		{name: "前置文案", input: "播放 3.4万", want: 34000},
+		{name: "千分位", input: "12,345人参与", want: 12345},
+		{name: "无数值", input: "暂无浏览", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractCount(tt.input))
+		})
+	}
+}
@@ -23,8 +23,19 @@ func SetupMultiPlatformRoutes(r *gin.Engine, service *MultiPlatformService, getB
 			platformGroup.GET("/check-login", HandleCheckLogin(service, getBrowserPage))
 			platformGroup.POST("/publish", HandlePlatformPublish(service, getBrowserPage))
 			platformGroup.POST("/publish-video", HandlePlatformPublishVideo(service, getBrowserPage))
+			platformGroup.GET("/drafts", HandlePlatformListDrafts(service, getBrowserPage))
+			platformGroup.POST("/drafts/:draft_id/publish", HandlePlatformPublishDraft(service, getBrowserPage))
 			platformGroup.GET("/feeds", HandlePlatformGetFeeds(service, getBrowserPage))
 			platformGroup.GET("/feeds/:feed_id", HandlePlatformGetFeedDetail(service, getBrowserPage))
+			platformGroup.PUT("/feeds/:feed_id", HandlePlatformEditPost(service, getBrowserPage))
+			platformGroup.DELETE("/feeds/:feed_id", HandlePlatformDeletePost(service, getBrowserPage))
+			platformGroup.POST("/feeds/:feed_id/like", HandlePlatformLike(service, getBrowserPage))
+			platformGroup.POST("/feeds/:feed_id/comment", HandlePlatformComment(service, getBrowserPage))
+			platformGroup.POST("/feeds/:feed_id/collect", HandlePlatformCollect(service, getBrowserPage))
+			platformGroup.GET("/messages", HandlePlatformListConversations(service, getBrowserPage))
+			platformGroup.GET("/messages/:conversation_id", HandlePlatformGetMessages(service, getBrowserPage))
+			platformGroup.POST("/messages/:conversation_id", HandlePlatformSendMessage(service, getBrowserPage))
+			platformGroup.GET("/topics", HandlePlatformSearchTopics(service, getBrowserPage))
 		}
 	}
 }
@@ -13,26 +13,40 @@ import (
 	"github.com/xpzouying/xiaohongshu-mcp/browser"
 	"github.com/xpzouying/xiaohongshu-mcp/configs"
 	"github.com/xpzouying/xiaohongshu-mcp/cookies"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/inbox"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/monitor"
 	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
 	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
 )
 
 // XiaohongshuService 小红书业务服务
-type XiaohongshuService struct{}
+type XiaohongshuService struct {
+	inbox    *inbox.Store
+	monitor  *monitor.Store
+	notifier *monitor.Notifier
+}
 
 // NewXiaohongshuService 创建小红书服务实例
 func NewXiaohongshuService() *XiaohongshuService {
-	return &XiaohongshuService{}
+	return &XiaohongshuService{
+		inbox:    inbox.NewStore(configs.GetInboxPath()),
+		monitor:  monitor.NewStore(configs.GetMonitorPath()),
+		notifier: monitor.NewNotifier(),
+	}
 }
 
 // PublishRequest 发布请求
 type PublishRequest struct {
-	Title      string   `json:"title" binding:"required"`
-	Content    string   `json:"content" binding:"required"`
-	Images     []string `json:"images" binding:"required,min=1"`
-	Tags       []string `json:"tags,omitempty"`
-	ScheduleAt string   `json:"schedule_at,omitempty"` // 定时发布时间，ISO8601格式，为空则立即发布
+	Title              string   `json:"title" binding:"required"`
+	Content            string   `json:"content" binding:"required"`
+	Images             []string `json:"images" binding:"required,min=1"`
+	Tags               []string `json:"tags,omitempty"`
+	ScheduleAt         string   `json:"schedule_at,omitempty"`          // 定时发布时间，ISO8601格式，为空则立即发布
+	ExistingTopicsOnly bool     `json:"existing_topics_only,omitempty"` // 只使用能匹配到已有话题的标签，其余标签跳过
+	Mentions           []string `json:"mentions,omitempty"`             // 正文末尾 @提及的用户昵称或小红书号
+	platform.PublishSettings
 }
 
 // LoginStatusResponse 登录状态响应
@@ -53,17 +67,25 @@ type PublishResponse struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
 	Images  int    `json:"images"`
-	Status  string `json:"status"`
+	Status  string `json:"status"`            // published|reviewing|unknown
+	Message string `json:"message,omitempty"` // 平台的发布提示
 	PostID  string `json:"post_id,omitempty"`
 }
 
 // PublishVideoRequest 发布视频请求（仅支持本地单个视频文件）
 type PublishVideoRequest struct {
-	Title      string   `json:"title" binding:"required"`
-	Content    string   `json:"content" binding:"required"`
-	Video      string   `json:"video" binding:"required"`
-	Tags       []string `json:"tags,omitempty"`
-	ScheduleAt string   `json:"schedule_at,omitempty"` // 定时发布时间，ISO8601格式，为空则立即发布
+	Title              string   `json:"title" binding:"required"`
+	Content            string   `json:"content" binding:"required"`
+	Video              string   `json:"video" binding:"required"`
+	Tags               []string `json:"tags,omitempty"`
+	ScheduleAt         string   `json:"schedule_at,omitempty"`          // 定时发布时间，ISO8601格式，为空则立即发布
+	ExistingTopicsOnly bool     `json:"existing_topics_only,omitempty"` // 只使用能匹配到已有话题的标签，其余标签跳过
+	Mentions           []string `json:"mentions,omitempty"`             // 正文末尾 @提及的用户昵称或小红书号
+	Cover              string   `json:"cover,omitempty"`                // 自定义封面，本地图片路径或 HTTP/HTTPS 链接
+	CoverTime          float64  `json:"cover_time,omitempty"`           // 截取视频第几秒的画面作为封面，与 cover 二选一
+	platform.PublishSettings
+
+	Progress platform.ProgressFunc `json:"-"` // 上传和转码进度回调，由 MCP 进度通知或 SSE 接口设置
 }
 
 // PublishVideoResponse 发布视频响应
@@ -71,7 +93,8 @@ type PublishVideoResponse struct {
 	Title   string `json:"title"`
 	Content string `json:"content"`
 	Video   string `json:"video"`
-	Status  string `json:"status"`
+	Status  string `json:"status"`            // published|reviewing|unknown
+	Message string `json:"message,omitempty"` // 平台的发布提示
 	PostID  string `json:"post_id,omitempty"`
 }
 
@@ -206,16 +229,24 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 	}
 
 	// 构建发布内容
+	if err := req.PublishSettings.Validate(); err != nil {
+		return nil, err
+	}
+
 	content := xiaohongshu.PublishImageContent{
-		Title:        req.Title,
-		Content:      req.Content,
-		Tags:         req.Tags,
-		ImagePaths:   imagePaths,
-		ScheduleTime: scheduleTime,
+		Title:              req.Title,
+		Content:            req.Content,
+		Tags:               req.Tags,
+		ImagePaths:         imagePaths,
+		ScheduleTime:       scheduleTime,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		Settings:           req.PublishSettings,
 	}
 
 	// 执行发布
-	if err := s.publishContent(ctx, content); err != nil {
+	result, err := s.publishContent(ctx, content)
+	if err != nil {
 		logrus.Errorf("发布内容失败: title=%s %v", content.Title, err)
 		return nil, err
 	}
@@ -224,7 +255,9 @@ func (s *XiaohongshuService) PublishContent(ctx context.Context, req *PublishReq
 		Title:   req.Title,
 		Content: req.Content,
 		Images:  len(imagePaths),
-		Status:  "发布完成",
+		Status:  string(result.State),
+		Message: result.Message,
+		PostID:  result.NoteID,
 	}
 
 	return response, nil
@@ -237,7 +270,7 @@ func (s *XiaohongshuService) processImages(images []string) ([]string, error) {
 }
 
 // publishContent 执行内容发布
-func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) error {
+func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohongshu.PublishImageContent) (*xiaohongshu.PublishResult, error) {
 	b := newBrowser()
 	defer b.Close()
 
@@ -246,7 +279,7 @@ func (s *XiaohongshuService) publishContent(ctx context.Context, content xiaohon
 
 	action, err := xiaohongshu.NewPublishImageAction(page)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// 执行发布
@@ -268,6 +301,12 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 		return nil, fmt.Errorf("视频文件不存在或不可访问: %v", err)
 	}
 
+	// 封面校验：图片与截帧二选一，图片需满足尺寸要求
+	coverPath, err := s.processCover(req.Cover, req.CoverTime)
+	if err != nil {
+		return nil, err
+	}
+
 	// 解析定时发布时间
 	var scheduleTime *time.Time
 	if req.ScheduleAt != "" {
@@ -295,16 +334,27 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 	}
 
 	// 构建发布内容
+	if err := req.PublishSettings.Validate(); err != nil {
+		return nil, err
+	}
+
 	content := xiaohongshu.PublishVideoContent{
-		Title:        req.Title,
-		Content:      req.Content,
-		Tags:         req.Tags,
-		VideoPath:    req.Video,
-		ScheduleTime: scheduleTime,
+		Title:              req.Title,
+		Content:            req.Content,
+		Tags:               req.Tags,
+		VideoPath:          req.Video,
+		ScheduleTime:       scheduleTime,
+		ExistingTopicsOnly: req.ExistingTopicsOnly,
+		Mentions:           req.Mentions,
+		Settings:           req.PublishSettings,
+		CoverPath:          coverPath,
+		CoverTime:          req.CoverTime,
+		Progress:           req.Progress,
 	}
 
 	// 执行发布
-	if err := s.publishVideo(ctx, content); err != nil {
+	result, err := s.publishVideo(ctx, content)
+	if err != nil {
 		return nil, err
 	}
 
@@ -312,13 +362,35 @@ func (s *XiaohongshuService) PublishVideo(ctx context.Context, req *PublishVideo
 		Title:   req.Title,
 		Content: req.Content,
 		Video:   req.Video,
-		Status:  "发布完成",
+		Status:  string(result.State),
+		Message: result.Message,
+		PostID:  result.NoteID,
 	}
 	return resp, nil
 }
 
+// processCover 下载或校验自定义封面，返回本地路径
+func (s *XiaohongshuService) processCover(cover string, coverTime float64) (string, error) {
+	req := &platform.VideoRequest{CoverPath: cover, CoverTime: coverTime}
+	if err := req.ValidateCover(); err != nil {
+		return "", err
+	}
+	if cover == "" {
+		return "", nil
+	}
+
+	paths, err := s.processImages([]string{cover})
+	if err != nil {
+		return "", fmt.Errorf("处理封面失败: %w", err)
+	}
+	if err := platform.CheckCoverSize(paths[0], platform.VideoCoverSpec); err != nil {
+		return "", err
+	}
+	return paths[0], nil
+}
+
 // publishVideo 执行视频发布
-func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) error {
+func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongshu.PublishVideoContent) (*xiaohongshu.PublishResult, error) {
 	b := newBrowser()
 	defer b.Close()
 
@@ -327,7 +399,7 @@ func (s *XiaohongshuService) publishVideo(ctx context.Context, content xiaohongs
 
 	action, err := xiaohongshu.NewPublishVideoAction(page)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	return action.PublishVideo(ctx, content)
@@ -359,6 +431,31 @@ func (s *XiaohongshuService) ListFeeds(ctx context.Context) (*FeedsListResponse,
 	return response, nil
 }
 
+// ListMyNotes 获取创作中心中自己发布的笔记
+func (s *XiaohongshuService) ListMyNotes(ctx context.Context, req *MyNotesRequest) (*xiaohongshu.CreatorNotesResult, error) {
+	status, err := xiaohongshu.ParseCreatorNoteStatus(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *xiaohongshu.CreatorNotesResult
+	err = withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewCreatorNotesAction(page)
+		result, err = action.ListNotes(ctx, xiaohongshu.CreatorNotesRequest{
+			Page:     req.Page,
+			PageSize: req.PageSize,
+			Status:   status,
+		})
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("获取我的笔记失败: %v", err)
+		return nil, err
+	}
+
+	return result, nil
+}
+
 func (s *XiaohongshuService) SearchFeeds(ctx context.Context, keyword string, filters ...xiaohongshu.FilterOption) (*FeedsListResponse, error) {
 	b := newBrowser()
 	defer b.Close()
@@ -381,6 +478,25 @@ func (s *XiaohongshuService) SearchFeeds(ctx context.Context, keyword string, fi
 	return response, nil
 }
 
+// DeepSearchFeeds 滚动加载搜索结果，支持按页码或游标继续获取
+func (s *XiaohongshuService) DeepSearchFeeds(ctx context.Context, req *SearchFeedsRequest) (*xiaohongshu.SearchPage, error) {
+	var result *xiaohongshu.SearchPage
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		result, err = xiaohongshu.NewSearchAction(page).DeepSearch(ctx, req.Keyword, xiaohongshu.DeepSearchOptions{
+			Filters:    []xiaohongshu.FilterOption{req.Filters},
+			MaxResults: req.MaxResults,
+			Page:       req.Page,
+			Cursor:     req.Cursor,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // GetFeedDetail 获取Feed详情
 func (s *XiaohongshuService) GetFeedDetail(ctx context.Context, feedID, xsecToken string, loadAllComments bool) (*FeedDetailResponse, error) {
 	return s.GetFeedDetailWithConfig(ctx, feedID, xsecToken, loadAllComments, xiaohongshu.DefaultCommentLoadConfig())
@@ -436,7 +552,7 @@ func (s *XiaohongshuService) UserProfile(ctx context.Context, userID, xsecToken
 }
 
 // PostCommentToFeed 发表评论到Feed
-func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string) (*PostCommentResponse, error) {
+func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsecToken, content string, mentions []string) (*PostCommentResponse, error) {
 	b := newBrowser()
 	defer b.Close()
 
@@ -445,7 +561,7 @@ func (s *XiaohongshuService) PostCommentToFeed(ctx context.Context, feedID, xsec
 
 	action := xiaohongshu.NewCommentFeedAction(page)
 
-	if err := action.PostComment(ctx, feedID, xsecToken, content); err != nil {
+	if err := action.PostComment(ctx, feedID, xsecToken, content, mentions); err != nil {
 		return nil, err
 	}
 
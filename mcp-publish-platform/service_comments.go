@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/commentexport"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// ListFeedComments 按游标分页获取评论
+func (s *XiaohongshuService) ListFeedComments(ctx context.Context, req *FeedCommentsRequest) (*xiaohongshu.CommentPage, error) {
+	var result *xiaohongshu.CommentPage
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		result, err = xiaohongshu.NewCommentPageAction(page).ListComments(ctx, xiaohongshu.CommentPageRequest{
+			FeedID:          req.FeedID,
+			XsecToken:       req.XsecToken,
+			Cursor:          req.Cursor,
+			PageSize:        req.PageSize,
+			WithSubComments: req.WithSubComments,
+		})
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("获取评论失败: feed_id=%s, cursor=%q, err=%v", req.FeedID, req.Cursor, err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// ExportFeedComments 逐页导出笔记评论到 JSONL/CSV 文件
+// 每写完一页记录一次进度，中断后以相同参数再次调用即从断点继续
+func (s *XiaohongshuService) ExportFeedComments(ctx context.Context, req *CommentExportRequest) (*CommentExportResponse, error) {
+	format, err := commentexport.ParseFormat(req.Format)
+	if err != nil {
+		return nil, err
+	}
+	output, err := commentexport.ResolveOutput(configs.GetExportDir(), req.Output, req.FeedID, format)
+	if err != nil {
+		return nil, err
+	}
+	progressPath := commentexport.ProgressPath(output)
+
+	progress, err := commentexport.LoadProgress(progressPath)
+	if err != nil {
+		return nil, err
+	}
+	if progress != nil && !req.Restart {
+		if progress.FeedID != req.FeedID || progress.Format != format {
+			return nil, fmt.Errorf("导出文件 %s 属于其他笔记或格式（feed_id=%s, format=%s），请更换 output 或设置 restart", output, progress.FeedID, progress.Format)
+		}
+		if progress.Done {
+			return &CommentExportResponse{Output: output, Progress: progress}, nil
+		}
+	}
+
+	resp := &CommentExportResponse{Output: output}
+	if progress == nil || req.Restart {
+		progress = &commentexport.Progress{
+			FeedID:    req.FeedID,
+			Format:    format,
+			Output:    output,
+			StartedAt: time.Now(),
+		}
+	} else {
+		resp.Resumed = true
+		logrus.Infof("从断点继续导出评论: feed_id=%s, 已导出 %d 条, cursor=%q", req.FeedID, progress.Records, progress.Cursor)
+	}
+	resp.Progress = progress
+
+	f, err := commentexport.OpenOutput(output, progress.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	w, err := commentexport.NewWriter(f, format, progress.Offset == 0)
+	if err != nil {
+		return nil, err
+	}
+
+	exported := 0
+	err = withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewCommentPageAction(page).StreamComments(ctx, xiaohongshu.CommentPageRequest{
+			FeedID:          req.FeedID,
+			XsecToken:       req.XsecToken,
+			Cursor:          progress.Cursor,
+			WithSubComments: req.WithSubComments,
+		}, func(p *xiaohongshu.CommentPage) error {
+			records := 0
+			for i := range p.Comments {
+				c := &p.Comments[i]
+				if err := w.Write(exportRecord(c, "")); err != nil {
+					return err
+				}
+				records++
+				for j := range c.SubComments {
+					if err := w.Write(exportRecord(&c.SubComments[j], c.ID)); err != nil {
+						return err
+					}
+					records++
+				}
+			}
+			if err := checkpointExport(f, w, progressPath, progress, p, records); err != nil {
+				return err
+			}
+
+			exported += len(p.Comments)
+			if req.MaxComments > 0 && exported >= req.MaxComments {
+				return xiaohongshu.ErrStopPaging
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		logrus.Errorf("导出评论中断: feed_id=%s, err=%v", req.FeedID, err)
+		return nil, fmt.Errorf("导出评论中断（已导出 %d 条，以相同参数重新调用可从断点继续）: %w", progress.Records, err)
+	}
+
+	// 没有评论时也记录完成状态
+	if progress.Pages == 0 {
+		if err := w.Flush(); err != nil {
+			return nil, err
+		}
+		progress.Done = true
+		if err := commentexport.SaveProgress(progressPath, progress); err != nil {
+			return nil, err
+		}
+	}
+
+	logrus.Infof("评论导出完成: %s, 一级评论 %d 条, 共 %d 条, 完成=%v", output, progress.Comments, progress.Records, progress.Done)
+	return resp, nil
+}
+
+// checkpointExport 刷新写入并保存进度，进度中的 offset 只覆盖完整写入的页
+func checkpointExport(f *os.File, w commentexport.Writer, path string, progress *commentexport.Progress, p *xiaohongshu.CommentPage, records int) error {
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	offset, err := f.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+
+	progress.Offset = offset
+	progress.Cursor = p.NextCursor
+	progress.Pages++
+	progress.Comments += len(p.Comments)
+	progress.Records += records
+	progress.Done = !p.HasMore
+	return commentexport.SaveProgress(path, progress)
+}
+
+func exportRecord(c *xiaohongshu.Comment, parentID string) *commentexport.Record {
+	r := &commentexport.Record{
+		ID:         c.ID,
+		ParentID:   parentID,
+		NoteID:     c.NoteID,
+		UserID:     c.UserInfo.UserID,
+		Nickname:   commentNickname(&c.UserInfo),
+		Content:    c.Content,
+		LikeCount:  c.LikeCount,
+		IPLocation: c.IPLocation,
+	}
+	if c.CreateTime > 0 {
+		r.CreatedAt = time.UnixMilli(c.CreateTime)
+	}
+	return r
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// FollowUser 关注用户，已关注时不重复操作
+func (s *XiaohongshuService) FollowUser(ctx context.Context, userID, xsecToken string) (*FollowResponse, error) {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewFollowAction(page).Follow(ctx, userID, xsecToken)
+	})
+	if err != nil {
+		logrus.Errorf("关注用户失败: user_id=%s, err=%v", userID, err)
+		return nil, err
+	}
+	return &FollowResponse{UserID: userID, Following: true, Message: "关注成功或已关注"}, nil
+}
+
+// UnfollowUser 取消关注用户，未关注时不重复操作
+func (s *XiaohongshuService) UnfollowUser(ctx context.Context, userID, xsecToken string) (*FollowResponse, error) {
+	err := withBrowserPage(func(page *rod.Page) error {
+		return xiaohongshu.NewFollowAction(page).Unfollow(ctx, userID, xsecToken)
+	})
+	if err != nil {
+		logrus.Errorf("取消关注用户失败: user_id=%s, err=%v", userID, err)
+		return nil, err
+	}
+	return &FollowResponse{UserID: userID, Following: false, Message: "取消关注成功或未关注"}, nil
+}
+
+// ListFollowing 按游标分页获取我关注的人
+func (s *XiaohongshuService) ListFollowing(ctx context.Context, req *FollowListRequest) (*xiaohongshu.FollowListPage, error) {
+	return s.listFollows(ctx, xiaohongshu.FollowListFollowing, req)
+}
+
+// ListFollowers 按游标分页获取我的粉丝
+func (s *XiaohongshuService) ListFollowers(ctx context.Context, req *FollowListRequest) (*xiaohongshu.FollowListPage, error) {
+	return s.listFollows(ctx, xiaohongshu.FollowListFollowers, req)
+}
+
+func (s *XiaohongshuService) listFollows(ctx context.Context, listType string, req *FollowListRequest) (*xiaohongshu.FollowListPage, error) {
+	var result *xiaohongshu.FollowListPage
+	err := withBrowserPage(func(page *rod.Page) error {
+		action := xiaohongshu.NewFollowListAction(page)
+		actionReq := xiaohongshu.FollowListRequest{Cursor: req.Cursor, PageSize: req.PageSize}
+
+		var err error
+		if listType == xiaohongshu.FollowListFollowers {
+			result, err = action.ListFollowers(ctx, actionReq)
+		} else {
+			result, err = action.ListFollowing(ctx, actionReq)
+		}
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("获取%s列表失败: cursor=%q, err=%v", listType, req.Cursor, err)
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/inbox"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+const (
+	defaultInboxMaxNotes           = 10
+	defaultInboxMaxCommentsPerNote = 50
+	inboxNotificationLimit         = 200
+)
+
+// SyncInbox 同步评论收件箱：遍历自己的笔记和通知中心，收集上次同步之后的新评论
+// 同步出错或被数量上限截断时不推进同步时间，下次从原来的时间重新扫描，由收件箱按评论ID去重
+func (s *XiaohongshuService) SyncInbox(ctx context.Context, req *InboxSyncRequest) (*InboxSyncResponse, error) {
+	if req.MaxNotes <= 0 {
+		req.MaxNotes = defaultInboxMaxNotes
+	}
+	if req.MaxCommentsPerNote <= 0 {
+		req.MaxCommentsPerNote = defaultInboxMaxCommentsPerNote
+	}
+
+	since, err := s.inbox.LastSync()
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &InboxSyncResponse{
+		Since:    since,
+		SyncedAt: time.Now(),
+	}
+
+	var (
+		collected []inbox.Comment
+		truncated bool
+	)
+	err = withBrowserPage(func(page *rod.Page) error {
+		if !req.SkipNotes {
+			comments, scanned, cut, errs := collectNoteComments(ctx, page, since, req)
+			collected = append(collected, comments...)
+			resp.ScannedNotes = scanned
+			resp.Errors = append(resp.Errors, errs...)
+			truncated = truncated || cut
+		}
+
+		if !req.SkipNotifications {
+			comments, cut, err := collectNotificationComments(ctx, page, since)
+			if err != nil {
+				logrus.Warnf("同步通知中心评论失败: %v", err)
+				resp.Errors = append(resp.Errors, "通知中心: "+err.Error())
+			}
+			collected = append(collected, comments...)
+			truncated = truncated || cut
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Partial = truncated || len(resp.Errors) > 0
+	if resp.Partial {
+		logrus.Warnf("评论收件箱同步不完整（截断=%v，错误 %d 个），保留上次同步时间", truncated, len(resp.Errors))
+	}
+
+	added, err := s.inbox.Merge(collected, resp.SyncedAt, !resp.Partial)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.NewComments = added
+	resp.NewCount = len(added)
+	logrus.Infof("评论收件箱同步完成: 扫描笔记 %d 篇，新增评论 %d 条", resp.ScannedNotes, resp.NewCount)
+	return resp, nil
+}
+
+// collectNoteComments 遍历自己已发布的笔记，收集 since 之后的评论
+// 笔记数或某篇笔记的评论数达到上限时 truncated 为 true
+func collectNoteComments(ctx context.Context, page *rod.Page, since time.Time, req *InboxSyncRequest) (comments []inbox.Comment, scanned int, truncated bool, errs []string) {
+	notes, err := xiaohongshu.NewCreatorNotesAction(page).ListNotes(ctx, xiaohongshu.CreatorNotesRequest{
+		Page:     1,
+		PageSize: req.MaxNotes,
+		Status:   xiaohongshu.CreatorNoteStatusPublished,
+	})
+	if err != nil {
+		return nil, 0, false, []string{"笔记列表: " + err.Error()}
+	}
+	truncated = notes.HasMore
+
+	config := xiaohongshu.DefaultCommentLoadConfig()
+	config.ClickMoreReplies = true
+	config.MaxCommentItems = req.MaxCommentsPerNote
+
+	for _, note := range notes.Notes {
+		if note.CommentCount == 0 {
+			continue
+		}
+		scanned++
+
+		detail, err := xiaohongshu.NewFeedDetailAction(page).GetFeedDetail(ctx, note.ID, note.XsecToken, true, config)
+		if err != nil {
+			logrus.Warnf("获取笔记评论失败: note_id=%s, err=%v", note.ID, err)
+			errs = append(errs, fmt.Sprintf("笔记 %s: %v", note.ID, err))
+			continue
+		}
+
+		if len(detail.Comments.List) >= req.MaxCommentsPerNote {
+			truncated = true
+		}
+		for _, c := range detail.Comments.List {
+			comments = appendNoteComment(comments, &note, &c, "", since)
+			for _, sub := range c.SubComments {
+				comments = appendNoteComment(comments, &note, &sub, c.ID, since)
+			}
+		}
+	}
+	return comments, scanned, truncated, errs
+}
+
+// appendNoteComment 过滤自己的评论和旧评论后加入结果
+func appendNoteComment(list []inbox.Comment, note *xiaohongshu.CreatorNote, c *xiaohongshu.Comment, parentID string, since time.Time) []inbox.Comment {
+	if isAuthorComment(c) {
+		return list
+	}
+	createdAt := time.UnixMilli(c.CreateTime)
+	if !since.IsZero() && !createdAt.After(since) {
+		return list
+	}
+
+	return append(list, inbox.Comment{
+		ID:        c.ID,
+		NoteID:    note.ID,
+		XsecToken: note.XsecToken,
+		NoteTitle: note.Title,
+		UserID:    c.UserInfo.UserID,
+		Nickname:  commentNickname(&c.UserInfo),
+		Content:   c.Content,
+		ParentID:  parentID,
+		Source:    inbox.SourceNote,
+		CreatedAt: createdAt,
+	})
+}
+
+// collectNotificationComments 从通知中心收集 since 之后的评论，通知数达到上限时 truncated 为 true
+func collectNotificationComments(ctx context.Context, page *rod.Page, since time.Time) ([]inbox.Comment, bool, error) {
+	notifications, err := xiaohongshu.NewNotificationAction(page).ListCommentNotifications(ctx, since, inboxNotificationLimit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	comments := make([]inbox.Comment, 0, len(notifications))
+	for _, n := range notifications {
+		c := inbox.Comment{
+			ID:        n.CommentInfo.ID,
+			NoteID:    n.ItemInfo.ID,
+			XsecToken: n.ItemInfo.XsecToken,
+			UserID:    n.UserInfo.UserID,
+			Nickname:  n.UserInfo.Nickname,
+			Content:   n.CommentInfo.Content,
+			Source:    inbox.SourceNotification,
+			CreatedAt: n.CreatedAt(),
+		}
+		if n.Type == "comment/comment" {
+			c.ParentID = n.CommentInfo.TargetComment.ID
+			c.TargetContent = n.CommentInfo.TargetComment.Content
+		}
+		comments = append(comments, c)
+	}
+	return comments, len(notifications) >= inboxNotificationLimit, nil
+}
+
+// isAuthorComment 作者本人的评论带有 is_author 标签
+func isAuthorComment(c *xiaohongshu.Comment) bool {
+	for _, tag := range c.ShowTags {
+		if tag == "is_author" {
+			return true
+		}
+	}
+	return false
+}
+
+func commentNickname(u *xiaohongshu.User) string {
+	if u.Nickname != "" {
+		return u.Nickname
+	}
+	return u.NickName
+}
+
+// ListInbox 查询评论收件箱
+func (s *XiaohongshuService) ListInbox(req *InboxListRequest) (*InboxListResponse, error) {
+	comments, err := s.inbox.List(inbox.Filter{
+		NoteID:    req.NoteID,
+		Unread:    req.Unread,
+		Unreplied: req.Unreplied,
+		Keyword:   req.Keyword,
+		Limit:     req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.inbox.Stats()
+	if err != nil {
+		return nil, err
+	}
+
+	return &InboxListResponse{
+		Comments: comments,
+		Count:    len(comments),
+		Stats:    stats,
+	}, nil
+}
+
+// ReplyInboxComment 回复收件箱中的评论，并记录回复状态
+func (s *XiaohongshuService) ReplyInboxComment(ctx context.Context, commentID, content string) (*ReplyCommentResponse, error) {
+	c, err := s.inbox.Get(commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.XsecToken == "" {
+		return nil, fmt.Errorf("评论缺少 xsec_token，请重新同步收件箱: comment_id=%s", commentID)
+	}
+
+	result, replyErr := s.ReplyCommentToFeed(ctx, c.NoteID, c.XsecToken, c.ID, c.UserID, content)
+	if err := s.inbox.MarkReplied(commentID, content, replyErr); err != nil {
+		logrus.Warnf("记录评论回复状态失败: %v", err)
+	}
+	if replyErr != nil {
+		return nil, replyErr
+	}
+	return result, nil
+}
+
+// MarkInboxRead 标记收件箱评论已读，ids 为空时标记全部
+func (s *XiaohongshuService) MarkInboxRead(ids []string) (int, error) {
+	return s.inbox.MarkRead(ids)
+}
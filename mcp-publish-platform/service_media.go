@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/downloader"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// mediaMetadataFile 下载目录中的元数据文件名
+const mediaMetadataFile = "meta.json"
+
+// DownloadFeedMedia 下载笔记的原图、实况图和视频到本地目录，并写入 meta.json
+// 已下载的文件会被跳过，中断后可以重新执行
+func (s *XiaohongshuService) DownloadFeedMedia(ctx context.Context, req *MediaDownloadRequest) (*MediaDownloadResponse, error) {
+	var detail *xiaohongshu.FeedDetailResponse
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		detail, err = xiaohongshu.NewFeedDetailAction(page).GetFeedDetail(ctx, req.FeedID, req.XsecToken, false, xiaohongshu.DefaultCommentLoadConfig())
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("获取笔记详情失败: feed_id=%s, err=%v", req.FeedID, err)
+		return nil, err
+	}
+	note := &detail.Note
+
+	dir := req.OutputDir
+	if dir == "" {
+		author := note.User.UserID
+		if author == "" {
+			author = "unknown"
+		}
+		dir = filepath.Join(configs.GetMediaDir(), filepath.Base(author), filepath.Base(req.FeedID))
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "创建下载目录失败")
+	}
+
+	resp := &MediaDownloadResponse{
+		FeedID:       req.FeedID,
+		XsecToken:    req.XsecToken,
+		URL:          xiaohongshu.FeedDetailURL(req.FeedID, req.XsecToken),
+		Title:        note.Title,
+		Desc:         note.Desc,
+		Type:         note.Type,
+		Time:         note.Time,
+		IPLocation:   note.IPLocation,
+		Author:       note.User,
+		InteractInfo: note.InteractInfo,
+		Dir:          dir,
+		Metadata:     filepath.Join(dir, mediaMetadataFile),
+		Files:        []MediaFileResult{},
+	}
+
+	d := downloader.NewMediaDownloader()
+	for _, item := range xiaohongshu.NoteMedia(note) {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if (item.Kind == xiaohongshu.MediaLivePhoto && req.SkipLivePhoto) || (item.Kind == xiaohongshu.MediaVideo && req.SkipVideo) {
+			continue
+		}
+
+		result := downloadMediaItem(ctx, d, dir, item)
+		if result.Error != "" {
+			logrus.Warnf("下载笔记媒体失败: feed_id=%s, %s #%d: %s", req.FeedID, item.Kind, item.Index, result.Error)
+			resp.Failed = append(resp.Failed, result)
+			continue
+		}
+		resp.Files = append(resp.Files, result)
+	}
+	resp.DownloadedAt = time.Now()
+
+	data, err := json.MarshalIndent(resp, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "序列化元数据失败")
+	}
+	if err := os.WriteFile(resp.Metadata, data, 0644); err != nil {
+		return nil, errors.Wrap(err, "写入元数据失败")
+	}
+
+	logrus.Infof("笔记媒体下载完成: feed_id=%s, 成功 %d 个, 失败 %d 个, 目录 %s", req.FeedID, len(resp.Files), len(resp.Failed), dir)
+	return resp, nil
+}
+
+// downloadMediaItem 按优先级依次尝试媒体的各个地址
+func downloadMediaItem(ctx context.Context, d *downloader.MediaDownloader, dir string, item xiaohongshu.MediaItem) MediaFileResult {
+	result := MediaFileResult{Kind: item.Kind, Index: item.Index, Width: item.Width, Height: item.Height}
+
+	var lastErr error
+	for _, u := range item.URLs {
+		file, err := d.Download(ctx, u, dir, mediaFileName(item))
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		result.URL = file.URL
+		result.Path = file.Path
+		result.Size = file.Size
+		result.Skipped = file.Skipped
+		return result
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("没有可用的下载地址")
+	}
+	result.Error = lastErr.Error()
+	return result
+}
+
+// mediaFileName 不含扩展名的文件名，如 image_01、live_01、video
+func mediaFileName(item xiaohongshu.MediaItem) string {
+	switch item.Kind {
+	case xiaohongshu.MediaLivePhoto:
+		return fmt.Sprintf("live_%02d", item.Index)
+	case xiaohongshu.MediaVideo:
+		return "video"
+	}
+	return fmt.Sprintf("image_%02d", item.Index)
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/configs"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/monitor"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// monitorTick 后台检查到期监控项的频率
+const monitorTick = time.Minute
+
+// AddWatch 新增监控项
+func (s *XiaohongshuService) AddWatch(req *WatchRequest) (*monitor.Watch, error) {
+	w := monitor.Watch{
+		Type:            req.Type,
+		Target:          req.Target,
+		XsecToken:       req.XsecToken,
+		Name:            req.Name,
+		SortBy:          req.SortBy,
+		MaxResults:      req.MaxResults,
+		IntervalMinutes: req.IntervalMinutes,
+		Spike: monitor.SpikeRule{
+			MinDelta:  req.SpikeMinDelta,
+			MinGrowth: req.SpikeMinGrowth,
+		},
+		Webhook: req.Webhook,
+		Enabled: req.Enabled == nil || *req.Enabled,
+	}
+	return s.monitor.AddWatch(w)
+}
+
+// ListWatches 列出监控项
+func (s *XiaohongshuService) ListWatches() ([]monitor.Watch, error) {
+	return s.monitor.ListWatches()
+}
+
+// UpdateWatch 修改监控项
+func (s *XiaohongshuService) UpdateWatch(id string, req *WatchUpdateRequest) (*monitor.Watch, error) {
+	return s.monitor.UpdateWatch(id, func(w *monitor.Watch) {
+		if req.Target != nil {
+			w.Target = *req.Target
+		}
+		if req.XsecToken != nil {
+			w.XsecToken = *req.XsecToken
+		}
+		if req.Name != nil {
+			w.Name = *req.Name
+		}
+		if req.SortBy != nil {
+			w.SortBy = *req.SortBy
+		}
+		if req.MaxResults != nil {
+			w.MaxResults = *req.MaxResults
+		}
+		if req.IntervalMinutes != nil {
+			w.IntervalMinutes = *req.IntervalMinutes
+		}
+		if req.SpikeMinDelta != nil {
+			w.Spike.MinDelta = *req.SpikeMinDelta
+		}
+		if req.SpikeMinGrowth != nil {
+			w.Spike.MinGrowth = *req.SpikeMinGrowth
+		}
+		if req.Webhook != nil {
+			w.Webhook = *req.Webhook
+		}
+		if req.Enabled != nil {
+			w.Enabled = *req.Enabled
+		}
+	})
+}
+
+// DeleteWatch 删除监控项
+func (s *XiaohongshuService) DeleteWatch(id string) error {
+	return s.monitor.DeleteWatch(id)
+}
+
+// ListMonitorAlerts 查询监控告警
+func (s *XiaohongshuService) ListMonitorAlerts(req *AlertListRequest) ([]monitor.Alert, error) {
+	return s.monitor.ListAlerts(monitor.AlertFilter{
+		WatchID: req.WatchID,
+		Type:    req.Type,
+		Limit:   req.Limit,
+	})
+}
+
+// CheckWatch 立即执行一次监控检查：抓取最新笔记、与上次快照对比、保存并推送告警
+func (s *XiaohongshuService) CheckWatch(ctx context.Context, id string) (*WatchCheckResponse, error) {
+	w, err := s.monitor.GetWatch(id)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := s.monitor.Snapshot(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	notes, fetchErr := fetchWatchNotes(ctx, w, now)
+	if fetchErr != nil {
+		logrus.Warnf("监控检查失败: id=%s, target=%s, err=%v", w.ID, w.Target, fetchErr)
+		if err := s.monitor.RecordCheck(id, nil, nil, fetchErr, now); err != nil {
+			logrus.Warnf("记录监控检查结果失败: %v", err)
+		}
+		return nil, fetchErr
+	}
+
+	alerts := monitor.Diff(w, prev, notes, now)
+	if err := s.monitor.RecordCheck(id, notes, alerts, nil, now); err != nil {
+		return nil, err
+	}
+
+	resp := &WatchCheckResponse{
+		WatchID:  id,
+		Notes:    len(notes),
+		Baseline: !w.Baselined,
+		Alerts:   alerts,
+	}
+	if resp.Alerts == nil {
+		resp.Alerts = []monitor.Alert{}
+	}
+
+	webhook := w.Webhook
+	if webhook == "" {
+		webhook = configs.GetMonitorWebhook()
+	}
+	if webhook != "" && len(alerts) > 0 {
+		if err := s.notifier.Send(ctx, webhook, alerts); err != nil {
+			logrus.Warnf("推送监控告警失败: id=%s, err=%v", id, err)
+			resp.NotifyError = err.Error()
+		} else {
+			resp.Notified = true
+		}
+	}
+
+	logrus.Infof("监控检查完成: %s, 笔记 %d 篇, 告警 %d 条", w.DisplayName(), resp.Notes, len(alerts))
+	return resp, nil
+}
+
+// RunMonitor 后台定期检查到期的监控项，直到 ctx 取消
+// 检查逐个执行，避免同时打开多个浏览器
+func (s *XiaohongshuService) RunMonitor(ctx context.Context) {
+	ticker := time.NewTicker(monitorTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		watches, err := s.monitor.ListWatches()
+		if err != nil {
+			logrus.Warnf("读取监控项失败: %v", err)
+			continue
+		}
+		for _, w := range watches {
+			if ctx.Err() != nil {
+				return
+			}
+			if !w.Due(time.Now()) {
+				continue
+			}
+			s.checkWatchSafely(ctx, &w)
+		}
+	}
+}
+
+// checkWatchSafely 页面操作中的 Must 调用可能 panic，后台任务不能因此退出
+func (s *XiaohongshuService) checkWatchSafely(ctx context.Context, w *monitor.Watch) {
+	defer func() {
+		if r := recover(); r != nil {
+			logrus.Errorf("定时监控检查 panic: %s: %v", w.DisplayName(), r)
+			if err := s.monitor.RecordCheck(w.ID, nil, nil, fmt.Errorf("panic: %v", r), time.Now()); err != nil {
+				logrus.Warnf("记录监控检查结果失败: %v", err)
+			}
+		}
+	}()
+
+	if _, err := s.CheckWatch(ctx, w.ID); err != nil {
+		logrus.Warnf("定时监控检查失败: %s: %v", w.DisplayName(), err)
+	}
+}
+
+// fetchWatchNotes 按监控类型抓取当前的笔记列表
+func fetchWatchNotes(ctx context.Context, w *monitor.Watch, now time.Time) ([]monitor.NoteSnapshot, error) {
+	var feeds []xiaohongshu.Feed
+	err := withBrowserPage(func(page *rod.Page) error {
+		switch w.Type {
+		case monitor.TypeKeyword:
+			action := xiaohongshu.NewSearchAction(page)
+			filter := xiaohongshu.FilterOption{SortBy: w.SortBy}
+			if w.MaxResults > 0 {
+				result, err := action.DeepSearch(ctx, w.Target, xiaohongshu.DeepSearchOptions{
+					Filters:    []xiaohongshu.FilterOption{filter},
+					MaxResults: w.MaxResults,
+				})
+				if err != nil {
+					return err
+				}
+				feeds = result.Feeds
+				return nil
+			}
+			result, err := action.Search(ctx, w.Target, filter)
+			feeds = result
+			return err
+		case monitor.TypeUser:
+			result, err := xiaohongshu.NewUserProfileAction(page).UserProfile(ctx, w.Target, w.XsecToken)
+			if err != nil {
+				return err
+			}
+			feeds = result.Feeds
+			return nil
+		}
+		return fmt.Errorf("不支持的监控类型: %s", w.Type)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	notes := make([]monitor.NoteSnapshot, 0, len(feeds))
+	for _, f := range feeds {
+		if f.ID == "" || (f.ModelType != "" && f.ModelType != "note") {
+			continue
+		}
+		card := &f.NoteCard
+		notes = append(notes, monitor.NoteSnapshot{
+			ID:        f.ID,
+			XsecToken: f.XsecToken,
+			Title:     card.DisplayTitle,
+			Author:    commentNickname(&card.User),
+			Likes:     xhsutil.ParseCount(card.InteractInfo.LikedCount),
+			Collects:  xhsutil.ParseCount(card.InteractInfo.CollectedCount),
+			Comments:  xhsutil.ParseCount(card.InteractInfo.CommentCount),
+			Shares:    xhsutil.ParseCount(card.InteractInfo.SharedCount),
+			FirstSeen: now,
+			UpdatedAt: now,
+		})
+	}
+	return notes, nil
+}
@@ -42,6 +42,17 @@ func (s *MultiPlatformService) PublishVideo(ctx context.Context, platformID plat
 	return s.platformManager.PublishVideo(ctx, platformID, page, req)
 }
 
+// ListDrafts 获取草稿列表
+func (s *MultiPlatformService) ListDrafts(ctx context.Context, platformID platform.PlatformID, page *rod.Page) ([]platform.DraftItem, error) {
+	return s.platformManager.ListDrafts(ctx, platformID, page)
+}
+
+// PublishDraft 发布草稿
+func (s *MultiPlatformService) PublishDraft(ctx context.Context, platformID platform.PlatformID, page *rod.Page, draftID string) (*platform.PublishResponse, error) {
+	logrus.Infof("发布草稿: platform=%s, draft_id=%s", platformID, draftID)
+	return s.platformManager.PublishDraft(ctx, platformID, page, draftID)
+}
+
 // GetFeeds 获取内容列表
 func (s *MultiPlatformService) GetFeeds(ctx context.Context, platformID platform.PlatformID, page *rod.Page, req *platform.GetFeedsRequest) (*platform.GetFeedsResponse, error) {
 	return s.platformManager.GetFeeds(ctx, platformID, page, req)
@@ -52,6 +63,62 @@ func (s *MultiPlatformService) GetFeedDetail(ctx context.Context, platformID pla
 	return s.platformManager.GetFeedDetail(ctx, platformID, page, feedID)
 }
 
+// EditPost 编辑已发布的内容
+func (s *MultiPlatformService) EditPost(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string, req *platform.EditPostRequest) error {
+	logrus.Infof("编辑内容: platform=%s, feed_id=%s", platformID, feedID)
+	return s.platformManager.EditPost(ctx, platformID, page, feedID, req)
+}
+
+// RequestDeleteConfirmation 获取删除确认令牌
+func (s *MultiPlatformService) RequestDeleteConfirmation(platformID platform.PlatformID, feedID string) (*platform.DeleteConfirmation, error) {
+	return s.platformManager.RequestDeleteConfirmation(platformID, feedID)
+}
+
+// DeletePost 删除已发布的内容
+func (s *MultiPlatformService) DeletePost(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID, confirmToken string) error {
+	logrus.Infof("删除内容: platform=%s, feed_id=%s", platformID, feedID)
+	return s.platformManager.DeletePost(ctx, platformID, page, feedID, confirmToken)
+}
+
+// Like 点赞内容
+func (s *MultiPlatformService) Like(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string) error {
+	logrus.Infof("点赞内容: platform=%s, feed_id=%s", platformID, feedID)
+	return s.platformManager.Like(ctx, platformID, page, feedID)
+}
+
+// Comment 评论内容
+func (s *MultiPlatformService) Comment(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string, content string, mentions []string) error {
+	logrus.Infof("评论内容: platform=%s, feed_id=%s", platformID, feedID)
+	return s.platformManager.Comment(ctx, platformID, page, feedID, content, mentions)
+}
+
+// Collect 收藏内容
+func (s *MultiPlatformService) Collect(ctx context.Context, platformID platform.PlatformID, page *rod.Page, feedID string) error {
+	logrus.Infof("收藏内容: platform=%s, feed_id=%s", platformID, feedID)
+	return s.platformManager.Collect(ctx, platformID, page, feedID)
+}
+
+// ListConversations 获取私信会话列表
+func (s *MultiPlatformService) ListConversations(ctx context.Context, platformID platform.PlatformID, page *rod.Page) ([]platform.Conversation, error) {
+	return s.platformManager.ListConversations(ctx, platformID, page)
+}
+
+// GetMessages 获取会话的消息记录
+func (s *MultiPlatformService) GetMessages(ctx context.Context, platformID platform.PlatformID, page *rod.Page, conversationID string) ([]platform.Message, error) {
+	return s.platformManager.GetMessages(ctx, platformID, page, conversationID)
+}
+
+// SendMessage 发送私信
+func (s *MultiPlatformService) SendMessage(ctx context.Context, platformID platform.PlatformID, page *rod.Page, conversationID, content string) error {
+	logrus.Infof("发送私信: platform=%s, conversation_id=%s", platformID, conversationID)
+	return s.platformManager.SendMessage(ctx, platformID, page, conversationID, content)
+}
+
+// SearchTopics 查询平台已有话题
+func (s *MultiPlatformService) SearchTopics(ctx context.Context, platformID platform.PlatformID, page *rod.Page, keyword string) ([]platform.Topic, error) {
+	return s.platformManager.SearchTopics(ctx, platformID, page, keyword)
+}
+
 // ListPlatforms 列出所有平台
 func (s *MultiPlatformService) ListPlatforms() []platform.PlatformID {
 	return s.platformManager.ListPlatforms()
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"context"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
+
+// SearchTopics 查询话题联想候选及浏览量
+func (s *XiaohongshuService) SearchTopics(ctx context.Context, keyword string) (*TopicSearchResponse, error) {
+	var topics []platform.Topic
+	err := withBrowserPage(func(page *rod.Page) error {
+		var err error
+		topics, err = xiaohongshu.NewTopicAction(page).SearchTopics(ctx, keyword)
+		return err
+	})
+	if err != nil {
+		logrus.Errorf("查询话题失败: keyword=%s, err=%v", keyword, err)
+		return nil, err
+	}
+	return &TopicSearchResponse{Keyword: keyword, Topics: topics, Count: len(topics)}, nil
+}
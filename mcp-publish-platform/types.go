@@ -1,6 +1,14 @@
 package main
 
-import "github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+import (
+	"time"
+
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/commentexport"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/inbox"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/monitor"
+	"github.com/xpzouying/xiaohongshu-mcp/xiaohongshu"
+)
 
 // HTTP API 响应类型
 
@@ -57,6 +65,15 @@ type FeedDetailRequest struct {
 type SearchFeedsRequest struct {
 	Keyword string                   `json:"keyword" binding:"required"`
 	Filters xiaohongshu.FilterOption `json:"filters,omitempty"`
+	// 以下参数任一不为空时滚动加载更多结果
+	MaxResults int    `json:"max_results,omitempty"` // 本次返回的结果数，默认 20，最多 200
+	Page       int    `json:"page,omitempty"`        // 页码，从 1 开始
+	Cursor     string `json:"cursor,omitempty"`      // 上一次返回的 cursor，继续获取后面的结果
+}
+
+// deep 是否需要滚动加载更多结果
+func (r *SearchFeedsRequest) deep() bool {
+	return r.MaxResults > 0 || r.Page > 1 || r.Cursor != ""
 }
 
 // FeedDetailResponse Feed详情响应
@@ -67,9 +84,10 @@ type FeedDetailResponse struct {
 
 // PostCommentRequest 发表评论请求
 type PostCommentRequest struct {
-	FeedID    string `json:"feed_id" binding:"required"`
-	XsecToken string `json:"xsec_token" binding:"required"`
-	Content   string `json:"content" binding:"required"`
+	FeedID    string   `json:"feed_id" binding:"required"`
+	XsecToken string   `json:"xsec_token" binding:"required"`
+	Content   string   `json:"content" binding:"required"`
+	Mentions  []string `json:"mentions,omitempty"` // 评论末尾 @提及的用户昵称或小红书号
 }
 
 // PostCommentResponse 发表评论响应
@@ -109,3 +127,200 @@ type ActionResult struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
 }
+
+// MyNotesRequest 我的笔记列表请求
+type MyNotesRequest struct {
+	Page     int    `json:"page" form:"page"`
+	PageSize int    `json:"page_size" form:"page_size"`
+	Status   string `json:"status" form:"status"` // all|published|reviewing|rejected
+}
+
+// InboxSyncRequest 同步评论收件箱请求
+type InboxSyncRequest struct {
+	MaxNotes           int  `json:"max_notes,omitempty"`             // 最多遍历的笔记数，默认 10
+	MaxCommentsPerNote int  `json:"max_comments_per_note,omitempty"` // 每篇笔记最多加载的评论数，默认 50
+	SkipNotes          bool `json:"skip_notes,omitempty"`            // 不遍历笔记，只同步通知中心
+	SkipNotifications  bool `json:"skip_notifications,omitempty"`    // 不同步通知中心
+}
+
+// InboxSyncResponse 同步评论收件箱响应
+type InboxSyncResponse struct {
+	Since        time.Time       `json:"since"`
+	SyncedAt     time.Time       `json:"synced_at"`
+	ScannedNotes int             `json:"scanned_notes"`
+	NewCount     int             `json:"new_count"`
+	NewComments  []inbox.Comment `json:"new_comments"`
+	Errors       []string        `json:"errors,omitempty"`
+	Partial      bool            `json:"partial,omitempty"` // 同步出错或被数量上限截断，本次未推进同步时间
+}
+
+// InboxListRequest 评论收件箱列表请求
+type InboxListRequest struct {
+	NoteID    string `json:"note_id" form:"note_id"`
+	Unread    bool   `json:"unread" form:"unread"`
+	Unreplied bool   `json:"unreplied" form:"unreplied"`
+	Keyword   string `json:"keyword" form:"keyword"`
+	Limit     int    `json:"limit" form:"limit"`
+}
+
+// InboxListResponse 评论收件箱列表响应
+type InboxListResponse struct {
+	Comments []inbox.Comment `json:"comments"`
+	Count    int             `json:"count"`
+	Stats    *inbox.Stats    `json:"stats"`
+}
+
+// InboxReplyRequest 回复收件箱评论请求
+type InboxReplyRequest struct {
+	Content string `json:"content" binding:"required"`
+}
+
+// InboxMarkReadRequest 标记收件箱评论已读请求
+type InboxMarkReadRequest struct {
+	CommentIDs []string `json:"comment_ids"` // 为空时标记全部
+}
+
+// FeedCommentsRequest 按游标分页获取评论请求
+type FeedCommentsRequest struct {
+	FeedID          string `json:"feed_id" form:"feed_id" binding:"required"`
+	XsecToken       string `json:"xsec_token" form:"xsec_token" binding:"required"`
+	Cursor          string `json:"cursor,omitempty" form:"cursor"`                       // 上次返回的 next_cursor，为空从第一页开始
+	PageSize        int    `json:"page_size,omitempty" form:"page_size"`                 // 一级评论数量，默认 20
+	WithSubComments bool   `json:"with_sub_comments,omitempty" form:"with_sub_comments"` // 是否展开楼中楼回复
+}
+
+// CommentExportRequest 导出评论请求
+type CommentExportRequest struct {
+	FeedID          string `json:"feed_id" binding:"required"`
+	XsecToken       string `json:"xsec_token" binding:"required"`
+	Format          string `json:"format,omitempty"`            // jsonl|csv，默认 jsonl
+	Output          string `json:"output,omitempty"`            // 导出目录下的相对路径，默认 comments_<feed_id>.<format>
+	WithSubComments bool   `json:"with_sub_comments,omitempty"` // 是否导出楼中楼回复
+	MaxComments     int    `json:"max_comments,omitempty"`      // 本次最多导出的一级评论数，0 表示全部
+	Restart         bool   `json:"restart,omitempty"`           // 忽略已有进度重新导出
+}
+
+// CommentExportResponse 导出评论响应
+type CommentExportResponse struct {
+	Output   string                  `json:"output"`
+	Resumed  bool                    `json:"resumed"`
+	Progress *commentexport.Progress `json:"progress"`
+}
+
+// MediaDownloadRequest 下载笔记媒体请求
+type MediaDownloadRequest struct {
+	FeedID        string `json:"feed_id" binding:"required"`
+	XsecToken     string `json:"xsec_token" binding:"required"`
+	OutputDir     string `json:"output_dir,omitempty"`      // 默认 downloads/<作者ID>/<笔记ID>
+	SkipLivePhoto bool   `json:"skip_live_photo,omitempty"` // 不下载实况图的视频部分
+	SkipVideo     bool   `json:"skip_video,omitempty"`      // 不下载视频笔记的视频
+}
+
+// MediaDownloadResponse 下载笔记媒体结果，同时作为 meta.json 写入下载目录
+type MediaDownloadResponse struct {
+	FeedID       string                   `json:"feed_id"`
+	XsecToken    string                   `json:"xsec_token"`
+	URL          string                   `json:"url"`
+	Title        string                   `json:"title"`
+	Desc         string                   `json:"desc"`
+	Type         string                   `json:"type"`
+	Time         int64                    `json:"time"`
+	IPLocation   string                   `json:"ip_location,omitempty"`
+	Author       xiaohongshu.User         `json:"author"`
+	InteractInfo xiaohongshu.InteractInfo `json:"interact_info"`
+	Dir          string                   `json:"dir"`
+	Metadata     string                   `json:"metadata"`
+	Files        []MediaFileResult        `json:"files"`
+	Failed       []MediaFileResult        `json:"failed,omitempty"`
+	DownloadedAt time.Time                `json:"downloaded_at"`
+}
+
+// MediaFileResult 单个媒体文件的下载结果
+type MediaFileResult struct {
+	Kind    string `json:"kind"`
+	Index   int    `json:"index"`
+	Width   int    `json:"width,omitempty"`
+	Height  int    `json:"height,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Size    int64  `json:"size,omitempty"`
+	Skipped bool   `json:"skipped,omitempty"` // 文件已存在，未重新下载
+	Error   string `json:"error,omitempty"`
+}
+
+// FollowRequest 关注/取消关注请求
+type FollowRequest struct {
+	UserID    string `json:"user_id" binding:"required"`
+	XsecToken string `json:"xsec_token" binding:"required"`
+}
+
+// FollowResponse 关注/取消关注响应
+type FollowResponse struct {
+	UserID    string `json:"user_id"`
+	Following bool   `json:"following"` // 操作后的关注状态
+	Message   string `json:"message"`
+}
+
+// FollowListRequest 关注/粉丝列表请求
+type FollowListRequest struct {
+	Cursor   string `json:"cursor,omitempty" form:"cursor"`       // 上次返回的 next_cursor，为空从第一页开始
+	PageSize int    `json:"page_size,omitempty" form:"page_size"` // 用户数量，默认 20
+}
+
+// TopicSearchRequest 话题查询请求
+type TopicSearchRequest struct {
+	Keyword string `json:"keyword" form:"keyword" binding:"required"`
+}
+
+// TopicSearchResponse 话题查询响应
+type TopicSearchResponse struct {
+	Keyword string           `json:"keyword"`
+	Topics  []platform.Topic `json:"topics"`
+	Count   int              `json:"count"`
+}
+
+// WatchRequest 新增监控项请求
+type WatchRequest struct {
+	Type            string  `json:"type" binding:"required,oneof=keyword user"`
+	Target          string  `json:"target" binding:"required"` // 关键词或用户 ID
+	XsecToken       string  `json:"xsec_token,omitempty"`      // 监控用户时必填
+	Name            string  `json:"name,omitempty"`
+	SortBy          string  `json:"sort_by,omitempty"`          // 关键词搜索排序，如 最新
+	MaxResults      int     `json:"max_results,omitempty"`      // 关键词每次检查的结果数，默认首屏
+	IntervalMinutes int     `json:"interval_minutes,omitempty"` // 检查间隔，默认 60，最小 10
+	SpikeMinDelta   int     `json:"spike_min_delta,omitempty"`  // 爆款判定的最小互动增量，默认 500
+	SpikeMinGrowth  float64 `json:"spike_min_growth,omitempty"` // 爆款判定的最小增幅，默认 0.5
+	Webhook         string  `json:"webhook,omitempty"`          // 为空时使用 MONITOR_WEBHOOK_URL
+	Enabled         *bool   `json:"enabled,omitempty"`          // 默认启用
+}
+
+// WatchUpdateRequest 修改监控项请求，只修改提供的字段
+type WatchUpdateRequest struct {
+	Target          *string  `json:"target,omitempty"`
+	XsecToken       *string  `json:"xsec_token,omitempty"`
+	Name            *string  `json:"name,omitempty"`
+	SortBy          *string  `json:"sort_by,omitempty"`
+	MaxResults      *int     `json:"max_results,omitempty"`
+	IntervalMinutes *int     `json:"interval_minutes,omitempty"`
+	SpikeMinDelta   *int     `json:"spike_min_delta,omitempty"`
+	SpikeMinGrowth  *float64 `json:"spike_min_growth,omitempty"`
+	Webhook         *string  `json:"webhook,omitempty"`
+	Enabled         *bool    `json:"enabled,omitempty"`
+}
+
+// WatchCheckResponse 执行一次监控检查的结果
+type WatchCheckResponse struct {
+	WatchID     string          `json:"watch_id"`
+	Notes       int             `json:"notes"`
+	Baseline    bool            `json:"baseline"` // 首次检查只建立基线
+	Alerts      []monitor.Alert `json:"alerts"`
+	Notified    bool            `json:"notified"`
+	NotifyError string          `json:"notify_error,omitempty"`
+}
+
+// AlertListRequest 监控告警列表请求
+type AlertListRequest struct {
+	WatchID string `json:"watch_id" form:"watch_id"`
+	Type    string `json:"type" form:"type"` // new_note|spike
+	Limit   int    `json:"limit" form:"limit"`
+}
@@ -20,8 +20,8 @@ func NewCommentFeedAction(page *rod.Page) *CommentFeedAction {
 	return &CommentFeedAction{page: page}
 }
 
-// PostComment 发表评论到 Feed
-func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken, content string) error {
+// PostComment 发表评论到 Feed，mentions 为评论末尾 @提及的用户昵称或小红书号
+func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken, content string, mentions []string) error {
 	// 不使用 Context(ctx)，避免继承外部 context 的超时
 	page := f.page.Timeout(60 * time.Second)
 
@@ -60,6 +60,10 @@ func (f *CommentFeedAction) PostComment(ctx context.Context, feedID, xsecToken,
 		return fmt.Errorf("无法输入评论内容: %w", err)
 	}
 
+	if err := inputMentions(elem2, mentions); err != nil {
+		return err
+	}
+
 	time.Sleep(1 * time.Second)
 
 	submitButton, err := page.Element("div.bottom button.submit")
@@ -0,0 +1,351 @@
+package xiaohongshu
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// commentPageAPIPattern 详情页加载一级评论的接口
+	commentPageAPIPattern = `*/api/sns/web/v2/comment/page*`
+	// subCommentPageAPIPattern 展开楼中楼回复的接口
+	subCommentPageAPIPattern = `*/api/sns/web/v2/comment/sub/page*`
+
+	defaultCommentPageSize  = 20
+	maxSubCommentRounds     = 10
+	commentPageWaitTimeout  = 8 * time.Second
+	commentPageScrollTrials = 6
+)
+
+// ErrStopPaging 由 StreamComments 的回调返回，表示不再继续翻页
+var ErrStopPaging = errors.New("停止翻页")
+
+// CommentPageRequest 按游标分页获取评论的请求
+type CommentPageRequest struct {
+	FeedID    string
+	XsecToken string
+	// Cursor 上一次返回的 NextCursor，为空表示从第一页开始
+	Cursor string
+	// PageSize 期望的一级评论数量，按接口分页粒度返回，实际数量可能略多
+	PageSize int
+	// WithSubComments 是否展开并收集楼中楼回复
+	WithSubComments bool
+}
+
+// CommentPage 一页评论
+type CommentPage struct {
+	Comments   []Comment `json:"comments"`
+	Cursor     string    `json:"cursor"`      // 本页起始游标
+	NextCursor string    `json:"next_cursor"` // 下一页游标，传入 CommentPageRequest.Cursor 继续
+	HasMore    bool      `json:"has_more"`
+}
+
+// apiComment 评论接口返回的评论，字段为下划线风格，与 __INITIAL_STATE__ 不同
+type apiComment struct {
+	ID              string       `json:"id"`
+	NoteID          string       `json:"note_id"`
+	Content         string       `json:"content"`
+	LikeCount       string       `json:"like_count"`
+	CreateTime      int64        `json:"create_time"`
+	IPLocation      string       `json:"ip_location"`
+	Liked           bool         `json:"liked"`
+	SubCommentCount string       `json:"sub_comment_count"`
+	SubComments     []apiComment `json:"sub_comments"`
+	ShowTags        []string     `json:"show_tags"`
+	UserInfo        struct {
+		UserID   string `json:"user_id"`
+		Nickname string `json:"nickname"`
+		Image    string `json:"image"`
+	} `json:"user_info"`
+}
+
+func (c *apiComment) toComment() Comment {
+	comment := Comment{
+		ID:              c.ID,
+		NoteID:          c.NoteID,
+		Content:         c.Content,
+		LikeCount:       c.LikeCount,
+		CreateTime:      c.CreateTime,
+		IPLocation:      c.IPLocation,
+		Liked:           c.Liked,
+		SubCommentCount: c.SubCommentCount,
+		ShowTags:        c.ShowTags,
+		UserInfo: User{
+			UserID:   c.UserInfo.UserID,
+			Nickname: c.UserInfo.Nickname,
+			Avatar:   c.UserInfo.Image,
+		},
+	}
+	for i := range c.SubComments {
+		comment.SubComments = append(comment.SubComments, c.SubComments[i].toComment())
+	}
+	return comment
+}
+
+// commentPageResponse 评论接口响应，一级评论和楼中楼共用
+type commentPageResponse struct {
+	Code    int    `json:"code"`
+	Success bool   `json:"success"`
+	Msg     string `json:"msg"`
+	Data    struct {
+		Comments []apiComment `json:"comments"`
+		Cursor   string       `json:"cursor"`
+		HasMore  bool         `json:"has_more"`
+	} `json:"data"`
+}
+
+// CommentPageAction 按游标分页获取评论
+type CommentPageAction struct {
+	page *rod.Page
+}
+
+func NewCommentPageAction(page *rod.Page) *CommentPageAction {
+	pp := page.Timeout(30 * time.Minute)
+	return &CommentPageAction{page: pp}
+}
+
+// ListComments 从 req.Cursor 开始获取一页评论
+func (a *CommentPageAction) ListComments(ctx context.Context, req CommentPageRequest) (*CommentPage, error) {
+	if req.PageSize <= 0 {
+		req.PageSize = defaultCommentPageSize
+	}
+
+	result := &CommentPage{
+		Comments:   make([]Comment, 0),
+		Cursor:     req.Cursor,
+		NextCursor: req.Cursor,
+	}
+	err := a.StreamComments(ctx, req, func(p *CommentPage) error {
+		result.Comments = append(result.Comments, p.Comments...)
+		result.NextCursor = p.NextCursor
+		result.HasMore = p.HasMore
+		if len(result.Comments) >= req.PageSize {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StreamComments 从 req.Cursor 开始逐页回调评论，直到没有更多评论或 fn 返回 ErrStopPaging
+// 页面只能从第一页顺序加载，游标之前的页会被跳过但不回调
+func (a *CommentPageAction) StreamComments(ctx context.Context, req CommentPageRequest, fn func(*CommentPage) error) error {
+	page := a.page.Context(ctx)
+
+	collector := &commentPageCollector{subs: make(map[string][]Comment)}
+
+	router := page.HijackRequests()
+	router.MustAdd(commentPageAPIPattern, collector.handlePage)
+	router.MustAdd(subCommentPageAPIPattern, collector.handleSubPage)
+	go router.Run()
+	defer router.MustStop()
+
+	if err := page.Navigate(makeFeedDetailURL(req.FeedID, req.XsecToken)); err != nil {
+		return errors.Wrap(err, "打开笔记详情页失败")
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待详情页稳定出现问题: %v，继续尝试", err)
+	}
+	if err := checkPageAccessible(page); err != nil {
+		return err
+	}
+
+	scrollToCommentsArea(page)
+	if checkNoCommentsArea(page) {
+		logrus.Infof("笔记没有评论: %s", req.FeedID)
+		return nil
+	}
+
+	// 首屏评论可能直接渲染在 __INITIAL_STATE__ 中而不走接口
+	collector.waitMore(ctx, 0, commentPageWaitTimeout)
+	if first, ok := collector.pageAt(0); !ok || first.Cursor != "" {
+		detail, err := (&FeedDetailAction{page: page}).extractFeedDetail(page, req.FeedID)
+		if err != nil {
+			return err
+		}
+		collector.prepend(&CommentPage{
+			Comments:   detail.Comments.List,
+			NextCursor: detail.Comments.Cursor,
+			HasMore:    detail.Comments.HasMore,
+		})
+	}
+
+	started := req.Cursor == ""
+	for i := 0; ; i++ {
+		p, err := a.nextPage(ctx, page, collector, i)
+		if err != nil {
+			if !started {
+				return errors.Wrapf(err, "未找到游标对应的评论页: %s", req.Cursor)
+			}
+			return err
+		}
+		if p == nil {
+			if !started {
+				return errors.Errorf("未找到游标对应的评论页: %s", req.Cursor)
+			}
+			return nil
+		}
+
+		if !started {
+			started = p.Cursor == req.Cursor
+			if !started {
+				continue
+			}
+		}
+
+		if req.WithSubComments {
+			expandSubComments(ctx, page, collector)
+		}
+		collector.attachSubComments(p)
+
+		logrus.Infof("获取评论页: cursor=%q, 评论 %d 条, has_more=%v", p.Cursor, len(p.Comments), p.HasMore)
+		if err := fn(p); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return nil
+			}
+			return err
+		}
+		if !p.HasMore {
+			return nil
+		}
+	}
+}
+
+// nextPage 返回第 i 页，尚未加载时滚动触发加载；没有更多评论时返回 nil
+func (a *CommentPageAction) nextPage(ctx context.Context, page *rod.Page, collector *commentPageCollector, i int) (*CommentPage, error) {
+	if p, ok := collector.pageAt(i); ok {
+		return p, nil
+	}
+	if prev, ok := collector.pageAt(i - 1); ok && !prev.HasMore {
+		return nil, nil
+	}
+
+	for trial := 0; trial < commentPageScrollTrials; trial++ {
+		before := collector.responses()
+		scrollToLastComment(page)
+		humanScroll(page, "normal", trial >= 2, 1+trial)
+		if collector.waitMore(ctx, before, commentPageWaitTimeout) {
+			if p, ok := collector.pageAt(i); ok {
+				return p, nil
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if checkEndContainer(page) {
+			return nil, nil
+		}
+	}
+
+	prev, _ := collector.pageAt(i - 1)
+	cursor := ""
+	if prev != nil {
+		cursor = prev.NextCursor
+	}
+	return nil, errors.Errorf("加载下一页评论超时，可从游标 %q 继续", cursor)
+}
+
+// expandSubComments 点击已加载评论中的"展开更多回复"，直到没有可点击的按钮
+func expandSubComments(ctx context.Context, page *rod.Page, collector *commentPageCollector) {
+	for round := 0; round < maxSubCommentRounds; round++ {
+		before := collector.responses()
+		clicked, _ := clickShowMoreButtonsSmart(page, 0)
+		if clicked == 0 {
+			return
+		}
+		collector.waitMore(ctx, before, 3*time.Second)
+	}
+}
+
+// commentPageCollector 收集评论接口响应
+type commentPageCollector struct {
+	hijackCollector
+	pages []*CommentPage
+	subs  map[string][]Comment // 一级评论 ID -> 展开加载的楼中楼回复
+}
+
+func (c *commentPageCollector) handlePage(h *rod.Hijack) {
+	var resp commentPageResponse
+	if !decodeHijackJSON(h, "评论", &resp) {
+		return
+	}
+
+	p := &CommentPage{
+		Comments:   make([]Comment, 0, len(resp.Data.Comments)),
+		Cursor:     h.Request.URL().Query().Get("cursor"),
+		NextCursor: resp.Data.Cursor,
+		HasMore:    resp.Data.HasMore,
+	}
+	for i := range resp.Data.Comments {
+		p.Comments = append(p.Comments, resp.Data.Comments[i].toComment())
+	}
+
+	c.record(func() {
+		c.pages = append(c.pages, p)
+	})
+}
+
+func (c *commentPageCollector) handleSubPage(h *rod.Hijack) {
+	var resp commentPageResponse
+	if !decodeHijackJSON(h, "评论", &resp) {
+		return
+	}
+
+	rootID := h.Request.URL().Query().Get("root_comment_id")
+	c.record(func() {
+		for i := range resp.Data.Comments {
+			c.subs[rootID] = append(c.subs[rootID], resp.Data.Comments[i].toComment())
+		}
+	})
+}
+
+// prepend 把首屏评论作为第一页插入
+func (c *commentPageCollector) prepend(p *CommentPage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pages = append([]*CommentPage{p}, c.pages...)
+}
+
+func (c *commentPageCollector) pageAt(i int) (*CommentPage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i < 0 || i >= len(c.pages) {
+		return nil, false
+	}
+	return c.pages[i], true
+}
+
+// attachSubComments 把展开加载的楼中楼回复合并到对应的一级评论，按 ID 去重
+func (c *commentPageCollector) attachSubComments(p *CommentPage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range p.Comments {
+		p.Comments[i].SubComments = mergeComments(p.Comments[i].SubComments, c.subs[p.Comments[i].ID])
+	}
+}
+
+func mergeComments(list, more []Comment) []Comment {
+	if len(more) == 0 {
+		return list
+	}
+	seen := make(map[string]bool, len(list)+len(more))
+	for _, c := range list {
+		seen[c.ID] = true
+	}
+	for _, c := range more {
+		if c.ID == "" || seen[c.ID] {
+			continue
+		}
+		seen[c.ID] = true
+		list = append(list, c)
+	}
+	return list
+}
@@ -0,0 +1,46 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentPageResponseUnmarshal(t *testing.T) {
+	data := `{"code":0,"success":true,"data":{"cursor":"next","has_more":true,"comments":[
+		{"id":"c1","note_id":"n1","content":"好看","like_count":"3","create_time":1700000000000,
+		 "ip_location":"上海","sub_comment_count":"2","show_tags":[],
+		 "user_info":{"user_id":"u1","nickname":"小红","image":"https://example.com/a.jpg"},
+		 "sub_comments":[{"id":"s1","note_id":"n1","content":"谢谢","show_tags":["is_author"],"user_info":{"user_id":"u0","nickname":"作者"}}]}]}}`
+
+	var resp commentPageResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &resp))
+	require.Len(t, resp.Data.Comments, 1)
+
+	c := resp.Data.Comments[0].toComment()
+	assert.Equal(t, "c1", c.ID)
+	assert.Equal(t, "u1", c.UserInfo.UserID)
+	assert.Equal(t, "小红", c.UserInfo.Nickname)
+	assert.Equal(t, int64(1700000000000), c.CreateTime)
+	require.Len(t, c.SubComments, 1)
+	assert.Equal(t, []string{"is_author"}, c.SubComments[0].ShowTags)
+	assert.Equal(t, "next", resp.Data.Cursor)
+	assert.True(t, resp.Data.HasMore)
+}
+
+func TestAttachSubCommentsDedup(t *testing.T) {
+	collector := &commentPageCollector{subs: map[string][]Comment{
+		"c1": {{ID: "s1"}, {ID: "s2"}, {ID: "s2"}},
+	}}
+	p := &CommentPage{Comments: []Comment{
+		{ID: "c1", SubComments: []Comment{{ID: "s1"}}},
+		{ID: "c2"},
+	}}
+
+	collector.attachSubComments(p)
+	require.Len(t, p.Comments[0].SubComments, 2)
+	assert.Equal(t, "s2", p.Comments[0].SubComments[1].ID)
+	assert.Empty(t, p.Comments[1].SubComments)
+}
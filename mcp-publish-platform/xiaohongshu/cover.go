@@ -0,0 +1,12 @@
+package xiaohongshu
+
+import "github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+
+// coverDialog 视频发布页的封面设置弹窗
+var coverDialog = platform.CoverDialog{
+	EntrySelector:  ".cover-container, .coverImg, [class*='cover']",
+	EntryPattern:   "设置封面|修改封面|编辑封面",
+	ModalSelector:  ".d-modal",
+	UploadTab:      "^上传封面$",
+	ConfirmPattern: "^确定$|^完成$",
+}
@@ -0,0 +1,355 @@
+package xiaohongshu
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+const (
+	urlOfNoteManager = `https://creator.xiaohongshu.com/new/note-manager`
+
+	// creatorNotesAPIPattern 笔记管理页加载笔记列表的接口
+	creatorNotesAPIPattern = `*/api/galaxy/*/creator/note/user/posted*`
+)
+
+// CreatorNoteStatus 创作中心笔记状态筛选
+type CreatorNoteStatus string
+
+const (
+	CreatorNoteStatusAll       CreatorNoteStatus = ""          // 全部笔记
+	CreatorNoteStatusPublished CreatorNoteStatus = "published" // 已发布
+	CreatorNoteStatusReviewing CreatorNoteStatus = "reviewing" // 审核中
+	CreatorNoteStatusRejected  CreatorNoteStatus = "rejected"  // 未通过
+)
+
+// creatorNoteTabs 状态与笔记管理页 TAB 名称的对应关系
+var creatorNoteTabs = map[CreatorNoteStatus]string{
+	CreatorNoteStatusAll:       "全部笔记",
+	CreatorNoteStatusPublished: "已发布",
+	CreatorNoteStatusReviewing: "审核中",
+	CreatorNoteStatusRejected:  "未通过",
+}
+
+// ParseCreatorNoteStatus 解析状态筛选参数，兼容中文 TAB 名称
+func ParseCreatorNoteStatus(s string) (CreatorNoteStatus, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "", "all":
+		return CreatorNoteStatusAll, nil
+	}
+	for status, tab := range creatorNoteTabs {
+		if s == string(status) || s == tab {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("不支持的笔记状态: %s（可选 all|published|reviewing|rejected）", s)
+}
+
+// CreatorNote 创作中心中的笔记及其数据
+type CreatorNote struct {
+	ID           string             `json:"id"`
+	XsecToken    string             `json:"xsec_token"`
+	Title        string             `json:"display_title"`
+	Type         string             `json:"type"` // normal 图文，video 视频
+	Time         string             `json:"time"` // 发布时间，如 "2024-01-20 10:30"
+	Images       []CreatorNoteImage `json:"images_list"`
+	ViewCount    creatorCount       `json:"view_count"`
+	LikeCount    creatorCount       `json:"likes"`
+	CommentCount creatorCount       `json:"comments_count"`
+	CollectCount creatorCount       `json:"collected_count"`
+	ShareCount   creatorCount       `json:"shared_count"`
+	TabStatus    int                `json:"tab_status"`
+	Status       CreatorNoteStatus  `json:"status"`
+}
+
+// CreatorNoteImage 笔记图片
+type CreatorNoteImage struct {
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// CoverURL 返回笔记封面
+func (n *CreatorNote) CoverURL() string {
+	if len(n.Images) == 0 {
+		return ""
+	}
+	return n.Images[0].URL
+}
+
+// PublishedAt 解析发布时间，失败时返回零值
+func (n *CreatorNote) PublishedAt() time.Time {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006年01月02日 15:04"} {
+		if t, err := time.ParseInLocation(layout, n.Time, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// creatorCount 创作中心接口中的数量字段，可能是数字或 "1.2万" 形式的字符串
+type creatorCount int
+
+func (c *creatorCount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		*c = 0
+		return nil
+	}
+	if data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*c = creatorCount(xhsutil.ParseCount(s))
+		return nil
+	}
+	var n float64
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*c = creatorCount(n)
+	return nil
+}
+
+// CreatorNotesRequest 笔记列表请求
+type CreatorNotesRequest struct {
+	Page     int               // 页码，从 1 开始
+	PageSize int               // 每页数量
+	Status   CreatorNoteStatus // 状态筛选
+}
+
+// CreatorNotesResult 笔记列表结果
+type CreatorNotesResult struct {
+	Notes    []CreatorNote     `json:"notes"`
+	Total    int               `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+	HasMore  bool              `json:"has_more"`
+	Status   CreatorNoteStatus `json:"status"`
+}
+
+// creatorNotesResponse 笔记列表接口响应
+type creatorNotesResponse struct {
+	Code    int    `json:"code"`
+	Success bool   `json:"success"`
+	Msg     string `json:"msg"`
+	Data    struct {
+		Notes []CreatorNote `json:"notes"`
+		Page  int           `json:"page"` // 下一页页码，-1 表示没有更多
+		Tags  []struct {
+			ID         string `json:"id"`
+			Name       string `json:"name"`
+			NotesCount int    `json:"notes_count"`
+		} `json:"tags"`
+	} `json:"data"`
+}
+
+// CreatorNotesAction 创作中心笔记管理
+type CreatorNotesAction struct {
+	page *rod.Page
+}
+
+func NewCreatorNotesAction(page *rod.Page) *CreatorNotesAction {
+	pp := page.Timeout(120 * time.Second)
+	return &CreatorNotesAction{page: pp}
+}
+
+// ListNotes 获取自己发布的笔记
+// 笔记管理页通过接口分批加载笔记，这里拦截接口响应，按需滚动加载到目标页
+func (a *CreatorNotesAction) ListNotes(ctx context.Context, req CreatorNotesRequest) (*CreatorNotesResult, error) {
+	if req.Page <= 0 {
+		req.Page = 1
+	}
+	if req.PageSize <= 0 {
+		req.PageSize = 20
+	}
+	tab, ok := creatorNoteTabs[req.Status]
+	if !ok {
+		return nil, fmt.Errorf("不支持的笔记状态: %s", req.Status)
+	}
+
+	page := a.page.Context(ctx)
+
+	collector := newCreatorNotesCollector()
+	router := page.HijackRequests()
+	router.MustAdd(creatorNotesAPIPattern, collector.handle)
+	go router.Run()
+	defer router.MustStop()
+
+	if err := page.Navigate(urlOfNoteManager); err != nil {
+		return nil, errors.Wrap(err, "导航到笔记管理页失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待笔记管理页加载出现问题: %v，继续尝试", err)
+	}
+
+	if !collector.wait(ctx, 15*time.Second) {
+		return nil, errors.New("未获取到笔记列表，请确认已登录创作中心")
+	}
+
+	if req.Status != CreatorNoteStatusAll {
+		collector.reset()
+		if err := clickNoteManagerTab(page, tab); err != nil {
+			return nil, err
+		}
+		if !collector.wait(ctx, 15*time.Second) {
+			return nil, errors.Errorf("切换到 %s 后未获取到笔记列表", tab)
+		}
+	}
+
+	// 滚动加载，直到覆盖目标页或没有更多数据
+	want := req.Page * req.PageSize
+	for collector.count() < want && collector.hasMore() {
+		before := collector.responses()
+		page.Mouse.MustScroll(0, 2000)
+		if !collector.waitMore(ctx, before, 8*time.Second) {
+			logrus.Warnf("滚动后未加载到更多笔记，已获取 %d 条", collector.count())
+			break
+		}
+	}
+
+	notes, total, more := collector.result(tab)
+	for i := range notes {
+		if req.Status != CreatorNoteStatusAll {
+			notes[i].Status = req.Status
+		} else {
+			notes[i].Status = creatorNoteStatusOf(notes[i].TabStatus)
+		}
+	}
+
+	start := (req.Page - 1) * req.PageSize
+	if start > len(notes) {
+		start = len(notes)
+	}
+	end := start + req.PageSize
+	if end > len(notes) {
+		end = len(notes)
+	}
+
+	return &CreatorNotesResult{
+		Notes:    notes[start:end],
+		Total:    total,
+		Page:     req.Page,
+		PageSize: req.PageSize,
+		HasMore:  end < len(notes) || more,
+		Status:   req.Status,
+	}, nil
+}
+
+// creatorNoteStatusOf 笔记接口中 tab_status 与 TAB 顺序一致：1 已发布，2 审核中，3 未通过
+func creatorNoteStatusOf(tabStatus int) CreatorNoteStatus {
+	switch tabStatus {
+	case 1:
+		return CreatorNoteStatusPublished
+	case 2:
+		return CreatorNoteStatusReviewing
+	case 3:
+		return CreatorNoteStatusRejected
+	default:
+		return CreatorNoteStatusAll
+	}
+}
+
+func clickNoteManagerTab(page *rod.Page, tab string) error {
+	elem, err := page.Timeout(10*time.Second).ElementR(`div, span`, "^"+tab)
+	if err != nil {
+		return errors.Wrapf(err, "未找到笔记状态 TAB: %s", tab)
+	}
+	if err := elem.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrapf(err, "点击笔记状态 TAB 失败: %s", tab)
+	}
+	return nil
+}
+
+// creatorNotesCollector 收集笔记列表接口响应
+type creatorNotesCollector struct {
+	hijackCollector
+	notes []CreatorNote
+	seen  map[string]bool
+	tags  map[string]int
+	more  bool
+}
+
+func newCreatorNotesCollector() *creatorNotesCollector {
+	return &creatorNotesCollector{
+		seen: make(map[string]bool),
+		tags: make(map[string]int),
+	}
+}
+
+func (c *creatorNotesCollector) handle(h *rod.Hijack) {
+	var resp creatorNotesResponse
+	if !decodeHijackJSON(h, "笔记列表", &resp) {
+		return
+	}
+
+	c.record(func() {
+		for _, note := range resp.Data.Notes {
+			if note.ID == "" || c.seen[note.ID] {
+				continue
+			}
+			c.seen[note.ID] = true
+			c.notes = append(c.notes, note)
+		}
+		for _, tag := range resp.Data.Tags {
+			c.tags[tag.Name] = tag.NotesCount
+		}
+		c.more = resp.Data.Page >= 0 && len(resp.Data.Notes) > 0
+	})
+}
+
+func (c *creatorNotesCollector) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.notes = nil
+	c.seen = make(map[string]bool)
+	c.more = false
+	c.batches = 0
+}
+
+func (c *creatorNotesCollector) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.notes)
+}
+
+func (c *creatorNotesCollector) hasMore() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.more
+}
+
+// wait 等待至少一次接口响应
+func (c *creatorNotesCollector) wait(ctx context.Context, timeout time.Duration) bool {
+	return c.waitMore(ctx, 0, timeout)
+}
+
+// result 返回已收集的笔记、总数（优先取 TAB 上的计数）以及是否还有更多
+func (c *creatorNotesCollector) result(tab string) ([]CreatorNote, int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	notes := make([]CreatorNote, len(c.notes))
+	copy(notes, c.notes)
+
+	total := len(notes)
+	for name, n := range c.tags {
+		if strings.HasPrefix(name, tab) || (tab == creatorNoteTabs[CreatorNoteStatusAll] && name == "所有笔记") {
+			if n > total {
+				total = n
+			}
+		}
+	}
+	return notes, total, c.more
+}
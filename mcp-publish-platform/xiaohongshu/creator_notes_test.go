@@ -0,0 +1,49 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatorNoteUnmarshal(t *testing.T) {
+	data := `{"id":"abc","xsec_token":"tk","display_title":"标题","type":"video",
+		"time":"2024-01-20 10:30","view_count":1234,"likes":"1.2万","comments_count":"56",
+		"collected_count":null,"shared_count":3,"tab_status":2,
+		"images_list":[{"url":"https://example.com/cover.jpg","width":100,"height":100}]}`
+
+	var note CreatorNote
+	require.NoError(t, json.Unmarshal([]byte(data), &note))
+
+	assert.Equal(t, creatorCount(1234), note.ViewCount)
+	assert.Equal(t, creatorCount(12000), note.LikeCount)
+	assert.Equal(t, creatorCount(56), note.CommentCount)
+	assert.Equal(t, creatorCount(0), note.CollectCount)
+	assert.Equal(t, "https://example.com/cover.jpg", note.CoverURL())
+	assert.Equal(t, 2024, note.PublishedAt().Year())
+	assert.Equal(t, CreatorNoteStatusReviewing, creatorNoteStatusOf(note.TabStatus))
+}
+
+func TestParseCreatorNoteStatus(t *testing.T) {
+	tests := []struct {
+		input string
+		want  CreatorNoteStatus
+	}{
+		{input: "", want: CreatorNoteStatusAll},
+		{input: "all", want: CreatorNoteStatusAll},
+		{input: "published", want: CreatorNoteStatusPublished},
+		{input: "审核中", want: CreatorNoteStatusReviewing},
+		{input: "rejected", want: CreatorNoteStatusRejected},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseCreatorNoteStatus(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got, tt.input)
+	}
+
+	_, err := ParseCreatorNoteStatus("unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,162 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// Draft 草稿箱中的草稿
+// 小红书草稿保存在创作中心本地，没有服务端ID，调用方需根据标题、类型和封面自行标识
+type Draft struct {
+	Index    int    `json:"index"`
+	Title    string `json:"title"`
+	SavedAt  string `json:"saved_at"`
+	CoverURL string `json:"cover_url"`
+	Type     string `json:"type"` // image_text 或 video
+}
+
+// saveDraft 点击"暂存离开"保存草稿
+func saveDraft(page *rod.Page) error {
+	btn, err := page.Timeout(10*time.Second).ElementR(`button`, `^\s*(暂存离开|存草稿|保存草稿)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "查找存草稿按钮失败")
+	}
+	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击存草稿按钮失败")
+	}
+
+	logrus.Info("已保存草稿")
+	time.Sleep(2 * time.Second)
+	return nil
+}
+
+// DraftAction 草稿箱
+type DraftAction struct {
+	page *rod.Page
+}
+
+func NewDraftAction(page *rod.Page) *DraftAction {
+	pp := page.Timeout(300 * time.Second)
+	return &DraftAction{page: pp}
+}
+
+// ListDrafts 列出草稿箱中的草稿，最新保存的在前
+func (a *DraftAction) ListDrafts(ctx context.Context) ([]Draft, error) {
+	page := a.page.Context(ctx)
+
+	if err := openDraftBox(page); err != nil {
+		return nil, err
+	}
+	return scrapeDrafts(page)
+}
+
+// PublishDraft 打开 pick 选中的草稿并发布，返回草稿和核对后的发布结果
+// pick 返回草稿在列表中的下标，无法唯一确定时返回错误
+func (a *DraftAction) PublishDraft(ctx context.Context, pick func([]Draft) (int, error)) (*Draft, *PublishResult, error) {
+	page := a.page.Context(ctx)
+
+	if err := openDraftBox(page); err != nil {
+		return nil, nil, err
+	}
+
+	drafts, err := scrapeDrafts(page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	index, err := pick(drafts)
+	if err != nil {
+		return nil, nil, err
+	}
+	target := &drafts[index]
+
+	items, err := page.Elements(draftItemSelector)
+	if err != nil || target.Index >= len(items) {
+		return nil, nil, errors.New("草稿列表已变化，请重新获取")
+	}
+
+	editBtn, err := items[target.Index].ElementR(`span, div, button`, `^\s*(编辑|继续编辑)\s*$`)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "未找到草稿编辑按钮")
+	}
+	if err := editBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, nil, errors.Wrap(err, "打开草稿失败")
+	}
+
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	btn, err := waitForPublishButtonClickable(page)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := platform.ClickSubmit(page, btn, xhsPublishCheck.FeedbackSelector); err != nil {
+		return nil, nil, errors.Wrap(err, "点击发布按钮失败")
+	}
+
+	logrus.Infof("草稿已提交发布: %s", target.Title)
+	result, err := verifyPublish(ctx, page, target.Title)
+	return target, result, err
+}
+
+const draftItemSelector = `[class*="draft"] [class*="item"], [class*="draft-list"] > div`
+
+// openDraftBox 打开发布页的草稿箱
+func openDraftBox(page *rod.Page) error {
+	if err := page.Navigate(urlOfPublic); err != nil {
+		return errors.Wrap(err, "导航到发布页面失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v，继续尝试", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	entry, err := page.Timeout(15*time.Second).ElementR(`span, div, button`, `^\s*草稿箱`)
+	if err != nil {
+		return errors.Wrap(err, "未找到草稿箱入口")
+	}
+	if err := entry.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "打开草稿箱失败")
+	}
+
+	time.Sleep(1 * time.Second)
+	return nil
+}
+
+// scrapeDrafts 读取草稿列表
+func scrapeDrafts(page *rod.Page) ([]Draft, error) {
+	result, err := page.Eval(`(selector) => {
+		const text = (el, sel) => {
+			const found = el.querySelector(sel);
+			return found ? found.innerText.trim() : '';
+		};
+		const items = Array.from(document.querySelectorAll(selector));
+		return JSON.stringify(items.map((el, index) => {
+			const img = el.querySelector('img');
+			return {
+				index: index,
+				title: text(el, '[class*="title"]'),
+				saved_at: text(el, '[class*="time"], [class*="date"]'),
+				cover_url: img ? img.src : '',
+				type: el.querySelector('video, [class*="video"], [class*="duration"]') ? 'video' : 'image_text',
+			};
+		}));
+	}`, draftItemSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "读取草稿列表失败")
+	}
+
+	var drafts []Draft
+	if err := json.Unmarshal([]byte(result.Value.Str()), &drafts); err != nil {
+		return nil, errors.Wrap(err, "解析草稿列表失败")
+	}
+	return drafts, nil
+}
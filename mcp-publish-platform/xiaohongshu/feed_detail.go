@@ -865,3 +865,8 @@ func (f *FeedDetailAction) extractFeedDetail(page *rod.Page, feedID string) (*Fe
 func makeFeedDetailURL(feedID, xsecToken string) string {
 	return fmt.Sprintf("https://www.xiaohongshu.com/explore/%s?xsec_token=%s&xsec_source=pc_feed", feedID, xsecToken)
 }
+
+// FeedDetailURL 笔记详情页地址
+func FeedDetailURL(feedID, xsecToken string) string {
+	return makeFeedDetailURL(feedID, xsecToken)
+}
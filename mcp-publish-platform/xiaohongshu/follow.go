@@ -0,0 +1,395 @@
+package xiaohongshu
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SelectorFollowButton 用户主页的关注按钮
+	SelectorFollowButton = ".user-info .follow-button"
+
+	// followingAPIPattern 关注列表弹窗加载数据的接口
+	followingAPIPattern = `*/api/sns/web/v1/user/follows*`
+	// followersAPIPattern 粉丝列表弹窗加载数据的接口
+	followersAPIPattern = `*/api/sns/web/v1/user/fans*`
+
+	defaultFollowPageSize  = 20
+	followPageWaitTimeout  = 8 * time.Second
+	followPageScrollTrials = 5
+)
+
+// 关注关系，取自主页 extraInfo.fstatus
+const (
+	FollowStatusNone    = "none"    // 互不关注
+	FollowStatusFollows = "follows" // 我关注了对方
+	FollowStatusFans    = "fans"    // 对方关注了我
+	FollowStatusBoth    = "both"    // 互相关注
+)
+
+// 关注列表类型
+const (
+	FollowListFollowing = "following" // 我关注的人
+	FollowListFollowers = "followers" // 我的粉丝
+)
+
+// FollowAction 负责关注和取消关注
+type FollowAction struct {
+	page *rod.Page
+}
+
+func NewFollowAction(page *rod.Page) *FollowAction {
+	pp := page.Timeout(60 * time.Second)
+	return &FollowAction{page: pp}
+}
+
+// Follow 关注用户，如果已关注则直接返回
+func (a *FollowAction) Follow(ctx context.Context, userID, xsecToken string) error {
+	return a.perform(ctx, userID, xsecToken, true)
+}
+
+// Unfollow 取消关注用户，如果未关注则直接返回
+func (a *FollowAction) Unfollow(ctx context.Context, userID, xsecToken string) error {
+	return a.perform(ctx, userID, xsecToken, false)
+}
+
+func (a *FollowAction) perform(ctx context.Context, userID, xsecToken string, targetFollowing bool) error {
+	actionType := "关注"
+	if !targetFollowing {
+		actionType = "取消关注"
+	}
+
+	page := a.page.Context(ctx)
+	url := makeUserProfileURL(userID, xsecToken)
+	logrus.Infof("Opening user profile page for %s: %s", actionType, url)
+
+	page.MustNavigate(url)
+	page.MustWaitDOMStable()
+	time.Sleep(1 * time.Second)
+
+	following, err := readFollowing(page)
+	if err != nil {
+		logrus.Warnf("failed to read follow state: %v (continue to try clicking)", err)
+	} else if following == targetFollowing {
+		logrus.Infof("user %s already in target state (%s), skip clicking", userID, actionType)
+		return nil
+	}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if err := clickFollowButton(page, targetFollowing); err != nil {
+			return err
+		}
+		time.Sleep(2 * time.Second)
+
+		following, err := readFollowing(page)
+		if err != nil {
+			return errors.Wrapf(err, "已点击%s按钮，但无法确认是否成功", actionType)
+		}
+		if following == targetFollowing {
+			logrus.Infof("user %s %s成功", userID, actionType)
+			return nil
+		}
+		logrus.Warnf("user %s %s可能未成功，状态未变化 (第 %d 次)", userID, actionType, attempt)
+	}
+
+	return errors.Errorf("%s失败：按钮状态未变化", actionType)
+}
+
+// clickFollowButton 点击关注按钮，取消关注时确认弹窗
+func clickFollowButton(page *rod.Page, targetFollowing bool) error {
+	btn, err := page.Timeout(5 * time.Second).Element(SelectorFollowButton)
+	if err != nil {
+		return errors.Wrap(err, "未找到关注按钮，可能是自己的主页或页面结构已变化")
+	}
+	if err := btn.Click("left", 1); err != nil {
+		return errors.Wrap(err, "点击关注按钮失败")
+	}
+
+	if targetFollowing {
+		return nil
+	}
+
+	// 取消关注会弹出确认框
+	confirm, err := page.Timeout(3*time.Second).ElementR("button, .reds-button-new, .confirm", `^(确定|确认|不再关注|取消关注)$`)
+	if err != nil {
+		logrus.Debugf("未出现取消关注确认框: %v", err)
+		return nil
+	}
+	if err := confirm.Click("left", 1); err != nil {
+		return errors.Wrap(err, "确认取消关注失败")
+	}
+	return nil
+}
+
+// readFollowing 读取当前是否已关注主页用户，优先看按钮文字，其次看 __INITIAL_STATE__
+func readFollowing(page *rod.Page) (bool, error) {
+	if btn, err := page.Timeout(2 * time.Second).Element(SelectorFollowButton); err == nil {
+		if text, err := btn.Text(); err == nil {
+			if following, ok := parseFollowButtonText(text); ok {
+				return following, nil
+			}
+		}
+	}
+
+	result, err := page.Eval(`() => {
+		const state = window.__INITIAL_STATE__;
+		if (!state || !state.user || !state.user.userPageData) {
+			return "";
+		}
+		const data = state.user.userPageData.value !== undefined ? state.user.userPageData.value : state.user.userPageData._value;
+		return data && data.extraInfo ? (data.extraInfo.fstatus || "") : "";
+	}`)
+	if err != nil {
+		return false, errors.Wrap(err, "读取关注状态失败")
+	}
+
+	switch result.Value.String() {
+	case FollowStatusFollows, FollowStatusBoth:
+		return true, nil
+	case FollowStatusNone, FollowStatusFans:
+		return false, nil
+	}
+	return false, errors.New("无法识别关注状态")
+}
+
+// parseFollowButtonText 按钮文字：关注/回关 表示未关注，已关注/互相关注 表示已关注
+func parseFollowButtonText(text string) (following, ok bool) {
+	text = strings.TrimSpace(text)
+	switch {
+	case strings.Contains(text, "已关注"), strings.Contains(text, "互相关注"):
+		return true, true
+	case text == "关注", text == "回关", strings.HasPrefix(text, "+"):
+		return false, true
+	}
+	return false, false
+}
+
+// FollowUser 关注列表中的用户
+type FollowUser struct {
+	UserID       string `json:"user_id"`
+	Nickname     string `json:"nickname"`
+	Avatar       string `json:"avatar"`
+	Desc         string `json:"desc,omitempty"`
+	XsecToken    string `json:"xsec_token,omitempty"`
+	FollowStatus string `json:"follow_status,omitempty"` // none|follows|fans|both
+}
+
+// FollowListRequest 按游标分页获取关注/粉丝列表的请求
+type FollowListRequest struct {
+	// Cursor 上一次返回的 NextCursor，为空表示从第一页开始
+	Cursor string
+	// PageSize 期望的用户数量，按接口分页粒度返回，实际数量可能略多
+	PageSize int
+}
+
+// FollowListPage 一页关注/粉丝
+type FollowListPage struct {
+	Users      []FollowUser `json:"users"`
+	Cursor     string       `json:"cursor"`
+	NextCursor string       `json:"next_cursor"`
+	HasMore    bool         `json:"has_more"`
+}
+
+// apiFollowUser 关注列表接口返回的用户
+type apiFollowUser struct {
+	UserID    string `json:"user_id"`
+	Nickname  string `json:"nickname"`
+	Images    string `json:"images"`
+	Image     string `json:"image"`
+	Desc      string `json:"desc"`
+	XsecToken string `json:"xsec_token"`
+	Fstatus   string `json:"fstatus"`
+}
+
+func (u *apiFollowUser) toFollowUser() FollowUser {
+	avatar := u.Images
+	if avatar == "" {
+		avatar = u.Image
+	}
+	return FollowUser{
+		UserID:       u.UserID,
+		Nickname:     u.Nickname,
+		Avatar:       avatar,
+		Desc:         u.Desc,
+		XsecToken:    u.XsecToken,
+		FollowStatus: u.Fstatus,
+	}
+}
+
+// followListResponse 关注/粉丝列表接口响应
+type followListResponse struct {
+	Code    int    `json:"code"`
+	Success bool   `json:"success"`
+	Msg     string `json:"msg"`
+	Data    struct {
+		Users   []apiFollowUser `json:"users"`
+		Cursor  string          `json:"cursor"`
+		HasMore bool            `json:"has_more"`
+	} `json:"data"`
+}
+
+// FollowListAction 获取自己的关注和粉丝列表
+type FollowListAction struct {
+	page *rod.Page
+}
+
+func NewFollowListAction(page *rod.Page) *FollowListAction {
+	pp := page.Timeout(10 * time.Minute)
+	return &FollowListAction{page: pp}
+}
+
+// ListFollowing 获取我关注的人
+func (a *FollowListAction) ListFollowing(ctx context.Context, req FollowListRequest) (*FollowListPage, error) {
+	return a.list(ctx, FollowListFollowing, req)
+}
+
+// ListFollowers 获取我的粉丝
+func (a *FollowListAction) ListFollowers(ctx context.Context, req FollowListRequest) (*FollowListPage, error) {
+	return a.list(ctx, FollowListFollowers, req)
+}
+
+// list 打开个人主页的关注/粉丝弹窗，滚动加载直到凑够一页
+// 弹窗只能从第一页顺序加载，游标之前的页会被跳过
+func (a *FollowListAction) list(ctx context.Context, listType string, req FollowListRequest) (*FollowListPage, error) {
+	if req.PageSize <= 0 {
+		req.PageSize = defaultFollowPageSize
+	}
+	page := a.page.Context(ctx)
+
+	pattern, label := followingAPIPattern, "关注"
+	if listType == FollowListFollowers {
+		pattern, label = followersAPIPattern, "粉丝"
+	}
+
+	collector := &followListCollector{}
+	router := page.HijackRequests()
+	router.MustAdd(pattern, collector.handle)
+	go router.Run()
+	defer router.MustStop()
+
+	if err := NewNavigate(page).ToProfilePage(ctx); err != nil {
+		return nil, errors.Wrap(err, "打开个人主页失败")
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待个人主页稳定出现问题: %v，继续尝试", err)
+	}
+
+	entry, err := page.Timeout(10*time.Second).ElementR(".user-interactions > div", label)
+	if err != nil {
+		return nil, errors.Wrapf(err, "未找到%s入口", label)
+	}
+	if err := entry.Click("left", 1); err != nil {
+		return nil, errors.Wrapf(err, "打开%s列表失败", label)
+	}
+
+	result := &FollowListPage{
+		Users:      make([]FollowUser, 0),
+		Cursor:     req.Cursor,
+		NextCursor: req.Cursor,
+	}
+	started := req.Cursor == ""
+	for i := 0; ; i++ {
+		p, err := collector.next(ctx, page, i)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			if !started {
+				return nil, errors.Errorf("未找到游标对应的%s列表页: %s", label, req.Cursor)
+			}
+			return result, nil
+		}
+
+		if !started {
+			started = p.Cursor == req.Cursor
+			if !started {
+				continue
+			}
+		}
+
+		result.Users = append(result.Users, p.Users...)
+		result.NextCursor = p.NextCursor
+		result.HasMore = p.HasMore
+		if !p.HasMore || len(result.Users) >= req.PageSize {
+			logrus.Infof("获取%s列表: cursor=%q, 用户 %d 个, has_more=%v", label, req.Cursor, len(result.Users), result.HasMore)
+			return result, nil
+		}
+	}
+}
+
+// followListCollector 收集关注/粉丝列表接口响应
+type followListCollector struct {
+	hijackCollector
+	pages []*FollowListPage
+}
+
+func (c *followListCollector) handle(h *rod.Hijack) {
+	var resp followListResponse
+	if !decodeHijackJSON(h, "关注列表", &resp) {
+		return
+	}
+
+	p := &FollowListPage{
+		Users:      make([]FollowUser, 0, len(resp.Data.Users)),
+		Cursor:     h.Request.URL().Query().Get("cursor"),
+		NextCursor: resp.Data.Cursor,
+		HasMore:    resp.Data.HasMore,
+	}
+	for i := range resp.Data.Users {
+		p.Users = append(p.Users, resp.Data.Users[i].toFollowUser())
+	}
+
+	c.record(func() {
+		c.pages = append(c.pages, p)
+	})
+}
+
+func (c *followListCollector) pageAt(i int) (*FollowListPage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if i < 0 || i >= len(c.pages) {
+		return nil, false
+	}
+	return c.pages[i], true
+}
+
+// next 返回第 i 页，尚未加载时滚动弹窗触发加载；没有更多时返回 nil
+func (c *followListCollector) next(ctx context.Context, page *rod.Page, i int) (*FollowListPage, error) {
+	if prev, ok := c.pageAt(i - 1); ok && !prev.HasMore {
+		return nil, nil
+	}
+
+	for trial := 0; trial <= followPageScrollTrials; trial++ {
+		before := c.responses()
+		if p, ok := c.pageAt(i); ok {
+			return p, nil
+		}
+		// 第一页在打开弹窗时加载，之后滚动弹窗中的最后一个用户触发加载
+		if trial > 0 || i > 0 {
+			page.MustEval(`() => {
+				const items = document.querySelectorAll('.follow-list .user-item, .fans-list .user-item, [class*="follow"] [class*="user-item"]');
+				if (items.length > 0) {
+					items[items.length - 1].scrollIntoView({behavior: 'smooth', block: 'end'});
+				}
+			}`)
+		}
+
+		if !c.waitMore(ctx, before, followPageWaitTimeout) && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	if p, ok := c.pageAt(i); ok {
+		return p, nil
+	}
+	if i == 0 {
+		return nil, errors.New("加载列表超时，可能列表为空或页面结构已变化")
+	}
+	prev, _ := c.pageAt(i - 1)
+	return nil, errors.Errorf("加载下一页超时，可从游标 %q 继续", prev.NextCursor)
+}
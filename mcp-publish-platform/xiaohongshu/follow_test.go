@@ -0,0 +1,49 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFollowButtonText(t *testing.T) {
+	tests := []struct {
+		text      string
+		following bool
+		ok        bool
+	}{
+		{"关注", false, true},
+		{" 回关 ", false, true},
+		{"已关注", true, true},
+		{"互相关注", true, true},
+		{"发消息", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		following, ok := parseFollowButtonText(tt.text)
+		assert.Equal(t, tt.ok, ok, tt.text)
+		assert.Equal(t, tt.following, following, tt.text)
+	}
+}
+
+func TestFollowListResponseDecode(t *testing.T) {
+	raw := `{"code":0,"success":true,"data":{"users":[
+		{"user_id":"u1","nickname":"小红","images":"http://a/1.jpg","desc":"hi","xsec_token":"tk","fstatus":"both"},
+		{"user_id":"u2","nickname":"小蓝","image":"http://a/2.jpg","fstatus":"fans"}
+	],"cursor":"c2","has_more":true}}`
+
+	var resp followListResponse
+	require.NoError(t, json.Unmarshal([]byte(raw), &resp))
+	require.Len(t, resp.Data.Users, 2)
+
+	u1 := resp.Data.Users[0].toFollowUser()
+	assert.Equal(t, FollowUser{UserID: "u1", Nickname: "小红", Avatar: "http://a/1.jpg", Desc: "hi", XsecToken: "tk", FollowStatus: FollowStatusBoth}, u1)
+
+	u2 := resp.Data.Users[1].toFollowUser()
+	assert.Equal(t, "http://a/2.jpg", u2.Avatar)
+	assert.Equal(t, FollowStatusFans, u2.FollowStatus)
+	assert.Equal(t, "c2", resp.Data.Cursor)
+	assert.True(t, resp.Data.HasMore)
+}
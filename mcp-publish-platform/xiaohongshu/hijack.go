@@ -0,0 +1,98 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+)
+
+// hijackCollector 收集被拦截接口响应的公共部分：响应计数和等待新响应
+// 具体的收集器嵌入它，在 record 中累积自己的数据，读取这些数据时持有同一把锁 mu
+type hijackCollector struct {
+	mu      sync.Mutex
+	batches int
+	notify  chan struct{}
+}
+
+// decodeHijackJSON 加载被拦截请求的响应并解析到 v，加载失败、解析失败或接口返回错误时返回 false
+func decodeHijackJSON(h *rod.Hijack, name string, v any) bool {
+	if err := h.LoadResponse(nil, true); err != nil {
+		logrus.Warnf("加载%s接口响应失败: %v", name, err)
+		return false
+	}
+
+	body := h.Response.Payload().Body
+	var status struct {
+		Code    int    `json:"code"`
+		Success bool   `json:"success"`
+		Msg     string `json:"msg"`
+	}
+	if err := json.Unmarshal(body, &status); err != nil {
+		logrus.Warnf("解析%s接口响应失败: %v", name, err)
+		return false
+	}
+	if !status.Success && status.Code != 0 {
+		logrus.Warnf("%s接口返回错误: code=%d, msg=%s", name, status.Code, status.Msg)
+		return false
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		logrus.Warnf("解析%s接口响应失败: %v", name, err)
+		return false
+	}
+	return true
+}
+
+// record 在锁内累积一次响应的数据，并唤醒等待方
+func (c *hijackCollector) record(fn func()) {
+	c.mu.Lock()
+	fn()
+	c.batches++
+	notify := c.notifyLocked()
+	c.mu.Unlock()
+
+	select {
+	case notify <- struct{}{}:
+	default:
+	}
+}
+
+// notifyLocked 返回通知通道，零值收集器在首次使用时创建
+func (c *hijackCollector) notifyLocked() chan struct{} {
+	if c.notify == nil {
+		c.notify = make(chan struct{}, 1)
+	}
+	return c.notify
+}
+
+// responses 已收到的响应数
+func (c *hijackCollector) responses() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.batches
+}
+
+// waitMore 等待响应数超过 before，超时或 ctx 取消时返回 false
+func (c *hijackCollector) waitMore(ctx context.Context, before int, timeout time.Duration) bool {
+	c.mu.Lock()
+	notify := c.notifyLocked()
+	c.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		if c.responses() > before {
+			return true
+		}
+		select {
+		case <-notify:
+		case <-timer.C:
+			return c.responses() > before
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
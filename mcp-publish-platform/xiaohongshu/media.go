@@ -0,0 +1,131 @@
+package xiaohongshu
+
+import (
+	"net/url"
+	"strings"
+)
+
+// 媒体类型
+const (
+	MediaImage     = "image"      // 图片
+	MediaLivePhoto = "live_photo" // 实况图的视频部分
+	MediaVideo     = "video"      // 视频笔记的视频
+)
+
+const (
+	originalImageHost = "https://sns-img-bd.xhscdn.com/"
+	originalVideoHost = "https://sns-video-bd.xhscdn.com/"
+)
+
+// MediaItem 笔记中的一个媒体文件，URLs 按优先级排列，前一个下载失败时依次尝试后面的
+type MediaItem struct {
+	Kind   string   `json:"kind"`
+	Index  int      `json:"index"` // 从 1 开始，实况图与对应图片序号相同
+	URLs   []string `json:"urls"`
+	Width  int      `json:"width,omitempty"`
+	Height int      `json:"height,omitempty"`
+}
+
+// NoteMedia 列出笔记的全部媒体：原图、实况图视频和视频笔记的视频
+func NoteMedia(note *FeedDetail) []MediaItem {
+	var items []MediaItem
+
+	// 视频笔记的 imageList 只有封面，同样保留
+	for i, img := range note.ImageList {
+		urls := dedupeURLs(OriginalImageURL(img.URLDefault), img.URLDefault, imageSceneURL(img.InfoList, "WB_DFT"), img.URLPre)
+		if len(urls) > 0 {
+			items = append(items, MediaItem{Kind: MediaImage, Index: i + 1, URLs: urls, Width: img.Width, Height: img.Height})
+		}
+
+		if img.Stream != nil {
+			if s := bestStream(img.Stream); s != nil {
+				items = append(items, MediaItem{
+					Kind:   MediaLivePhoto,
+					Index:  i + 1,
+					URLs:   dedupeURLs(append([]string{s.MasterURL}, s.BackupURLs...)...),
+					Width:  s.Width,
+					Height: s.Height,
+				})
+			}
+		}
+	}
+
+	if v := note.Video; v != nil {
+		var urls []string
+		var width, height int
+		if key := v.Consumer.OriginVideoKey; key != "" {
+			urls = append(urls, originalVideoHost+key)
+		}
+		if s := bestStream(&v.Media.Stream); s != nil {
+			urls = append(urls, s.MasterURL)
+			urls = append(urls, s.BackupURLs...)
+			width, height = s.Width, s.Height
+		}
+		if urls = dedupeURLs(urls...); len(urls) > 0 {
+			items = append(items, MediaItem{Kind: MediaVideo, Index: 1, URLs: urls, Width: width, Height: height})
+		}
+	}
+
+	return items
+}
+
+// OriginalImageURL 由详情页的图片地址推出无压缩原图地址，无法识别时返回空
+// 详情页地址形如 http://sns-webpic-qc.xhscdn.com/<时间>/<签名>/<图片ID>!nd_dft_wlteh_webp_3
+func OriginalImageURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	path := strings.TrimPrefix(u.Path, "/")
+	if i := strings.Index(path, "!"); i >= 0 {
+		path = path[:i]
+	}
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 3 || parts[2] == "" {
+		return ""
+	}
+	return originalImageHost + parts[2]
+}
+
+// bestStream 优先选兼容性最好的 H.264，同编码下选分辨率最高的
+func bestStream(s *MediaStream) *StreamInfo {
+	for _, streams := range [][]StreamInfo{s.H264, s.H265, s.AV1} {
+		var best *StreamInfo
+		for i := range streams {
+			cur := &streams[i]
+			if cur.MasterURL == "" {
+				continue
+			}
+			if best == nil || cur.Width*cur.Height > best.Width*best.Height {
+				best = cur
+			}
+		}
+		if best != nil {
+			return best
+		}
+	}
+	return nil
+}
+
+func imageSceneURL(infos []ImageInfo, scene string) string {
+	for _, info := range infos {
+		if info.ImageScene == scene {
+			return info.URL
+		}
+	}
+	return ""
+}
+
+func dedupeURLs(urls ...string) []string {
+	seen := make(map[string]bool, len(urls))
+	result := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if u == "" || seen[u] {
+			continue
+		}
+		seen[u] = true
+		result = append(result, u)
+	}
+	return result
+}
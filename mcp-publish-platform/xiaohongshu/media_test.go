@@ -0,0 +1,77 @@
+package xiaohongshu
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOriginalImageURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			"http://sns-webpic-qc.xhscdn.com/202410181234/0a1b2c3d/1040g2sg31abc!nd_dft_wlteh_webp_3",
+			"https://sns-img-bd.xhscdn.com/1040g2sg31abc",
+		},
+		{
+			"https://sns-webpic-qc.xhscdn.com/202410181234/0a1b2c3d/spectrum/1040g0k0xyz!nd_dft_wgth_webp_3",
+			"https://sns-img-bd.xhscdn.com/spectrum/1040g0k0xyz",
+		},
+		{"https://sns-webpic-qc.xhscdn.com/only-one-part", ""},
+		{"not a url", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, OriginalImageURL(tt.in), tt.in)
+	}
+}
+
+func TestNoteMedia(t *testing.T) {
+	raw := `{
+		"noteId": "n1",
+		"imageList": [
+			{"width": 1080, "height": 1440, "urlDefault": "http://sns-webpic-qc.xhscdn.com/t/s/img1!nd_dft", "urlPre": "http://sns-webpic-qc.xhscdn.com/t/s/img1!nd_prv"},
+			{"width": 1080, "height": 1440, "urlDefault": "http://sns-webpic-qc.xhscdn.com/t/s/img2!nd_dft", "livePhoto": true,
+			 "stream": {"h264": [
+				{"masterUrl": "http://v/low.mp4", "width": 480, "height": 640},
+				{"masterUrl": "http://v/high.mp4", "backupUrls": ["http://v/high-bak.mp4"], "width": 1080, "height": 1440}
+			 ], "h265": [], "av1": []}}
+		],
+		"video": {
+			"consumer": {"originVideoKey": "pre_post/abc"},
+			"media": {"stream": {"h264": [], "h265": [{"masterUrl": "http://v/h265.mp4", "width": 720, "height": 1280}]}},
+			"capa": {"duration": 30}
+		}
+	}`
+	var note FeedDetail
+	require.NoError(t, json.Unmarshal([]byte(raw), &note))
+
+	items := NoteMedia(&note)
+	require.Len(t, items, 4)
+
+	assert.Equal(t, MediaImage, items[0].Kind)
+	assert.Equal(t, 1, items[0].Index)
+	assert.Equal(t, []string{
+		"https://sns-img-bd.xhscdn.com/img1",
+		"http://sns-webpic-qc.xhscdn.com/t/s/img1!nd_dft",
+		"http://sns-webpic-qc.xhscdn.com/t/s/img1!nd_prv",
+	}, items[0].URLs)
+
+	assert.Equal(t, MediaImage, items[1].Kind)
+	assert.Equal(t, MediaLivePhoto, items[2].Kind)
+	assert.Equal(t, 2, items[2].Index)
+	assert.Equal(t, []string{"http://v/high.mp4", "http://v/high-bak.mp4"}, items[2].URLs)
+	assert.Equal(t, 1080, items[2].Width)
+
+	assert.Equal(t, MediaVideo, items[3].Kind)
+	assert.Equal(t, []string{"https://sns-video-bd.xhscdn.com/pre_post/abc", "http://v/h265.mp4"}, items[3].URLs)
+	assert.Equal(t, 720, items[3].Width)
+}
+
+func TestNoteMediaEmpty(t *testing.T) {
+	assert.Empty(t, NoteMedia(&FeedDetail{}))
+}
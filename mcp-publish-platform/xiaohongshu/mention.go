@@ -0,0 +1,89 @@
+package xiaohongshu
+
+import (
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// errMentionNotFound 提及选择器中没有匹配的用户
+var errMentionNotFound = errors.New("未找到提及用户")
+
+// mentionItemSelectors 提及选择器候选项，依次为发布页编辑器和笔记详情页评论框
+var mentionItemSelectors = []string{
+	"#creator-editor-mention-container .item",
+	".mention-container .mention-item",
+	`[class*="mention"] [class*="item"]`,
+}
+
+// inputMentions 在光标处逐个插入 @提及
+// 只有从提及选择器中选中的用户才会渲染为可跳转的提及，直接输入 "@昵称" 只是普通文本，因此匹配不到的用户会被跳过
+func inputMentions(elem *rod.Element, names []string) error {
+	for _, name := range names {
+		name = strings.TrimSpace(strings.TrimLeft(name, "@"))
+		if name == "" {
+			continue
+		}
+		if err := inputMention(elem, name); err != nil {
+			if errors.Is(err, errMentionNotFound) {
+				logrus.Warnf("提及选择器中未找到用户[%s]，已跳过", name)
+				continue
+			}
+			return errors.Wrapf(err, "提及用户[%s]失败", name)
+		}
+	}
+	return nil
+}
+
+// inputMention 输入 @昵称 并从提及选择器中点击匹配的用户
+func inputMention(elem *rod.Element, name string) error {
+	if err := elem.Input(" "); err != nil {
+		return errors.Wrap(err, "输入空格失败")
+	}
+	if err := typeTrigger(elem, "@", name); err != nil {
+		return err
+	}
+
+	// 提及选择器需要请求用户搜索接口，比话题联想慢
+	time.Sleep(1500 * time.Millisecond)
+
+	items := mentionItems(elem.Page())
+	texts := make([]string, 0, len(items))
+	for _, item := range items {
+		text, _ := item.Text()
+		texts = append(texts, text)
+	}
+
+	idx := platform.MatchMention(texts, name)
+	if idx < 0 {
+		clearTyped(elem, " @"+name)
+		return errMentionNotFound
+	}
+
+	if err := items[idx].Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击提及用户失败")
+	}
+	logrus.Infof("成功提及用户: @%s", name)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// mentionItems 读取当前展开的提及选择器候选项
+func mentionItems(page *rod.Page) []*rod.Element {
+	for _, selector := range mentionItemSelectors {
+		has, _, err := page.Has(selector)
+		if err != nil || !has {
+			continue
+		}
+		items, err := page.Elements(selector)
+		if err == nil && len(items) > 0 {
+			return items
+		}
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package xiaohongshu
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// chatPage 专业号私信工作台，网页版小红书不提供私信入口
+var chatPage = platform.ChatPage{
+	URL:                  "https://pro.xiaohongshu.com/im",
+	ConversationSelector: `[class*="conversation"] [class*="item"], [class*="session"] [class*="item"], [class*="chat-list"] [class*="item"]`,
+	MessageSelector:      `[class*="message-list"] [class*="message-item"], [class*="msg-list"] [class*="msg-item"], [class*="chat-content"] [class*="message"]`,
+	InputSelector:        `textarea, [contenteditable="true"]`,
+}
+
+// MessageAction 私信
+type MessageAction struct {
+	page *rod.Page
+}
+
+func NewMessageAction(page *rod.Page) *MessageAction {
+	pp := page.Timeout(120 * time.Second)
+	return &MessageAction{page: pp}
+}
+
+// ListConversations 列出私信会话，最近的在前
+func (a *MessageAction) ListConversations(ctx context.Context) ([]platform.Conversation, error) {
+	conversations, err := chatPage.Conversations(a.page.Context(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]platform.Conversation, 0, len(conversations))
+	for _, c := range conversations {
+		items = append(items, c.Conversation)
+	}
+	return items, nil
+}
+
+// Messages 打开指定会话并读取已加载的消息记录
+func (a *MessageAction) Messages(ctx context.Context, conversationID string) ([]platform.Message, error) {
+	page := a.page.Context(ctx)
+
+	if _, err := chatPage.Open(page, conversationID); err != nil {
+		return nil, err
+	}
+	return chatPage.Messages(page)
+}
+
+// SendMessage 打开指定会话并发送文本消息，消息出现在会话中才算发送成功
+func (a *MessageAction) SendMessage(ctx context.Context, conversationID, content string) error {
+	page := a.page.Context(ctx)
+
+	target, err := chatPage.Open(page, conversationID)
+	if err != nil {
+		return err
+	}
+	if err := chatPage.Send(page, content); err != nil {
+		return err
+	}
+
+	logrus.Infof("已向 %s 发送私信", target.Nickname)
+	return nil
+}
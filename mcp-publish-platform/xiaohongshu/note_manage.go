@@ -0,0 +1,144 @@
+package xiaohongshu
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+const (
+	urlOfNoteEdit = `https://creator.xiaohongshu.com/publish/update?id=%s`
+)
+
+// xhsManagePage 笔记管理页中的笔记卡片通过曝光埋点携带笔记ID
+var xhsManagePage = platform.ManagePage{
+	URL:          urlOfNoteManager,
+	IDAttrs:      []string{"data-impression"},
+	CardSelector: `div.note, [class*="note-item"]`,
+}
+
+// EditNoteContent 编辑笔记的内容，空字段保持不变
+type EditNoteContent struct {
+	Title   string
+	Content string
+	Tags    []string // 标签位于正文末尾，修改标签需要同时提供正文
+}
+
+// NoteManageAction 已发布笔记的编辑与删除
+type NoteManageAction struct {
+	page *rod.Page
+}
+
+func NewNoteManageAction(page *rod.Page) *NoteManageAction {
+	pp := page.Timeout(300 * time.Second)
+	return &NoteManageAction{page: pp}
+}
+
+// EditNote 打开笔记编辑页，修改标题/正文后重新发布
+func (a *NoteManageAction) EditNote(ctx context.Context, noteID string, content EditNoteContent) error {
+	if content.Title == "" && content.Content == "" && len(content.Tags) == 0 {
+		return errors.New("没有需要修改的内容")
+	}
+	if len(content.Tags) > 0 && content.Content == "" {
+		return errors.New("修改标签需要同时提供正文")
+	}
+
+	page := a.page.Context(ctx)
+
+	if err := page.Navigate(fmt.Sprintf(urlOfNoteEdit, noteID)); err != nil {
+		return errors.Wrap(err, "导航到笔记编辑页失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待笔记编辑页加载出现问题: %v，继续尝试", err)
+	}
+	if err := page.WaitDOMStable(time.Second, 0.1); err != nil {
+		logrus.Warnf("等待 DOM 稳定出现问题: %v，继续尝试", err)
+	}
+
+	titleElem, err := page.Element("div.d-input input")
+	if err != nil {
+		return errors.Wrap(err, "查找标题输入框失败，笔记可能不存在或不可编辑")
+	}
+
+	if content.Title != "" {
+		if err := titleElem.SelectAllText(); err != nil {
+			return errors.Wrap(err, "选中原标题失败")
+		}
+		if err := titleElem.Input(content.Title); err != nil {
+			return errors.Wrap(err, "输入标题失败")
+		}
+		time.Sleep(500 * time.Millisecond)
+		if err := checkTitleMaxLength(page); err != nil {
+			return err
+		}
+		slog.Info("标题修改完成")
+	}
+
+	if content.Content != "" {
+		contentElem, ok := getContentElement(page)
+		if !ok {
+			return errors.New("没有找到内容输入框")
+		}
+		if err := platform.ClearEditable(contentElem); err != nil {
+			return errors.Wrap(err, "清空原正文失败")
+		}
+		if err := contentElem.Input(content.Content); err != nil {
+			return errors.Wrap(err, "输入正文失败")
+		}
+		if err := inputTags(contentElem, content.Tags, false); err != nil {
+			return err
+		}
+		time.Sleep(1 * time.Second)
+		if err := checkContentMaxLength(page); err != nil {
+			return err
+		}
+		slog.Info("正文修改完成")
+	}
+
+	btn, err := waitForPublishButtonClickable(page)
+	if err != nil {
+		return err
+	}
+	if err := platform.ClickSubmit(page, btn, xhsPublishCheck.FeedbackSelector); err != nil {
+		return errors.Wrap(err, "点击发布按钮失败")
+	}
+
+	return xhsManagePage.VerifyEdit(page, noteID, &platform.EditPostRequest{Title: content.Title})
+}
+
+// DeleteNote 在笔记管理页删除指定笔记
+func (a *NoteManageAction) DeleteNote(ctx context.Context, noteID string) error {
+	page := a.page.Context(ctx)
+
+	card, err := xhsManagePage.OpenCard(page, noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := platform.ClickCardAction(page, card, `^\s*删除\s*$`); err != nil {
+		return err
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	confirmBtn, err := page.Timeout(10*time.Second).ElementR(
+		`.d-modal button, .d-popconfirm button, [role="dialog"] button`, `^\s*(确定|确认|删除)\s*$`)
+	if err != nil {
+		return errors.Wrap(err, "未找到删除确认按钮")
+	}
+	if err := confirmBtn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击删除确认按钮失败")
+	}
+
+	if err := xhsManagePage.WaitCardGone(page, noteID, 10*time.Second); err != nil {
+		return err
+	}
+	logrus.Infof("笔记已删除: %s", noteID)
+	return nil
+}
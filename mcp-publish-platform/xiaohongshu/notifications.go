@@ -0,0 +1,197 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	urlOfNotification = `https://www.xiaohongshu.com/notification`
+
+	// mentionsAPIPattern 通知中心"评论和@"加载消息的接口
+	mentionsAPIPattern = `*/api/sns/web/v1/you/mentions*`
+)
+
+// CommentNotification 通知中心中的一条评论通知
+type CommentNotification struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`  // comment/item 评论笔记，comment/comment 回复评论
+	Title    string `json:"title"` // 如 "评论了你的笔记"
+	Time     int64  `json:"time"`  // 秒级时间戳
+	UserInfo struct {
+		UserID    string `json:"userid"`
+		Nickname  string `json:"nickname"`
+		Image     string `json:"image"`
+		XsecToken string `json:"xsec_token"`
+	} `json:"user_info"`
+	CommentInfo struct {
+		ID            string `json:"id"`
+		Content       string `json:"content"`
+		TargetComment struct {
+			ID       string `json:"id"`
+			Content  string `json:"content"`
+			UserInfo struct {
+				UserID   string `json:"userid"`
+				Nickname string `json:"nickname"`
+			} `json:"user_info"`
+		} `json:"target_comment"`
+	} `json:"comment_info"`
+	ItemInfo struct {
+		ID        string `json:"id"`
+		XsecToken string `json:"xsec_token"`
+		Content   string `json:"content"`
+		Image     string `json:"image"`
+		Type      string `json:"type"`
+	} `json:"item_info"`
+}
+
+// IsComment 是否为评论类通知（排除 @ 和点赞等）
+func (n *CommentNotification) IsComment() bool {
+	return strings.HasPrefix(n.Type, "comment/") && n.CommentInfo.ID != ""
+}
+
+// CreatedAt 通知时间
+func (n *CommentNotification) CreatedAt() time.Time {
+	if n.Time <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(n.Time, 0)
+}
+
+// mentionsResponse 通知接口响应
+type mentionsResponse struct {
+	Code    int    `json:"code"`
+	Success bool   `json:"success"`
+	Msg     string `json:"msg"`
+	Data    struct {
+		MessageList []CommentNotification `json:"message_list"`
+		HasMore     bool                  `json:"has_more"`
+		Cursor      json.RawMessage       `json:"cursor"`
+	} `json:"data"`
+}
+
+// NotificationAction 通知中心
+type NotificationAction struct {
+	page *rod.Page
+}
+
+func NewNotificationAction(page *rod.Page) *NotificationAction {
+	pp := page.Timeout(120 * time.Second)
+	return &NotificationAction{page: pp}
+}
+
+// ListCommentNotifications 获取"评论和@"中的评论通知，按时间倒序
+// since 不为零时，加载到早于 since 的消息即停止；limit 为 0 表示不限制数量
+func (a *NotificationAction) ListCommentNotifications(ctx context.Context, since time.Time, limit int) ([]CommentNotification, error) {
+	page := a.page.Context(ctx)
+
+	collector := &mentionsCollector{seen: make(map[string]bool)}
+
+	router := page.HijackRequests()
+	router.MustAdd(mentionsAPIPattern, collector.handle)
+	go router.Run()
+	defer router.MustStop()
+
+	if err := page.Navigate(urlOfNotification); err != nil {
+		return nil, errors.Wrap(err, "导航到通知中心失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待通知中心加载出现问题: %v，继续尝试", err)
+	}
+
+	// 通知中心默认打开"评论和@"，这里显式点击一次保证接口被触发
+	if tab, err := page.Timeout(10*time.Second).ElementR(`div, span`, `^\s*评论和@`); err == nil {
+		if err := tab.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			logrus.Warnf("点击评论和@ TAB 失败: %v", err)
+		}
+	}
+
+	if !collector.waitMore(ctx, 0, 15*time.Second) {
+		return nil, errors.New("未获取到通知列表，请确认已登录")
+	}
+
+	for collector.more() && !collector.reached(since, limit) {
+		before := collector.responses()
+		page.Mouse.MustScroll(0, 2000)
+		if !collector.waitMore(ctx, before, 8*time.Second) {
+			logrus.Warnf("滚动后未加载到更多通知，已获取 %d 条", len(collector.list()))
+			break
+		}
+	}
+
+	var result []CommentNotification
+	for _, n := range collector.list() {
+		if !n.IsComment() {
+			continue
+		}
+		if !since.IsZero() && !n.CreatedAt().After(since) {
+			continue
+		}
+		result = append(result, n)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// mentionsCollector 收集通知接口响应
+type mentionsCollector struct {
+	hijackCollector
+	messages []CommentNotification
+	seen     map[string]bool
+	hasMore  bool
+}
+
+func (c *mentionsCollector) handle(h *rod.Hijack) {
+	var resp mentionsResponse
+	if !decodeHijackJSON(h, "通知", &resp) {
+		return
+	}
+
+	c.record(func() {
+		for _, msg := range resp.Data.MessageList {
+			if msg.ID == "" || c.seen[msg.ID] {
+				continue
+			}
+			c.seen[msg.ID] = true
+			c.messages = append(c.messages, msg)
+		}
+		c.hasMore = resp.Data.HasMore
+	})
+}
+
+func (c *mentionsCollector) more() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hasMore
+}
+
+func (c *mentionsCollector) list() []CommentNotification {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list := make([]CommentNotification, len(c.messages))
+	copy(list, c.messages)
+	return list
+}
+
+// reached 是否已加载到 since 之前的消息或达到数量上限
+func (c *mentionsCollector) reached(since time.Time, limit int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if limit > 0 && len(c.messages) >= limit {
+		return true
+	}
+	if since.IsZero() || len(c.messages) == 0 {
+		return false
+	}
+	last := c.messages[len(c.messages)-1]
+	return !last.CreatedAt().After(since)
+}
@@ -13,15 +13,20 @@ import (
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
 )
 
 // PublishImageContent 发布图文内容
 type PublishImageContent struct {
-	Title        string
-	Content      string
-	Tags         []string
-	ImagePaths   []string
-	ScheduleTime *time.Time // 定时发布时间，nil 表示立即发布
+	Title              string
+	Content            string
+	Tags               []string
+	ImagePaths         []string
+	ScheduleTime       *time.Time               // 定时发布时间，nil 表示立即发布
+	Draft              bool                     // 仅保存草稿，不发布
+	ExistingTopicsOnly bool                     // 只使用能匹配到已有话题的标签
+	Mentions           []string                 // 正文末尾 @提及的用户昵称或小红书号
+	Settings           platform.PublishSettings // 地点、可见范围、合集、原创声明
 }
 
 type PublishAction struct {
@@ -65,15 +70,16 @@ func NewPublishImageAction(page *rod.Page) (*PublishAction, error) {
 	}, nil
 }
 
-func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) error {
+// Publish 上传图片并提交，发布后核对结果；仅保存草稿时返回的结果为 nil
+func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent) (*PublishResult, error) {
 	if len(content.ImagePaths) == 0 {
-		return errors.New("图片不能为空")
+		return nil, errors.New("图片不能为空")
 	}
 
 	page := p.page.Context(ctx)
 
 	if err := uploadImages(page, content.ImagePaths); err != nil {
-		return errors.Wrap(err, "小红书上传图片失败")
+		return nil, errors.Wrap(err, "小红书上传图片失败")
 	}
 
 	tags := content.Tags
@@ -82,13 +88,18 @@ func (p *PublishAction) Publish(ctx context.Context, content PublishImageContent
 		tags = tags[:10]
 	}
 
-	logrus.Infof("发布内容: title=%s, images=%v, tags=%v, schedule=%v", content.Title, len(content.ImagePaths), tags, content.ScheduleTime)
+	content.Tags = tags
 
-	if err := submitPublish(page, content.Title, content.Content, tags, content.ScheduleTime); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	logrus.Infof("发布内容: title=%s, images=%v, tags=%v, mentions=%v, schedule=%v, draft=%v", content.Title, len(content.ImagePaths), tags, content.Mentions, content.ScheduleTime, content.Draft)
+
+	if err := submitPublish(page, content); err != nil {
+		return nil, errors.Wrap(err, "小红书发布失败")
+	}
+	if content.Draft {
+		return nil, nil
 	}
 
-	return nil
+	return verifyPublish(ctx, page, content.Title)
 }
 
 func removePopCover(page *rod.Page) {
@@ -268,12 +279,12 @@ func waitForUploadComplete(page *rod.Page, expectedCount int) error {
 	return errors.Errorf("第%d张图片上传超时(60s)，请检查网络连接和图片大小", expectedCount)
 }
 
-func submitPublish(page *rod.Page, title, content string, tags []string, scheduleTime *time.Time) error {
+func submitPublish(page *rod.Page, content PublishImageContent) error {
 	titleElem, err := page.Element("div.d-input input")
 	if err != nil {
 		return errors.Wrap(err, "查找标题输入框失败")
 	}
-	if err := titleElem.Input(title); err != nil {
+	if err := titleElem.Input(content.Title); err != nil {
 		return errors.Wrap(err, "输入标题失败")
 	}
 
@@ -290,10 +301,13 @@ func submitPublish(page *rod.Page, title, content string, tags []string, schedul
 	if !ok {
 		return errors.New("没有找到内容输入框")
 	}
-	if err := contentElem.Input(content); err != nil {
+	if err := contentElem.Input(content.Content); err != nil {
 		return errors.Wrap(err, "输入正文失败")
 	}
-	if err := inputTags(contentElem, tags); err != nil {
+	if err := inputMentions(contentElem, content.Mentions); err != nil {
+		return err
+	}
+	if err := inputTags(contentElem, content.Tags, content.ExistingTopicsOnly); err != nil {
 		return err
 	}
 
@@ -305,23 +319,29 @@ func submitPublish(page *rod.Page, title, content string, tags []string, schedul
 	}
 	slog.Info("检查正文长度：通过")
 
+	if err := applyPublishSettings(page, content.Settings); err != nil {
+		return err
+	}
+
 	// 处理定时发布
-	if scheduleTime != nil {
-		if err := setSchedulePublish(page, *scheduleTime); err != nil {
+	if content.ScheduleTime != nil {
+		if err := setSchedulePublish(page, *content.ScheduleTime); err != nil {
 			return errors.Wrap(err, "设置定时发布失败")
 		}
-		slog.Info("定时发布设置完成", "schedule_time", scheduleTime.Format("2006-01-02 15:04"))
+		slog.Info("定时发布设置完成", "schedule_time", content.ScheduleTime.Format("2006-01-02 15:04"))
+	}
+
+	if content.Draft {
+		return saveDraft(page)
 	}
 
 	submitButton, err := page.Element(".publish-page-publish-btn button.bg-red")
 	if err != nil {
 		return errors.Wrap(err, "查找发布按钮失败")
 	}
-	if err := submitButton.Click(proto.InputMouseButtonLeft, 1); err != nil {
+	if err := platform.ClickSubmit(page, submitButton, xhsPublishCheck.FeedbackSelector); err != nil {
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
-
-	time.Sleep(3 * time.Second)
 	return nil
 }
 
@@ -403,7 +423,7 @@ func getContentElement(page *rod.Page) (*rod.Element, bool) {
 	return nil, false
 }
 
-func inputTags(contentElem *rod.Element, tags []string) error {
+func inputTags(contentElem *rod.Element, tags []string, existingOnly bool) error {
 	if len(tags) == 0 {
 		return nil
 	}
@@ -433,45 +453,43 @@ func inputTags(contentElem *rod.Element, tags []string) error {
 
 	for _, tag := range tags {
 		tag = strings.TrimLeft(tag, "#")
-		if err := inputTag(contentElem, tag); err != nil {
+		if err := inputTag(contentElem, tag, existingOnly); err != nil {
+			if errors.Is(err, errTopicNotFound) {
+				logrus.Warnf("标签[%s]没有对应的已有话题，已跳过", tag)
+				continue
+			}
 			return errors.Wrapf(err, "输入标签[%s]失败", tag)
 		}
 	}
 	return nil
 }
 
-func inputTag(contentElem *rod.Element, tag string) error {
-	if err := contentElem.Input("#"); err != nil {
-		return errors.Wrap(err, "输入#失败")
+// inputTag 输入单个标签并选择话题联想
+// existingOnly 为 true 时只选择与标签同名的已有话题，找不到时清除输入并返回 errTopicNotFound
+func inputTag(contentElem *rod.Element, tag string, existingOnly bool) error {
+	topics, items, err := suggestTopics(contentElem, tag)
+	if err != nil {
+		return err
 	}
-	time.Sleep(200 * time.Millisecond)
 
-	for _, char := range tag {
-		if err := contentElem.Input(string(char)); err != nil {
-			return errors.Wrapf(err, "输入字符[%c]失败", char)
+	idx := 0
+	if existingOnly {
+		idx = platform.FindTopic(topics, tag)
+		if idx < 0 {
+			clearTyped(contentElem, "#"+tag)
+			return errTopicNotFound
 		}
-		time.Sleep(50 * time.Millisecond)
-	}
-
-	time.Sleep(1 * time.Second)
-
-	page := contentElem.Page()
-	topicContainer, err := page.Element("#creator-editor-topic-container")
-	if err != nil || topicContainer == nil {
-		slog.Warn("未找到标签联想下拉框，直接输入空格", "tag", tag)
-		return contentElem.Input(" ")
 	}
 
-	firstItem, err := topicContainer.Element(".item")
-	if err != nil || firstItem == nil {
+	if len(items) == 0 {
 		slog.Warn("未找到标签联想选项，直接输入空格", "tag", tag)
 		return contentElem.Input(" ")
 	}
 
-	if err := firstItem.Click(proto.InputMouseButtonLeft, 1); err != nil {
+	if err := items[idx].Click(proto.InputMouseButtonLeft, 1); err != nil {
 		return errors.Wrap(err, "点击标签联想选项失败")
 	}
-	slog.Info("成功点击标签联想选项", "tag", tag)
+	slog.Info("成功点击标签联想选项", "tag", tag, "topic", topics[idx].Name)
 	time.Sleep(200 * time.Millisecond)
 
 	time.Sleep(500 * time.Millisecond) // 等待标签处理完成
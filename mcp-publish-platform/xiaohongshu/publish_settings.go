@@ -0,0 +1,159 @@
+package xiaohongshu
+
+import (
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// visibilityLabels 可见范围对应的发布页选项文案
+var visibilityLabels = map[platform.Visibility]string{
+	platform.VisibilityPublic:  "公开可见",
+	platform.VisibilityPrivate: "仅自己可见",
+	platform.VisibilityFriends: "仅互关好友可见",
+}
+
+// applyPublishSettings 依次设置地点、合集、原创声明和可见范围
+// 任一项设置失败都直接返回错误，避免例如要求仅自己可见的笔记被公开发布
+func applyPublishSettings(page *rod.Page, s platform.PublishSettings) error {
+	if s.Location != "" {
+		if err := setLocation(page, s.Location); err != nil {
+			return errors.Wrap(err, "设置地点失败")
+		}
+	}
+	if s.Collection != "" {
+		if err := setCollection(page, s.Collection); err != nil {
+			return errors.Wrap(err, "加入合集失败")
+		}
+	}
+	if s.Original {
+		if err := declareOriginal(page); err != nil {
+			return errors.Wrap(err, "声明原创失败")
+		}
+	}
+	if s.Visibility != "" && s.Visibility != platform.VisibilityPublic {
+		if err := setVisibility(page, s.Visibility); err != nil {
+			return errors.Wrap(err, "设置可见范围失败")
+		}
+	}
+	return nil
+}
+
+// setLocation 在地点选择框中搜索关键词并选中第一个结果
+func setLocation(page *rod.Page, keyword string) error {
+	trigger, err := page.Timeout(5*time.Second).ElementR(".address-input, .d-select-wrapper", "添加地点")
+	if err != nil {
+		return errors.Wrap(err, "未找到添加地点入口")
+	}
+	if err := trigger.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击添加地点失败")
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	searchInput, err := page.Timeout(5 * time.Second).Element(".address-input input, .d-select-wrapper input")
+	if err != nil {
+		return errors.Wrap(err, "未找到地点搜索框")
+	}
+	if err := searchInput.Input(keyword); err != nil {
+		return errors.Wrap(err, "输入地点关键词失败")
+	}
+	// 地点搜索需要请求 POI 接口
+	time.Sleep(2 * time.Second)
+
+	item, err := page.Timeout(5 * time.Second).Element(".d-options-wrapper .item, .d-grid-item .item, .d-options .d-option")
+	if err != nil {
+		return errors.Errorf("未找到地点: %s", keyword)
+	}
+	if err := item.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择地点失败")
+	}
+	slog.Info("已设置地点", "keyword", keyword)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// setCollection 打开合集下拉框并选中同名合集
+func setCollection(page *rod.Page, name string) error {
+	trigger, err := page.Timeout(5*time.Second).ElementR(".collection-container, .d-select-wrapper", "合集")
+	if err != nil {
+		return errors.Wrap(err, "未找到合集入口")
+	}
+	if err := trigger.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击合集入口失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	option, err := page.Timeout(5*time.Second).ElementR(".d-options .d-option, .d-options-wrapper .item", "^"+regexp.QuoteMeta(name)+"$")
+	if err != nil {
+		return errors.Errorf("未找到合集: %s", name)
+	}
+	if err := option.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择合集失败")
+	}
+	slog.Info("已加入合集", "collection", name)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
+
+// declareOriginal 勾选原创声明（已勾选时跳过），并在弹出的协议确认框中点击确认
+func declareOriginal(page *rod.Page) error {
+	checkbox, err := page.Timeout(5*time.Second).ElementR(".original-wrapper, .d-checkbox", "原创")
+	if err != nil {
+		return errors.Wrap(err, "未找到原创声明选项")
+	}
+	checked, err := platform.IsChecked(checkbox)
+	if err != nil {
+		return err
+	}
+	if checked {
+		slog.Info("原创声明已勾选")
+		return nil
+	}
+	if err := checkbox.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击原创声明失败")
+	}
+	time.Sleep(800 * time.Millisecond)
+
+	// 首次声明原创会弹出协议确认框
+	if btn, err := page.Timeout(3*time.Second).ElementR(".d-modal button, .d-dialog button", "声明原创|确认|同意"); err == nil {
+		if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+			return errors.Wrap(err, "确认原创声明失败")
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	slog.Info("已声明原创")
+	return nil
+}
+
+// setVisibility 打开权限设置下拉框并选择可见范围
+func setVisibility(page *rod.Page, visibility platform.Visibility) error {
+	label, ok := visibilityLabels[visibility]
+	if !ok {
+		return errors.Errorf("不支持的可见范围: %s", visibility)
+	}
+
+	trigger, err := page.Timeout(5*time.Second).ElementR(".permission-card-wrapper .d-select-wrapper, .d-select-wrapper", "公开可见")
+	if err != nil {
+		return errors.Wrap(err, "未找到权限设置入口")
+	}
+	if err := trigger.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "点击权限设置失败")
+	}
+	time.Sleep(500 * time.Millisecond)
+
+	option, err := page.Timeout(5*time.Second).ElementR(".d-options .d-option, .d-options-wrapper .item", label)
+	if err != nil {
+		return errors.Errorf("未找到可见范围选项: %s", label)
+	}
+	if err := option.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return errors.Wrap(err, "选择可见范围失败")
+	}
+	slog.Info("已设置可见范围", "visibility", label)
+	time.Sleep(500 * time.Millisecond)
+	return nil
+}
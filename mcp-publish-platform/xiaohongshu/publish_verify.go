@@ -0,0 +1,74 @@
+package xiaohongshu
+
+import (
+	"context"
+
+	"github.com/go-rod/rod"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+// submitFeedbackSelector 发布后可能出现的提示：toast、alert 和错误弹窗
+const submitFeedbackSelector = `.d-toast, [class*="toast"], [role="alert"], .d-modal, [role="dialog"]`
+
+// xhsPublishCheck 小红书发布成功后跳转到发布成功页，笔记状态从创作中心的笔记接口读取
+var xhsPublishCheck = platform.PublishCheck{
+	FeedbackSelector: submitFeedbackSelector,
+	SuccessURL:       "/publish/success",
+}
+
+// PublishResult 提交发布后的结果
+type PublishResult struct {
+	State     platform.PublishState `json:"state"`
+	Message   string                `json:"message,omitempty"`    // 平台的提示或错误文案
+	NoteID    string                `json:"note_id,omitempty"`    // 在笔记列表中找到的新笔记ID
+	XsecToken string                `json:"xsec_token,omitempty"` // 新笔记的 xsec_token
+}
+
+// verifyPublish 点击发布后先观察页面提示，再到创作中心的笔记列表核对新笔记的状态，发布失败时同时返回错误
+func verifyPublish(ctx context.Context, page *rod.Page, title string) (*PublishResult, error) {
+	var notes []CreatorNote
+	check := xhsPublishCheck
+	check.List = func(ctx context.Context, page *rod.Page) ([]platform.ManagedItem, error) {
+		resp, err := NewCreatorNotesAction(page).ListNotes(ctx, CreatorNotesRequest{Page: 1, PageSize: 10})
+		if err != nil {
+			return nil, err
+		}
+		notes = resp.Notes
+		return managedNotes(notes), nil
+	}
+
+	resp, err := check.Verify(ctx, page, "小红书笔记", title)
+	result := &PublishResult{
+		State:   platform.PublishState(resp.Status),
+		Message: resp.Message,
+		NoteID:  resp.FeedID,
+	}
+	if resp.Error != "" {
+		result.Message = resp.Error
+	}
+	for _, note := range notes {
+		if note.ID != "" && note.ID == resp.FeedID {
+			result.XsecToken = note.XsecToken
+			break
+		}
+	}
+	return result, err
+}
+
+// managedNotes 将创作中心的笔记转换为通用的作品卡片，状态使用笔记列表 TAB 的名称
+func managedNotes(notes []CreatorNote) []platform.ManagedItem {
+	items := make([]platform.ManagedItem, 0, len(notes))
+	for _, note := range notes {
+		status := ""
+		if note.Status != CreatorNoteStatusAll {
+			status = creatorNoteTabs[note.Status]
+		}
+		items = append(items, platform.ManagedItem{
+			ID:     note.ID,
+			Title:  note.Title,
+			Status: status,
+			Time:   note.Time,
+		})
+	}
+	return items
+}
@@ -0,0 +1,33 @@
+package xiaohongshu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+func TestManagedNotes(t *testing.T) {
+	now := time.Date(2024, 1, 20, 10, 35, 0, 0, time.Local)
+	items := managedNotes([]CreatorNote{
+		{ID: "new", Title: "周末去哪儿", Time: "2024-01-20 10:30", Status: CreatorNoteStatusReviewing},
+		{ID: "old", Title: "旧笔记", Time: "2024-01-19 10:30", Status: CreatorNoteStatusPublished},
+		{ID: "rejected", Title: "被拒的笔记", Time: "2024-01-20 10:31", Status: CreatorNoteStatusRejected},
+		{ID: "no-time", Title: "刚发布"},
+	})
+
+	assert.Equal(t, "new", platform.FindManagedItem(items, " 周末去哪儿 ", now).ID)
+	assert.Equal(t, "no-time", platform.FindManagedItem(items, "刚发布", now).ID)
+	assert.Nil(t, platform.FindManagedItem(items, "旧笔记", now), "超出时间窗口的同名笔记不是本次发布的")
+	assert.Nil(t, platform.FindManagedItem(items, "不存在", now))
+
+	states := map[string]platform.PublishState{}
+	for _, item := range items {
+		states[item.ID] = platform.ClassifyPublishMessage(item.Status)
+	}
+	assert.Equal(t, platform.PublishStateReviewing, states["new"])
+	assert.Equal(t, platform.PublishStatePublished, states["old"])
+	assert.Equal(t, platform.PublishStateFailed, states["rejected"])
+	assert.Equal(t, platform.PublishStateUnknown, states["no-time"])
+}
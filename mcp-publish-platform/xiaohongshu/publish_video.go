@@ -8,18 +8,25 @@ import (
 	"time"
 
 	"github.com/go-rod/rod"
-	"github.com/go-rod/rod/lib/proto"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
 )
 
 // PublishVideoContent 发布视频内容
 type PublishVideoContent struct {
-	Title        string
-	Content      string
-	Tags         []string
-	VideoPath    string
-	ScheduleTime *time.Time // 定时发布时间，nil 表示立即发布
+	Title              string
+	Content            string
+	Tags               []string
+	VideoPath          string
+	ScheduleTime       *time.Time               // 定时发布时间，nil 表示立即发布
+	Draft              bool                     // 仅保存草稿，不发布
+	ExistingTopicsOnly bool                     // 只使用能匹配到已有话题的标签
+	Mentions           []string                 // 正文末尾 @提及的用户昵称或小红书号
+	CoverPath          string                   // 自定义封面图片路径
+	CoverTime          float64                  // 截取视频第几秒的画面作为封面，CoverPath 为空时生效
+	Settings           platform.PublishSettings // 地点、可见范围、合集、原创声明
+	Progress           platform.ProgressFunc    // 上传和转码进度回调（可选）
 }
 
 // NewPublishVideoAction 进入发布页并切换到"上传视频"
@@ -51,25 +58,32 @@ func NewPublishVideoAction(page *rod.Page) (*PublishAction, error) {
 }
 
 // PublishVideo 上传视频并提交
-func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) error {
+func (p *PublishAction) PublishVideo(ctx context.Context, content PublishVideoContent) (*PublishResult, error) {
 	if content.VideoPath == "" {
-		return errors.New("视频不能为空")
+		return nil, errors.New("视频不能为空")
 	}
 
 	page := p.page.Context(ctx)
 
-	if err := uploadVideo(page, content.VideoPath); err != nil {
-		return errors.Wrap(err, "小红书上传视频失败")
+	if err := uploadVideo(page, content.VideoPath, content.Progress); err != nil {
+		return nil, errors.Wrap(err, "小红书上传视频失败")
 	}
 
-	if err := submitPublishVideo(page, content.Title, content.Content, content.Tags, content.ScheduleTime); err != nil {
-		return errors.Wrap(err, "小红书发布失败")
+	if err := submitPublishVideo(page, content); err != nil {
+		return nil, errors.Wrap(err, "小红书发布失败")
 	}
-	return nil
+	if content.Draft {
+		return nil, nil
+	}
+
+	return verifyPublish(ctx, page, content.Title)
 }
 
-// uploadVideo 上传单个本地视频
-func uploadVideo(page *rod.Page, videoPath string) error {
+// uploadVideoProgressSelector 上传区域中的进度条和进度文案
+const uploadVideoProgressSelector = `[class*="upload"] [class*="progress"], [class*="upload"] [class*="percent"], [class*="uploading"], [class*="transcod"]`
+
+// uploadVideo 上传单个本地视频，上传和转码期间每秒读取页面上的进度
+func uploadVideo(page *rod.Page, videoPath string, progress platform.ProgressFunc) error {
 	pp := page.Timeout(5 * time.Minute) // 视频处理耗时更长
 
 	if _, err := os.Stat(videoPath); os.IsNotExist(err) {
@@ -87,13 +101,21 @@ func uploadVideo(page *rod.Page, videoPath string) error {
 		}
 	}
 
+	tracker := platform.NewProgressTracker(videoPath, progress)
+	tracker.Start()
 	fileInput.MustSetFiles(videoPath)
 
+	stop := tracker.Watch(func() string {
+		return platform.ProgressText(pp, uploadVideoProgressSelector)
+	}, time.Second)
+
 	// 对于视频，等待发布按钮变为可点击即表示处理完成
 	btn, err := waitForPublishButtonClickable(pp)
+	stop()
 	if err != nil {
 		return err
 	}
+	tracker.Done()
 	slog.Info("视频上传/处理完成，发布按钮可点击", "btn", btn)
 	return nil
 }
@@ -129,14 +151,19 @@ func waitForPublishButtonClickable(page *rod.Page) (*rod.Element, error) {
 	return nil, errors.New("等待发布按钮可点击超时")
 }
 
-// submitPublishVideo 填写标题、正文、标签并点击发布（等待按钮可点击后再提交）
-func submitPublishVideo(page *rod.Page, title, content string, tags []string, scheduleTime *time.Time) error {
+// submitPublishVideo 设置封面，填写标题、正文、标签并点击发布（等待按钮可点击后再提交）
+func submitPublishVideo(page *rod.Page, content PublishVideoContent) error {
+	// 封面
+	if err := coverDialog.Set(page, content.CoverPath, content.CoverTime); err != nil {
+		return errors.Wrap(err, "设置封面失败")
+	}
+
 	// 标题
 	titleElem, err := page.Element("div.d-input input")
 	if err != nil {
 		return errors.Wrap(err, "查找标题输入框失败")
 	}
-	if err := titleElem.Input(title); err != nil {
+	if err := titleElem.Input(content.Title); err != nil {
 		return errors.Wrap(err, "输入标题失败")
 	}
 	time.Sleep(1 * time.Second)
@@ -146,34 +173,43 @@ func submitPublishVideo(page *rod.Page, title, content string, tags []string, sc
 	if !ok {
 		return errors.New("没有找到内容输入框")
 	}
-	if err := contentElem.Input(content); err != nil {
+	if err := contentElem.Input(content.Content); err != nil {
 		return errors.Wrap(err, "输入正文失败")
 	}
-	if err := inputTags(contentElem, tags); err != nil {
+	if err := inputMentions(contentElem, content.Mentions); err != nil {
+		return err
+	}
+	if err := inputTags(contentElem, content.Tags, content.ExistingTopicsOnly); err != nil {
 		return err
 	}
 
 	time.Sleep(1 * time.Second)
 
+	if err := applyPublishSettings(page, content.Settings); err != nil {
+		return err
+	}
+
 	// 处理定时发布
-	if scheduleTime != nil {
-		if err := setSchedulePublish(page, *scheduleTime); err != nil {
+	if content.ScheduleTime != nil {
+		if err := setSchedulePublish(page, *content.ScheduleTime); err != nil {
 			return errors.Wrap(err, "设置定时发布失败")
 		}
-		slog.Info("定时发布设置完成", "schedule_time", scheduleTime.Format("2006-01-02 15:04"))
+		slog.Info("定时发布设置完成", "schedule_time", content.ScheduleTime.Format("2006-01-02 15:04"))
 	}
 
-	// 等待发布按钮可点击
+	// 等待发布按钮可点击，视频转码完成前草稿同样无法保存
 	btn, err := waitForPublishButtonClickable(page)
 	if err != nil {
 		return err
 	}
 
+	if content.Draft {
+		return saveDraft(page)
+	}
+
 	// 点击发布
-	if err := btn.Click(proto.InputMouseButtonLeft, 1); err != nil {
+	if err := platform.ClickSubmit(page, btn, xhsPublishCheck.FeedbackSelector); err != nil {
 		return errors.Wrap(err, "点击发布按钮失败")
 	}
-
-	time.Sleep(3 * time.Second)
 	return nil
 }
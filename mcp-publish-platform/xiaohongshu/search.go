@@ -168,6 +168,15 @@ func NewSearchAction(page *rod.Page) *SearchAction {
 func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...FilterOption) ([]Feed, error) {
 	page := s.page.Context(ctx)
 
+	if err := openSearch(page, keyword, filters); err != nil {
+		return nil, err
+	}
+
+	return readSearchFeeds(page)
+}
+
+// openSearch 打开搜索结果页并应用筛选条件
+func openSearch(page *rod.Page, keyword string, filters []FilterOption) error {
 	searchURL := makeSearchURL(keyword)
 	page.MustNavigate(searchURL)
 	page.MustWaitStable()
@@ -181,7 +190,7 @@ func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...Fi
 		for _, filter := range filters {
 			internalFilters, err := convertToInternalFilters(filter)
 			if err != nil {
-				return nil, fmt.Errorf("筛选选项转换失败: %w", err)
+				return fmt.Errorf("筛选选项转换失败: %w", err)
 			}
 			allInternalFilters = append(allInternalFilters, internalFilters...)
 		}
@@ -189,7 +198,7 @@ func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...Fi
 		// 验证所有内部筛选选项
 		for _, filter := range allInternalFilters {
 			if err := validateInternalFilterOption(filter); err != nil {
-				return nil, fmt.Errorf("筛选选项验证失败: %w", err)
+				return fmt.Errorf("筛选选项验证失败: %w", err)
 			}
 		}
 
@@ -213,7 +222,11 @@ func (s *SearchAction) Search(ctx context.Context, keyword string, filters ...Fi
 		// 重新等待 __INITIAL_STATE__ 更新
 		page.MustWait(`() => window.__INITIAL_STATE__ !== undefined`)
 	}
+	return nil
+}
 
+// readSearchFeeds 读取页面状态中已加载的搜索结果，滚动加载的结果会追加在后面
+func readSearchFeeds(page *rod.Page) ([]Feed, error) {
 	result := page.MustEval(`() => {
 		if (window.__INITIAL_STATE__ &&
 		    window.__INITIAL_STATE__.search &&
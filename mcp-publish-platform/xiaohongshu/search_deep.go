@@ -0,0 +1,193 @@
+package xiaohongshu
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/errors"
+)
+
+const (
+	defaultSearchResults = 20
+	maxSearchResults     = 200
+	// maxSearchDepth 滚动能加载到的结果上限，超过后小红书基本不再返回新结果
+	maxSearchDepth = 500
+
+	searchStagnantLimit = 4
+	searchPollTimeout   = 4 * time.Second
+	deepSearchTimeout   = 5 * time.Minute
+)
+
+// DeepSearchOptions 深度搜索参数
+type DeepSearchOptions struct {
+	Filters []FilterOption
+	// MaxResults 本次返回的结果数，默认 20，最多 200
+	MaxResults int
+	// Page 页码，从 1 开始，每页 MaxResults 条
+	Page int
+	// Cursor 上一次返回的游标，提供时忽略 Page
+	Cursor string
+}
+
+// SearchPage 一批搜索结果
+type SearchPage struct {
+	Feeds   []Feed `json:"feeds"`
+	Count   int    `json:"count"`
+	Offset  int    `json:"offset"`           // 本批第一条在全部结果中的位置
+	Cursor  string `json:"cursor,omitempty"` // 继续获取下一批的游标，没有更多结果时为空
+	HasMore bool   `json:"has_more"`
+}
+
+// searchCursor 游标内容，页面只能从头滚动加载，游标记录已返回的结果数
+type searchCursor struct {
+	Keyword string         `json:"k"`
+	Filters []FilterOption `json:"f,omitempty"`
+	Offset  int            `json:"o"`
+}
+
+func encodeSearchCursor(c searchCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSearchCursor(s string) (*searchCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("无效的搜索游标: %w", err)
+	}
+	var c searchCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("无效的搜索游标: %w", err)
+	}
+	if c.Offset < 0 {
+		return nil, fmt.Errorf("无效的搜索游标: offset=%d", c.Offset)
+	}
+	return &c, nil
+}
+
+// DeepSearch 滚动搜索结果列表，累积去重后的笔记直到满足本批数量
+// 通过 Page 或上一次返回的 Cursor 继续获取后面的结果
+func (s *SearchAction) DeepSearch(ctx context.Context, keyword string, opts DeepSearchOptions) (*SearchPage, error) {
+	if opts.MaxResults <= 0 {
+		opts.MaxResults = defaultSearchResults
+	}
+	if opts.MaxResults > maxSearchResults {
+		opts.MaxResults = maxSearchResults
+	}
+
+	offset := 0
+	switch {
+	case opts.Cursor != "":
+		cursor, err := decodeSearchCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if cursor.Keyword != keyword {
+			return nil, fmt.Errorf("搜索游标属于关键词 %q，与当前关键词 %q 不一致", cursor.Keyword, keyword)
+		}
+		// 游标中保存了筛选条件，继续翻页时沿用
+		opts.Filters = cursor.Filters
+		offset = cursor.Offset
+	case opts.Page > 1:
+		offset = (opts.Page - 1) * opts.MaxResults
+	}
+	if offset >= maxSearchDepth {
+		return nil, fmt.Errorf("搜索结果最多加载 %d 条，当前位置 %d 已超出", maxSearchDepth, offset)
+	}
+
+	target := offset + opts.MaxResults
+	if target > maxSearchDepth {
+		target = maxSearchDepth
+	}
+
+	page := s.page.Context(ctx).Timeout(deepSearchTimeout)
+	if err := openSearch(page, keyword, opts.Filters); err != nil {
+		return nil, err
+	}
+
+	var (
+		feeds     []Feed
+		seen      = make(map[string]bool)
+		stagnant  int
+		exhausted bool
+	)
+	collect := func() int {
+		list, err := readSearchFeeds(page)
+		if err != nil {
+			return 0
+		}
+		added := 0
+		for _, f := range list {
+			// 搜索结果中混有相关搜索词等非笔记卡片
+			if f.ID == "" || seen[f.ID] || (f.ModelType != "" && f.ModelType != "note") {
+				continue
+			}
+			seen[f.ID] = true
+			feeds = append(feeds, f)
+			added++
+		}
+		return added
+	}
+
+	collect()
+	// 多取一条用于判断是否还有更多结果
+	for len(feeds) <= target {
+		humanScroll(page, "normal", stagnant >= 2, 1+stagnant)
+		if s.waitSearchGrowth(ctx, collect) {
+			stagnant = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		stagnant++
+		if stagnant >= searchStagnantLimit {
+			logrus.Infof("搜索结果已到底: keyword=%s, 共 %d 条", keyword, len(feeds))
+			exhausted = true
+			break
+		}
+	}
+
+	if len(feeds) == 0 {
+		return nil, errors.ErrNoFeeds
+	}
+
+	result := &SearchPage{Feeds: make([]Feed, 0), Offset: offset}
+	if offset < len(feeds) {
+		end := min(target, len(feeds))
+		result.Feeds = feeds[offset:end]
+	}
+	result.Count = len(result.Feeds)
+	result.HasMore = !exhausted && offset+result.Count < maxSearchDepth
+	if result.HasMore {
+		result.Cursor = encodeSearchCursor(searchCursor{
+			Keyword: keyword,
+			Filters: opts.Filters,
+			Offset:  offset + result.Count,
+		})
+	}
+
+	logrus.Infof("深度搜索完成: keyword=%s, offset=%d, 返回 %d 条, 已加载 %d 条, has_more=%v",
+		keyword, offset, result.Count, len(feeds), result.HasMore)
+	return result, nil
+}
+
+// waitSearchGrowth 滚动后等待新结果写入页面状态
+func (s *SearchAction) waitSearchGrowth(ctx context.Context, collect func() int) bool {
+	deadline := time.Now().Add(searchPollTimeout)
+	for time.Now().Before(deadline) {
+		if collect() > 0 {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+	return false
+}
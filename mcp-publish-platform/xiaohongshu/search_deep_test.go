@@ -0,0 +1,26 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	cursor := encodeSearchCursor(searchCursor{
+		Keyword: "露营",
+		Filters: []FilterOption{{SortBy: "最新"}},
+		Offset:  40,
+	})
+
+	got, err := decodeSearchCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, "露营", got.Keyword)
+	assert.Equal(t, 40, got.Offset)
+	require.Len(t, got.Filters, 1)
+	assert.Equal(t, "最新", got.Filters[0].SortBy)
+
+	_, err = decodeSearchCursor("not-a-cursor!")
+	assert.Error(t, err)
+}
@@ -0,0 +1,200 @@
+package xiaohongshu
+
+import (
+	"context"
+	"image"
+	"image/draw"
+	"image/png"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+	"github.com/xpzouying/xiaohongshu-mcp/pkg/xhsutil"
+)
+
+const topicItemSelector = "#creator-editor-topic-container .item"
+
+// errTopicNotFound 话题联想中没有与标签同名的话题
+var errTopicNotFound = errors.New("话题不存在")
+
+// TopicAction 话题查询
+type TopicAction struct {
+	page *rod.Page
+}
+
+func NewTopicAction(page *rod.Page) *TopicAction {
+	pp := page.Timeout(60 * time.Second)
+	return &TopicAction{page: pp}
+}
+
+// SearchTopics 在发布页编辑器中输入 #关键词，读取话题联想下拉框中的候选
+// 图文发布页需要先上传图片才出现编辑器，这里上传一张临时占位图；图文页只有点击"暂存离开"才会保存草稿，
+// 查询完成后清除输入，不会留下草稿。不使用"写长文"，长文编辑器会自动保存草稿
+func (a *TopicAction) SearchTopics(ctx context.Context, keyword string) ([]platform.Topic, error) {
+	keyword = strings.TrimSpace(strings.TrimLeft(keyword, "#"))
+	if keyword == "" {
+		return nil, errors.New("关键词不能为空")
+	}
+
+	page := a.page.Context(ctx)
+
+	contentElem, err := openTopicEditor(page)
+	if err != nil {
+		return nil, err
+	}
+
+	topics, _, err := suggestTopics(contentElem, keyword)
+	clearTyped(contentElem, "#"+keyword)
+	if err != nil {
+		return nil, err
+	}
+
+	logrus.Infof("话题联想 %q 返回 %d 个候选", keyword, len(topics))
+	return topics, nil
+}
+
+// openTopicEditor 打开"上传图文"页，上传临时占位图后返回正文编辑器
+func openTopicEditor(page *rod.Page) (*rod.Element, error) {
+	if err := page.Navigate(urlOfPublic); err != nil {
+		return nil, errors.Wrap(err, "导航到发布页面失败")
+	}
+	if err := page.WaitLoad(); err != nil {
+		logrus.Warnf("等待页面加载出现问题: %v，继续尝试", err)
+	}
+	time.Sleep(2 * time.Second)
+
+	if err := mustClickPublishTab(page, "上传图文"); err != nil {
+		return nil, errors.Wrap(err, "切换到上传图文失败")
+	}
+	time.Sleep(1 * time.Second)
+
+	placeholder, err := writePlaceholderImage()
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(placeholder)
+
+	if err := uploadImages(page, []string{placeholder}); err != nil {
+		return nil, errors.Wrap(err, "上传占位图失败")
+	}
+
+	editor, ok := getContentElement(page)
+	if !ok {
+		return nil, errors.New("未找到正文编辑器")
+	}
+	if err := editor.Click(proto.InputMouseButtonLeft, 1); err != nil {
+		return nil, errors.Wrap(err, "点击正文编辑器失败")
+	}
+	return editor, nil
+}
+
+// writePlaceholderImage 生成一张纯白占位图，调用方负责删除
+func writePlaceholderImage() (string, error) {
+	f, err := os.CreateTemp("", "xhs-topic-*.png")
+	if err != nil {
+		return "", errors.Wrap(err, "创建占位图失败")
+	}
+	defer f.Close()
+
+	img := image.NewGray(image.Rect(0, 0, 300, 300))
+	draw.Draw(img, img.Bounds(), image.White, image.Point{}, draw.Src)
+	if err := png.Encode(f, img); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Wrap(err, "写入占位图失败")
+	}
+	return f.Name(), nil
+}
+
+// suggestTopics 在编辑器中输入 #关键词 并读取联想下拉框，返回候选及对应的可点击元素
+// 下拉框未出现时返回空列表
+func suggestTopics(contentElem *rod.Element, keyword string) ([]platform.Topic, []*rod.Element, error) {
+	if err := typeTrigger(contentElem, "#", keyword); err != nil {
+		return nil, nil, err
+	}
+
+	time.Sleep(1 * time.Second)
+
+	page := contentElem.Page()
+	has, _, err := page.Has(topicItemSelector)
+	if err != nil || !has {
+		return nil, nil, nil
+	}
+	items, err := page.Elements(topicItemSelector)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "读取话题联想失败")
+	}
+
+	topics := make([]platform.Topic, 0, len(items))
+	for _, item := range items {
+		text, err := item.Text()
+		if err != nil {
+			text = ""
+		}
+		topics = append(topics, parseTopicText(text))
+	}
+	return topics, items, nil
+}
+
+// typeTrigger 先输入触发符（# 或 @）再逐字输入关键词，逐字输入才能触发编辑器的联想请求
+func typeTrigger(elem *rod.Element, trigger, keyword string) error {
+	if err := elem.Input(trigger); err != nil {
+		return errors.Wrapf(err, "输入%s失败", trigger)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	for _, char := range keyword {
+		if err := elem.Input(string(char)); err != nil {
+			return errors.Wrapf(err, "输入字符[%c]失败", char)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil
+}
+
+// clearTyped 用退格删除刚输入的文本，typed 需包含触发符及其前面额外输入的空格
+func clearTyped(elem *rod.Element, typed string) {
+	ka, err := elem.KeyActions()
+	if err != nil {
+		logrus.Warnf("清除输入失败: %v", err)
+		return
+	}
+	for i := 0; i < utf8.RuneCountInString(typed); i++ {
+		ka = ka.Type(input.Backspace)
+	}
+	if err := ka.Do(); err != nil {
+		logrus.Warnf("清除输入失败: %v", err)
+	}
+}
+
+// parseTopicText 解析联想候选文本，如 "#美食\n12.3亿次浏览"
+func parseTopicText(text string) platform.Topic {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return platform.Topic{}
+	}
+
+	name, views := lines[0], ""
+	if len(lines) > 1 {
+		views = lines[len(lines)-1]
+	} else if i := strings.LastIndex(name, " "); i > 0 && strings.Contains(name[i:], "浏览") {
+		name, views = name[:i], strings.TrimSpace(name[i:])
+	}
+
+	return platform.Topic{
+		Name:      strings.TrimSpace(strings.TrimLeft(name, "#")),
+		Views:     xhsutil.ExtractCount(views),
+		ViewsText: views,
+	}
+}
@@ -0,0 +1,24 @@
+package xiaohongshu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xpzouying/xiaohongshu-mcp/internal/platform"
+)
+
+func TestParseTopicText(t *testing.T) {
+	tests := []struct {
+		in   string
+		want platform.Topic
+	}{
+		{"#美食\n12.3亿次浏览", platform.Topic{Name: "美食", Views: 1230000000, ViewsText: "12.3亿次浏览"}},
+		{"  #旅行攻略 \n\n 856万次浏览 ", platform.Topic{Name: "旅行攻略", Views: 8560000, ViewsText: "856万次浏览"}},
+		{"#穿搭 3.4万次浏览", platform.Topic{Name: "穿搭", Views: 34000, ViewsText: "3.4万次浏览"}},
+		{"#新话题", platform.Topic{Name: "新话题"}},
+		{"", platform.Topic{}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, parseTopicText(tt.in), tt.in)
+	}
+}
@@ -103,15 +103,50 @@ type FeedDetail struct {
 	User         User              `json:"user"`
 	InteractInfo InteractInfo      `json:"interactInfo"`
 	ImageList    []DetailImageInfo `json:"imageList"`
+	Video        *DetailVideo      `json:"video,omitempty"` // 视频笔记才有
 }
 
 // DetailImageInfo 表示详情页的图片信息
 type DetailImageInfo struct {
-	Width      int    `json:"width"`
-	Height     int    `json:"height"`
-	URLDefault string `json:"urlDefault"`
-	URLPre     string `json:"urlPre"`
-	LivePhoto  bool   `json:"livePhoto,omitempty"`
+	Width      int          `json:"width"`
+	Height     int          `json:"height"`
+	URLDefault string       `json:"urlDefault"`
+	URLPre     string       `json:"urlPre"`
+	LivePhoto  bool         `json:"livePhoto,omitempty"`
+	InfoList   []ImageInfo  `json:"infoList,omitempty"`
+	Stream     *MediaStream `json:"stream,omitempty"` // 实况图的视频流
+}
+
+// DetailVideo 表示详情页的视频信息
+type DetailVideo struct {
+	Media    VideoMedia      `json:"media"`
+	Consumer VideoConsumer   `json:"consumer"`
+	Capa     VideoCapability `json:"capa"`
+}
+
+// VideoMedia 表示视频的媒体流
+type VideoMedia struct {
+	Stream MediaStream `json:"stream"`
+}
+
+// VideoConsumer 表示视频的原始文件信息
+type VideoConsumer struct {
+	OriginVideoKey string `json:"originVideoKey"`
+}
+
+// MediaStream 表示按编码分组的视频流
+type MediaStream struct {
+	H264 []StreamInfo `json:"h264"`
+	H265 []StreamInfo `json:"h265"`
+	AV1  []StreamInfo `json:"av1"`
+}
+
+// StreamInfo 表示单个视频流
+type StreamInfo struct {
+	MasterURL  string   `json:"masterUrl"`
+	BackupURLs []string `json:"backupUrls"`
+	Width      int      `json:"width"`
+	Height     int      `json:"height"`
 }
 
 // CommentList 表示评论列表
@@ -0,0 +1,263 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/monkeycode/publisher-core/ai/provider"
+	bolt "go.etcd.io/bbolt"
+)
+
+// CacheBackend 响应缓存存储接口
+type CacheBackend interface {
+	Get(key string) (*CacheEntry, error) // 未命中时返回 nil, nil
+	Set(key string, entry *CacheEntry) error
+	Delete(key string) error
+	Close() error
+}
+
+// CacheEntry 缓存条目
+type CacheEntry struct {
+	Result    *provider.GenerateResult `json:"result"`
+	CreatedAt time.Time                `json:"created_at"`
+	ExpiresAt time.Time                `json:"expires_at"`
+}
+
+// CacheStats 缓存命中统计
+type CacheStats struct {
+	Hits     int64   `json:"hits"`
+	Misses   int64   `json:"misses"`
+	Bypassed int64   `json:"bypassed"`
+	HitRate  float64 `json:"hit_rate"`
+}
+
+// ResponseCache 确定性请求的响应缓存
+type ResponseCache struct {
+	backend CacheBackend
+	ttl     time.Duration
+
+	hits     atomic.Int64
+	misses   atomic.Int64
+	bypassed atomic.Int64
+}
+
+// NewResponseCache 创建响应缓存，ttl 为 0 时永不过期
+func NewResponseCache(backend CacheBackend, ttl time.Duration) *ResponseCache {
+	return &ResponseCache{
+		backend: backend,
+		ttl:     ttl,
+	}
+}
+
+// Cacheable 判断请求是否可缓存：温度为 0 或调用方显式开启，且未要求绕过
+// 温度为 0 时服务商使用其默认温度，需要每次重新生成的调用方应设置 NoCache
+func Cacheable(opts *provider.GenerateOptions) bool {
+	if opts.NoCache {
+		return false
+	}
+	return opts.Temperature == 0 || opts.Cache
+}
+
+// CacheKey 由服务商、模型、消息和采样参数计算缓存键
+func CacheKey(pt provider.ProviderType, opts *provider.GenerateOptions) string {
+	keyData := struct {
+		Provider    provider.ProviderType `json:"provider"`
+		Model       string                `json:"model"`
+		Messages    []provider.Message    `json:"messages"`
+		MaxTokens   int                   `json:"max_tokens"`
+		Temperature float64               `json:"temperature"`
+		TopP        float64               `json:"top_p"`
+		Stop        []string              `json:"stop"`
+	}{
+		Provider:    pt,
+		Model:       opts.Model,
+		Messages:    opts.Messages,
+		MaxTokens:   opts.MaxTokens,
+		Temperature: opts.Temperature,
+		TopP:        opts.TopP,
+		Stop:        opts.Stop,
+	}
+
+	data, _ := json.Marshal(keyData)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Get 查询缓存，过期条目视为未命中并删除
+func (c *ResponseCache) Get(key string) (*provider.GenerateResult, bool) {
+	entry, err := c.backend.Get(key)
+	if err != nil || entry == nil || entry.Result == nil {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	if !entry.ExpiresAt.IsZero() && time.Now().After(entry.ExpiresAt) {
+		_ = c.backend.Delete(key)
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.hits.Add(1)
+	result := *entry.Result
+	result.Cached = true
+	return &result, true
+}
+
+// Set 写入缓存
+func (c *ResponseCache) Set(key string, result *provider.GenerateResult) error {
+	entry := &CacheEntry{
+		Result:    result,
+		CreatedAt: time.Now(),
+	}
+	if c.ttl > 0 {
+		entry.ExpiresAt = entry.CreatedAt.Add(c.ttl)
+	}
+	return c.backend.Set(key, entry)
+}
+
+// Stats 返回命中统计
+func (c *ResponseCache) Stats() CacheStats {
+	stats := CacheStats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Bypassed: c.bypassed.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// Close 关闭底层存储
+func (c *ResponseCache) Close() error {
+	return c.backend.Close()
+}
+
+// FileCacheBackend 本地文件缓存实现，每个键一个 JSON 文件
+type FileCacheBackend struct {
+	dataDir string
+}
+
+func NewFileCacheBackend(dataDir string) (*FileCacheBackend, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileCacheBackend{dataDir: dataDir}, nil
+}
+
+func (b *FileCacheBackend) path(key string) string {
+	// 按前两位分目录，避免单目录文件过多
+	return filepath.Join(b.dataDir, key[:2], key+".json")
+}
+
+func (b *FileCacheBackend) Get(key string) (*CacheEntry, error) {
+	data, err := os.ReadFile(b.path(key))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (b *FileCacheBackend) Set(key string, entry *CacheEntry) error {
+	path := b.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func (b *FileCacheBackend) Delete(key string) error {
+	err := os.Remove(b.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (b *FileCacheBackend) Close() error {
+	return nil
+}
+
+var boltCacheBucket = []byte("ai_responses")
+
+// BoltCacheBackend BoltDB 缓存实现
+type BoltCacheBackend struct {
+	db *bolt.DB
+}
+
+func NewBoltCacheBackend(path string) (*BoltCacheBackend, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open bolt db: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(boltCacheBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create bucket: %w", err)
+	}
+
+	return &BoltCacheBackend{db: db}, nil
+}
+
+func (b *BoltCacheBackend) Get(key string) (*CacheEntry, error) {
+	var entry *CacheEntry
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltCacheBucket).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		entry = &CacheEntry{}
+		return json.Unmarshal(data, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (b *BoltCacheBackend) Set(key string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltCacheBucket).Put([]byte(key), data)
+	})
+}
+
+func (b *BoltCacheBackend) Delete(key string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltCacheBucket).Delete([]byte(key))
+	})
+}
+
+func (b *BoltCacheBackend) Close() error {
+	return b.db.Close()
+}
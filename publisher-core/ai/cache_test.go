@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"testing"
+	"time"
+
+	"github.com/monkeycode/publisher-core/ai/provider"
+)
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		name string
+		opts provider.GenerateOptions
+		want bool
+	}{
+		{name: "温度为 0", opts: provider.GenerateOptions{}, want: true},
+		{name: "温度为 0 且绕过", opts: provider.GenerateOptions{NoCache: true}, want: false},
+		{name: "温度非 0", opts: provider.GenerateOptions{Temperature: 0.7}, want: false},
+		{name: "显式开启", opts: provider.GenerateOptions{Cache: true}, want: true},
+		{name: "显式开启且温度非 0", opts: provider.GenerateOptions{Cache: true, Temperature: 0.7}, want: true},
+		{name: "强制绕过", opts: provider.GenerateOptions{Cache: true, NoCache: true}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Cacheable(&tt.opts); got != tt.want {
+				t.Errorf("Cacheable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResponseCache(t *testing.T) {
+	backend, err := NewFileCacheBackend(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	cache := NewResponseCache(backend, time.Hour)
+
+	opts := &provider.GenerateOptions{Model: "m", Messages: []provider.Message{{Role: "user", Content: "hi"}}, Cache: true}
+	key := CacheKey(provider.ProviderDeepSeek, opts)
+
+	if _, ok := cache.Get(key); ok {
+		t.Fatal("空缓存不应命中")
+	}
+	if err := cache.Set(key, &provider.GenerateResult{Content: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	result, ok := cache.Get(key)
+	if !ok || result.Content != "hello" || !result.Cached {
+		t.Fatalf("缓存未命中或结果错误: %+v", result)
+	}
+
+	stats := cache.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("统计错误: %+v", stats)
+	}
+}
@@ -3,8 +3,11 @@ package ai
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
@@ -55,25 +58,38 @@ type HistoryStorage interface {
 
 // HistoryFilter 历史过滤器
 type HistoryFilter struct {
-	Platform string
-	Type     string
+	Platform  string
+	Type      string
+	Query     string // 在标题和正文中全文搜索
 	StartDate *time.Time
 	EndDate   *time.Time
-	Limit    int
-	Offset   int
+	SortBy    string // created_at（默认）、rating、tokens
+	SortAsc   bool   // 默认按降序排列
+	Limit     int
+	Offset    int
 }
 
+// 历史记录排序字段
+const (
+	HistorySortCreatedAt = "created_at"
+	HistorySortRating    = "rating"
+	HistorySortTokens    = "tokens"
+)
+
 // HistoryStats 历史统计
 type HistoryStats struct {
-	TotalGenerated   int            `json:"total_generated"`
-	TotalPublished   int            `json:"total_published"`
-	TotalTokens      TokenUsage     `json:"total_tokens"`
-	AvgRating        float64        `json:"avg_rating"`
-	PlatformStats    map[string]int `json:"platform_stats"`
-	TypeStats        map[string]int `json:"type_stats"`
-	TopModels        []ModelUsage   `json:"top_models"`
+	TotalGenerated int            `json:"total_generated"`
+	TotalPublished int            `json:"total_published"`
+	TotalTokens    TokenUsage     `json:"total_tokens"`
+	AvgRating      float64        `json:"avg_rating"`
+	PlatformStats  map[string]int `json:"platform_stats"`
+	TypeStats      map[string]int `json:"type_stats"`
+	TopModels      []ModelUsage   `json:"top_models"`
 }
 
+// topModelsLimit 统计中返回的模型数量上限
+const topModelsLimit = 10
+
 // ModelUsage 模型使用统计
 type ModelUsage struct {
 	Model     string `json:"model"`
@@ -243,17 +259,7 @@ func (s *JSONHistoryStorage) List(filter HistoryFilter) ([]*ContentHistory, erro
 				continue
 			}
 
-			// 应用过滤条件
-			if filter.Platform != "" && h.Platform != filter.Platform {
-				continue
-			}
-			if filter.Type != "" && h.Type != filter.Type {
-				continue
-			}
-			if filter.StartDate != nil && h.CreatedAt.Before(*filter.StartDate) {
-				continue
-			}
-			if filter.EndDate != nil && h.CreatedAt.After(*filter.EndDate) {
+			if !filter.match(&h) {
 				continue
 			}
 
@@ -261,8 +267,13 @@ func (s *JSONHistoryStorage) List(filter HistoryFilter) ([]*ContentHistory, erro
 		}
 	}
 
+	sortHistories(histories, filter.SortBy, filter.SortAsc)
+
 	// 应用分页
-	if filter.Offset > 0 && filter.Offset < len(histories) {
+	if filter.Offset > 0 {
+		if filter.Offset >= len(histories) {
+			return []*ContentHistory{}, nil
+		}
 		histories = histories[filter.Offset:]
 	}
 	if filter.Limit > 0 && filter.Limit < len(histories) {
@@ -285,14 +296,10 @@ func (s *JSONHistoryStorage) Delete(id string) error {
 }
 
 func (s *JSONHistoryStorage) GetStats(platform string, days int) (*HistoryStats, error) {
-	endDate := time.Now()
-	startDate := endDate.AddDate(0, 0, -days)
-
-	filter := HistoryFilter{
-		Platform:  platform,
-		StartDate: &startDate,
-		EndDate:   &endDate,
-		Limit:     1000,
+	filter := HistoryFilter{Platform: platform}
+	if days > 0 {
+		startDate := time.Now().AddDate(0, 0, -days)
+		filter.StartDate = &startDate
 	}
 
 	histories, err := s.List(filter)
@@ -300,11 +307,65 @@ func (s *JSONHistoryStorage) GetStats(platform string, days int) (*HistoryStats,
 		return nil, err
 	}
 
+	return buildHistoryStats(histories), nil
+}
+
+func (f HistoryFilter) match(h *ContentHistory) bool {
+	if f.Platform != "" && h.Platform != f.Platform {
+		return false
+	}
+	if f.Type != "" && h.Type != f.Type {
+		return false
+	}
+	if f.StartDate != nil && h.CreatedAt.Before(*f.StartDate) {
+		return false
+	}
+	if f.EndDate != nil && h.CreatedAt.After(*f.EndDate) {
+		return false
+	}
+	if f.Query != "" && !strings.Contains(h.Title, f.Query) && !strings.Contains(h.Content, f.Query) {
+		return false
+	}
+	return true
+}
+
+func sortHistories(histories []*ContentHistory, sortBy string, asc bool) {
+	less := func(a, b *ContentHistory) bool {
+		switch sortBy {
+		case HistorySortRating:
+			if a.Rating != b.Rating {
+				return a.Rating < b.Rating
+			}
+		case HistorySortTokens:
+			if a.Tokens.Total != b.Tokens.Total {
+				return a.Tokens.Total < b.Tokens.Total
+			}
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+
+	sort.SliceStable(histories, func(i, j int) bool {
+		if asc {
+			return less(histories[i], histories[j])
+		}
+		return less(histories[j], histories[i])
+	})
+}
+
+// buildHistoryStats 汇总统计，平均评分只计算已评分的记录
+func buildHistoryStats(histories []*ContentHistory) *HistoryStats {
 	stats := &HistoryStats{
 		PlatformStats: make(map[string]int),
 		TypeStats:     make(map[string]int),
+		TopModels:     make([]ModelUsage, 0),
 	}
 
+	type modelAgg struct {
+		count, rated, ratingSum int
+	}
+	models := make(map[string]*modelAgg)
+	rated, ratingSum := 0, 0
+
 	for _, h := range histories {
 		stats.TotalGenerated++
 		if h.PublishedAt != nil {
@@ -313,15 +374,44 @@ func (s *JSONHistoryStorage) GetStats(platform string, days int) (*HistoryStats,
 		stats.TotalTokens.Input += h.Tokens.Input
 		stats.TotalTokens.Output += h.Tokens.Output
 		stats.TotalTokens.Total += h.Tokens.Total
-		stats.Rating += h.Rating
 
 		stats.PlatformStats[h.Platform]++
 		stats.TypeStats[h.Type]++
+
+		m, ok := models[h.Model]
+		if !ok {
+			m = &modelAgg{}
+			models[h.Model] = m
+		}
+		m.count++
+		if h.Rating > 0 {
+			rated++
+			ratingSum += h.Rating
+			m.rated++
+			m.ratingSum += h.Rating
+		}
 	}
 
-	if stats.TotalGenerated > 0 {
-		stats.AvgRating = float64(stats.Rating) / float64(stats.TotalGenerated)
+	if rated > 0 {
+		stats.AvgRating = float64(ratingSum) / float64(rated)
+	}
+
+	for model, m := range models {
+		usage := ModelUsage{Model: model, Count: m.count}
+		if m.rated > 0 {
+			usage.AvgRating = int(math.Round(float64(m.ratingSum) / float64(m.rated)))
+		}
+		stats.TopModels = append(stats.TopModels, usage)
+	}
+	sort.Slice(stats.TopModels, func(i, j int) bool {
+		if stats.TopModels[i].Count != stats.TopModels[j].Count {
+			return stats.TopModels[i].Count > stats.TopModels[j].Count
+		}
+		return stats.TopModels[i].Model < stats.TopModels[j].Model
+	})
+	if len(stats.TopModels) > topModelsLimit {
+		stats.TopModels = stats.TopModels[:topModelsLimit]
 	}
 
-	return stats, nil
+	return stats
 }
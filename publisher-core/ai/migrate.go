@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"fmt"
+	"os"
+)
+
+// MigrationResult JSON 目录迁移结果
+type MigrationResult struct {
+	Histories int `json:"histories"`
+	Templates int `json:"templates"`
+	Failed    int `json:"failed"`
+}
+
+// MigrateJSONStorage 将 JSON 目录布局（历史按日期分目录、模板平铺）导入到目标存储。
+// 目标存储按 ID 覆盖写入，重复执行是安全的；目录不存在时跳过
+func MigrateJSONStorage(historyDir, templateDir string, histories HistoryStorage, templates TemplateStorage) (*MigrationResult, error) {
+	result := &MigrationResult{}
+
+	if historyDir != "" && histories != nil && dirExists(historyDir) {
+		src, err := NewJSONHistoryStorage(historyDir)
+		if err != nil {
+			return result, fmt.Errorf("open history dir: %w", err)
+		}
+		items, err := src.List(HistoryFilter{SortAsc: true})
+		if err != nil {
+			return result, fmt.Errorf("read history: %w", err)
+		}
+		for _, h := range items {
+			if err := histories.Save(h); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Histories++
+		}
+	}
+
+	if templateDir != "" && templates != nil && dirExists(templateDir) {
+		src, err := NewJSONTemplateStorage(templateDir)
+		if err != nil {
+			return result, fmt.Errorf("open template dir: %w", err)
+		}
+		items, err := src.List(TemplateFilter{})
+		if err != nil {
+			return result, fmt.Errorf("read templates: %w", err)
+		}
+		for _, t := range items {
+			if err := templates.Save(t); err != nil {
+				result.Failed++
+				continue
+			}
+			result.Templates++
+		}
+	}
+
+	return result, nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,65 @@
+package ai
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMigrateJSONStorage(t *testing.T) {
+	dir := t.TempDir()
+	historyDir := filepath.Join(dir, "history")
+	templateDir := filepath.Join(dir, "templates")
+
+	jsonHistories, err := NewJSONHistoryStorage(historyDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seedHistories(t, jsonHistories, time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local))
+
+	jsonTemplates, err := NewJSONTemplateStorage(templateDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range []string{"t1", "t2"} {
+		if err := jsonTemplates.Save(&ContentTemplate{ID: id, Platform: "xiaohongshu", UpdatedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db := openTestSQLite(t)
+	histories := NewSQLiteHistoryStorage(db)
+	templates := NewSQLiteTemplateStorage(db)
+
+	// 重复执行不会产生重复记录
+	for run := 1; run <= 2; run++ {
+		result, err := MigrateJSONStorage(historyDir, templateDir, histories, templates)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Histories != 5 || result.Templates != 2 || result.Failed != 0 {
+			t.Fatalf("run %d: result = %+v", run, result)
+		}
+
+		stored, err := histories.List(HistoryFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids := historyIDs(stored); len(ids) != 5 || ids[0] != "h5" {
+			t.Fatalf("run %d: migrated histories = %v", run, ids)
+		}
+		storedTemplates, err := templates.List(TemplateFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(storedTemplates) != 2 {
+			t.Fatalf("run %d: migrated templates = %d", run, len(storedTemplates))
+		}
+	}
+
+	// 源目录不存在时跳过
+	result, err := MigrateJSONStorage(filepath.Join(dir, "missing"), "", histories, templates)
+	if err != nil || result.Histories != 0 || result.Templates != 0 {
+		t.Fatalf("missing dir: result = %+v, err = %v", result, err)
+	}
+}
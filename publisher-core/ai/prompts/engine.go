@@ -0,0 +1,141 @@
+package prompts
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+)
+
+// PlatformLimits 各平台内容长度限制（字符数）
+var PlatformLimits = map[string]map[string]int{
+	"xiaohongshu": {"title": 20, "content": 1000, "tags": 10},
+	"douyin":      {"title": 30, "content": 1000, "tags": 5},
+	"toutiao":     {"title": 30, "content": 20000, "tags": 5},
+}
+
+// RenderOptions 渲染选项
+type RenderOptions struct {
+	// Strict 为 true 时引用未提供的变量会报错，而不是输出 "<no value>"
+	Strict bool
+}
+
+// FuncMap 模板可用的辅助函数
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"join":          join,
+		"truncate":      truncate,
+		"default":       defaultValue,
+		"trim":          strings.TrimSpace,
+		"upper":         strings.ToUpper,
+		"lower":         strings.ToLower,
+		"hashtags":      hashtags,
+		"platformLimit": platformLimit,
+		"fitPlatform":   fitPlatform,
+	}
+}
+
+// Parse 解析模板文本
+func Parse(name, text string, opts RenderOptions) (*template.Template, error) {
+	t := template.New(name).Funcs(FuncMap())
+	if opts.Strict {
+		t = t.Option("missingkey=error")
+	}
+	return t.Parse(text)
+}
+
+// Render 渲染模板文本
+func Render(name, text string, data map[string]any, opts RenderOptions) (string, error) {
+	t, err := Parse(name, text, opts)
+	if err != nil {
+		return "", fmt.Errorf("parse template %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// join 用分隔符连接列表：{{join ", " .Tags}}
+func join(sep string, items any) string {
+	switch v := items.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []string:
+		return strings.Join(v, sep)
+	}
+
+	rv := reflect.ValueOf(items)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Sprint(items)
+	}
+	parts := make([]string, rv.Len())
+	for i := range parts {
+		parts[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return strings.Join(parts, sep)
+}
+
+// truncate 按字符截断，超出部分以省略号代替：{{truncate 20 .Title}}
+func truncate(n int, s string) string {
+	runes := []rune(s)
+	if n <= 0 || len(runes) <= n {
+		return s
+	}
+	if n == 1 {
+		return string(runes[:1])
+	}
+	return string(runes[:n-1]) + "…"
+}
+
+// defaultValue 值为空时使用默认值：{{default "无" .Tips}}
+func defaultValue(def any, value any) any {
+	if value == nil {
+		return def
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		if rv.Len() == 0 {
+			return def
+		}
+	}
+	return value
+}
+
+// hashtags 将标签列表格式化为 "#a #b"，自动去除已有的 # 前缀
+func hashtags(items any) string {
+	var tags []string
+	switch v := items.(type) {
+	case string:
+		tags = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '，' || r == ' ' })
+	case []string:
+		tags = v
+	default:
+		return join(" ", items)
+	}
+
+	parts := make([]string, 0, len(tags))
+	for _, t := range tags {
+		t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+		if t != "" {
+			parts = append(parts, "#"+t)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// platformLimit 返回平台字段长度限制，未知平台或字段返回 0
+func platformLimit(platform, field string) int {
+	return PlatformLimits[platform][field]
+}
+
+// fitPlatform 按平台字段长度限制截断：{{fitPlatform "xiaohongshu" "title" .Title}}
+func fitPlatform(platform, field, s string) string {
+	return truncate(platformLimit(platform, field), s)
+}
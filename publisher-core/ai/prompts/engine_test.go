@@ -0,0 +1,89 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		data   map[string]any
+		strict bool
+		want   string
+	}{
+		{name: "变量", text: "主题：{{.Topic}}", data: map[string]any{"Topic": "旅行"}, want: "主题：旅行"},
+		{name: "条件", text: "{{if .Tips}}提示：{{.Tips}}{{else}}无提示{{end}}", data: map[string]any{"Tips": ""}, want: "无提示"},
+		{name: "循环", text: "{{range .Tags}}[{{.}}]{{end}}", data: map[string]any{"Tags": []string{"a", "b"}}, want: "[a][b]"},
+		{name: "join", text: `{{join ", " .Tags}}`, data: map[string]any{"Tags": []string{"a", "b"}}, want: "a, b"},
+		{name: "join 数字", text: `{{join "/" .Nums}}`, data: map[string]any{"Nums": []int{1, 2}}, want: "1/2"},
+		{name: "default 空值", text: `{{default "无" .Tips}}`, data: map[string]any{"Tips": ""}, want: "无"},
+		{name: "default 有值", text: `{{default "无" .Tips}}`, data: map[string]any{"Tips": "多喝水"}, want: "多喝水"},
+		{name: "hashtags", text: `{{hashtags .Tags}}`, data: map[string]any{"Tags": "#旅行, 美食 "}, want: "#旅行 #美食"},
+		{name: "非严格模式缺少变量", text: "{{.Missing}}", data: map[string]any{}, want: "<no value>"},
+		{name: "严格模式下 nil 值可用 default", text: `{{default "无" .Tips}}`, data: map[string]any{"Tips": nil}, strict: true, want: "无"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.name, tt.text, tt.data, RenderOptions{Strict: tt.strict})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderStrictMissingKey(t *testing.T) {
+	_, err := Render("strict", "主题：{{.Topic}}", map[string]any{}, RenderOptions{Strict: true})
+	if err == nil || !strings.Contains(err.Error(), "Topic") {
+		t.Fatalf("Render() error = %v, want missing key error", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	if _, err := Parse("ok", `{{truncate 5 .Title}}`, RenderOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Parse("unclosed", `{{if .Title}}`, RenderOptions{}); err == nil {
+		t.Error("unclosed action should fail to parse")
+	}
+	if _, err := Parse("unknown", `{{shout .Title}}`, RenderOptions{}); err == nil {
+		t.Error("unknown function should fail to parse")
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		n    int
+		s    string
+		want string
+	}{
+		{n: 5, s: "春天的旅行", want: "春天的旅行"},
+		{n: 4, s: "春天的旅行", want: "春天的…"},
+		{n: 1, s: "春天", want: "春"},
+		{n: 0, s: "春天", want: "春天"},
+		{n: 3, s: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.n, tt.s); got != tt.want {
+			t.Errorf("truncate(%d, %q) = %q, want %q", tt.n, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFitPlatform(t *testing.T) {
+	long := strings.Repeat("字", 25)
+	if got := []rune(fitPlatform("xiaohongshu", "title", long)); len(got) != 20 || got[19] != '…' {
+		t.Errorf("xiaohongshu title = %q", string(got))
+	}
+	if got := fitPlatform("douyin", "title", long); got != long {
+		t.Errorf("douyin title should fit in 30 characters: %q", got)
+	}
+	if got := fitPlatform("unknown", "title", long); got != long {
+		t.Errorf("unknown platform should not truncate: %q", got)
+	}
+}
@@ -2,7 +2,6 @@ package prompts
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/monkeycode/publisher-core/ai/provider"
 )
@@ -12,11 +11,15 @@ const (
 	RoleHotspotAnalyst = "你是一位热点分析专家，擅长分析新闻热点、提取关键信息、判断趋势走向。"
 	RoleContentAuditor = "你是一位内容审核专家，擅长识别内容中的敏感信息、违规内容和潜在风险。"
 	RoleSEOExpert      = "你是一位SEO优化专家，擅长优化内容以提高搜索引擎排名和社交媒体曝光率。"
+	RoleCommunityMgr   = "你是一位社交媒体运营，负责以账号本人的口吻回复粉丝评论，真诚、得体、不过度营销。"
 )
 
 type PromptTemplate struct {
 	System string
 	User   string
+
+	// Optional 可以不提供的变量，未提供时按空值渲染，模板中用 default 或 if 处理；其余变量缺少时报错
+	Optional []string
 }
 
 var Templates = map[string]PromptTemplate{
@@ -129,6 +132,41 @@ var Templates = map[string]PromptTemplate{
 }`,
 	},
 
+	"reply_comment": {
+		System: RoleCommunityMgr,
+		User: `请以账号「{{.Account}}」的口吻，回复{{.Platform}}笔记下的一条评论。
+
+笔记标题：{{default "（无）" .NoteTitle}}
+评论用户：{{.Nickname}}
+评论内容：{{.Comment}}
+
+账号人设：{{default "真诚友好的内容创作者" .Persona}}
+语气风格：{{default "亲切自然" .Tone}}
+{{- if .Guidelines}}
+回复准则：
+{{- range .Guidelines}}
+- {{.}}
+{{- end}}
+{{- end}}
+{{- if .Forbidden}}
+禁止出现：{{join "、" .Forbidden}}
+{{- end}}
+
+要求：
+1. 回复不超过{{.MaxLength}}字，口语化，不要使用"亲"等客服腔
+2. 针对评论的具体内容回应，不要泛泛感谢
+3. 不承诺无法兑现的事情，不引导站外交易
+4. 对广告、引战或无意义的评论，可以选择不回复
+
+请以JSON格式输出：
+{
+  "reply": "回复内容",
+  "skip": false,
+  "reason": "选择不回复时说明原因"
+}`,
+		Optional: []string{"NoteTitle", "Persona", "Tone", "Guidelines", "Forbidden"},
+	},
+
 	"extract_keywords": {
 		System: RoleSEOExpert,
 		User: `请从以下内容中提取关键词：
@@ -165,21 +203,47 @@ func GetTemplate(name string) (PromptTemplate, bool) {
 	return t, ok
 }
 
+// BuildPrompt 使用字符串变量渲染提示词，缺少变量时报错
 func BuildPrompt(templateName string, vars map[string]string) ([]provider.Message, error) {
+	data := make(map[string]any, len(vars))
+	for k, v := range vars {
+		data[k] = v
+	}
+	return BuildPromptData(templateName, data)
+}
+
+// BuildPromptData 使用任意类型变量渲染提示词，支持条件、循环和辅助函数
+// 缺少模板未声明为 Optional 的变量时报错
+func BuildPromptData(templateName string, data map[string]any) ([]provider.Message, error) {
 	tmpl, ok := Templates[templateName]
 	if !ok {
 		return nil, fmt.Errorf("template not found: %s", templateName)
 	}
 
-	user := tmpl.User
+	opts := RenderOptions{Strict: true}
+	if len(tmpl.Optional) > 0 {
+		filled := make(map[string]any, len(data)+len(tmpl.Optional))
+		for _, key := range tmpl.Optional {
+			filled[key] = nil
+		}
+		for k, v := range data {
+			filled[k] = v
+		}
+		data = filled
+	}
 
-	for k, v := range vars {
-		placeholder := fmt.Sprintf("{{.%s}}", k)
-		user = strings.ReplaceAll(user, placeholder, v)
+	system, err := Render(templateName+".system", tmpl.System, data, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := Render(templateName+".user", tmpl.User, data, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	return []provider.Message{
-		{Role: provider.RoleSystem, Content: tmpl.System},
+		{Role: provider.RoleSystem, Content: system},
 		{Role: provider.RoleUser, Content: user},
 	}, nil
 }
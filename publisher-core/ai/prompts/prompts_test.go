@@ -0,0 +1,40 @@
+package prompts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildPromptDataOptional(t *testing.T) {
+	messages, err := BuildPromptData("reply_comment", map[string]any{
+		"Account":   "旅行号",
+		"Platform":  "小红书",
+		"Nickname":  "用户1",
+		"Comment":   "好喜欢",
+		"MaxLength": 60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := messages[1].Content
+	for _, want := range []string{"笔记标题：（无）", "账号人设：真诚友好的内容创作者", "语气风格：亲切自然"} {
+		if !strings.Contains(user, want) {
+			t.Errorf("prompt missing %q:\n%s", want, user)
+		}
+	}
+	if strings.Contains(user, "<no value>") || strings.Contains(user, "回复准则") || strings.Contains(user, "禁止出现") {
+		t.Errorf("omitted optional keys leaked into prompt:\n%s", user)
+	}
+}
+
+func TestBuildPromptDataMissingRequired(t *testing.T) {
+	if _, err := BuildPromptData("reply_comment", map[string]any{"Account": "旅行号"}); err == nil {
+		t.Error("missing required key should fail")
+	}
+	if _, err := BuildPrompt("audit_content", map[string]string{}); err == nil {
+		t.Error("missing Content should fail")
+	}
+	if _, err := BuildPrompt("no_such_template", nil); err == nil {
+		t.Error("unknown template should fail")
+	}
+}
@@ -34,6 +34,14 @@ type GenerateOptions struct {
 	Temperature float64   `json:"temperature,omitempty"`
 	TopP        float64   `json:"top_p,omitempty"`
 	Stop        []string  `json:"stop,omitempty"`
+
+	// 用量统计标签，不会发送给模型服务
+	Team     string `json:"team,omitempty"`
+	Template string `json:"template,omitempty"`
+
+	// 响应缓存控制：温度为 0 的请求默认缓存，Cache 为其他温度显式开启缓存，NoCache 强制绕过
+	Cache   bool `json:"cache,omitempty"`
+	NoCache bool `json:"no_cache,omitempty"`
 }
 
 type GenerateResult struct {
@@ -43,6 +51,7 @@ type GenerateResult struct {
 	InputTokens  int       `json:"input_tokens"`
 	OutputTokens int       `json:"output_tokens"`
 	FinishedAt   time.Time `json:"finished_at"`
+	Cached       bool      `json:"cached,omitempty"`
 }
 
 type Provider interface {
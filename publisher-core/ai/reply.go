@@ -0,0 +1,605 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/monkeycode/publisher-core/ai/prompts"
+	"github.com/monkeycode/publisher-core/ai/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// 回复草稿状态
+const (
+	ReplyStatusPending  = "pending"  // 待审批
+	ReplyStatusBlocked  = "blocked"  // 未通过内容审核，需人工修改
+	ReplyStatusApproved = "approved" // 已批准，等待发送
+	ReplyStatusRejected = "rejected" // 已拒绝
+	ReplyStatusSent     = "sent"     // 已发送
+	ReplyStatusFailed   = "failed"   // 发送失败
+)
+
+// ErrReplyNotFound 回复草稿不存在
+var ErrReplyNotFound = errors.New("reply draft not found")
+
+// BrandVoice 账号的回复口吻配置
+type BrandVoice struct {
+	Account    string   `json:"account"`
+	Persona    string   `json:"persona,omitempty"`    // 账号人设
+	Tone       string   `json:"tone,omitempty"`       // 语气风格
+	Guidelines []string `json:"guidelines,omitempty"` // 回复准则
+	Forbidden  []string `json:"forbidden,omitempty"`  // 禁用词
+	MaxLength  int      `json:"max_length,omitempty"` // 回复字数上限，默认 60
+}
+
+// FeedComment 笔记评论，字段与小红书 CommentList 接口一致，可直接解析 get_feed_detail 的评论数据
+type FeedComment struct {
+	ID         string `json:"id"`
+	NoteID     string `json:"noteId"`
+	Content    string `json:"content"`
+	CreateTime int64  `json:"createTime"`
+	UserInfo   struct {
+		UserID   string `json:"userId"`
+		Nickname string `json:"nickname"`
+	} `json:"userInfo"`
+	SubComments []FeedComment `json:"subComments"`
+	ShowTags    []string      `json:"showTags"`
+}
+
+// FeedCommentList 笔记评论列表
+type FeedCommentList struct {
+	List []FeedComment `json:"list"`
+}
+
+// isAuthor 作者本人的评论带有 is_author 标签
+func (c *FeedComment) isAuthor() bool {
+	for _, tag := range c.ShowTags {
+		if tag == "is_author" {
+			return true
+		}
+	}
+	return false
+}
+
+// Unreplied 返回作者尚未回复的一级评论
+func (l *FeedCommentList) Unreplied() []FeedComment {
+	var result []FeedComment
+	for _, c := range l.List {
+		if c.isAuthor() || strings.TrimSpace(c.Content) == "" {
+			continue
+		}
+		replied := false
+		for i := range c.SubComments {
+			if c.SubComments[i].isAuthor() {
+				replied = true
+				break
+			}
+		}
+		if !replied {
+			result = append(result, c)
+		}
+	}
+	return result
+}
+
+// AuditResult audit_content 提示词的审核结果
+type AuditResult struct {
+	Passed      bool     `json:"passed"`
+	Issues      []string `json:"issues"`
+	Suggestions []string `json:"suggestions"`
+	Score       int      `json:"score"`
+}
+
+// ReplyDraft 审批队列中的回复草稿
+type ReplyDraft struct {
+	ID         string       `json:"id"`
+	Account    string       `json:"account"`
+	Platform   string       `json:"platform"`
+	FeedID     string       `json:"feed_id"`
+	XsecToken  string       `json:"xsec_token"`
+	NoteTitle  string       `json:"note_title,omitempty"`
+	CommentID  string       `json:"comment_id"`
+	UserID     string       `json:"user_id"`
+	Nickname   string       `json:"nickname"`
+	Comment    string       `json:"comment"`
+	Reply      string       `json:"reply"`
+	Status     string       `json:"status"`
+	Audit      *AuditResult `json:"audit,omitempty"`
+	Note       string       `json:"note,omitempty"` // 审批备注或失败原因
+	Provider   string       `json:"provider"`
+	Model      string       `json:"model"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ReviewedAt *time.Time   `json:"reviewed_at,omitempty"`
+	SentAt     *time.Time   `json:"sent_at,omitempty"`
+}
+
+// ReplyFilter 回复草稿过滤器
+type ReplyFilter struct {
+	Account string
+	FeedID  string
+	Status  string
+	Limit   int
+}
+
+func (f ReplyFilter) match(d *ReplyDraft) bool {
+	if f.Account != "" && d.Account != f.Account {
+		return false
+	}
+	if f.FeedID != "" && d.FeedID != f.FeedID {
+		return false
+	}
+	if f.Status != "" && d.Status != f.Status {
+		return false
+	}
+	return true
+}
+
+// ReplyQueueStorage 回复审批队列存储接口
+type ReplyQueueStorage interface {
+	Save(draft *ReplyDraft) error
+	Load(id string) (*ReplyDraft, error)
+	List(filter ReplyFilter) ([]*ReplyDraft, error)
+}
+
+// ReplySender 发送评论回复，小红书 CommentFeedAction 即满足该接口
+type ReplySender interface {
+	ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, userID, content string) error
+}
+
+// Pacing 发送节奏，避免短时间内集中回复触发风控
+type Pacing struct {
+	Interval  time.Duration // 两次回复的基础间隔
+	Jitter    time.Duration // 在基础间隔上随机增加的时长
+	MaxPerRun int           // 单次最多发送条数，0 表示不限制
+}
+
+// DefaultPacing 默认发送节奏：30~60 秒一条，每轮最多 20 条
+func DefaultPacing() Pacing {
+	return Pacing{Interval: 30 * time.Second, Jitter: 30 * time.Second, MaxPerRun: 20}
+}
+
+func (p Pacing) delay() time.Duration {
+	d := p.Interval
+	if p.Jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(p.Jitter)))
+	}
+	return d
+}
+
+// DraftRepliesRequest 生成回复草稿的请求
+type DraftRepliesRequest struct {
+	Account   string          `json:"account"`
+	Team      string          `json:"team,omitempty"` // 用量统计和预算所属团队
+	Platform  string          `json:"platform"`
+	FeedID    string          `json:"feed_id"`
+	XsecToken string          `json:"xsec_token"`
+	NoteTitle string          `json:"note_title,omitempty"`
+	Comments  FeedCommentList `json:"comments"`
+	Limit     int             `json:"limit,omitempty"` // 本次最多生成的草稿数，0 表示不限制
+}
+
+// SendReport 一轮发送的结果
+type SendReport struct {
+	Sent    int           `json:"sent"`
+	Failed  int           `json:"failed"`
+	Pending int           `json:"pending"` // 超出单轮上限、留待下一轮的数量
+	Drafts  []*ReplyDraft `json:"drafts"`
+}
+
+// ReplyAssistant 评论回复助手：生成草稿、内容审核、人工审批、限速发送
+type ReplyAssistant struct {
+	service *Service
+	storage ReplyQueueStorage
+
+	mu     sync.RWMutex
+	voices map[string]BrandVoice
+}
+
+// NewReplyAssistant 创建评论回复助手
+func NewReplyAssistant(service *Service, storage ReplyQueueStorage) *ReplyAssistant {
+	return &ReplyAssistant{
+		service: service,
+		storage: storage,
+		voices:  make(map[string]BrandVoice),
+	}
+}
+
+// SetBrandVoice 设置账号的回复口吻
+func (a *ReplyAssistant) SetBrandVoice(v BrandVoice) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.voices[v.Account] = v
+}
+
+// BrandVoice 获取账号的回复口吻，未配置时返回默认口吻
+func (a *ReplyAssistant) BrandVoice(account string) BrandVoice {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if v, ok := a.voices[account]; ok {
+		return v
+	}
+	return BrandVoice{Account: account}
+}
+
+// LoadBrandVoices 从 JSON 文件加载各账号的回复口吻（BrandVoice 数组）
+func (a *ReplyAssistant) LoadBrandVoices(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read brand voices: %w", err)
+	}
+
+	var voices []BrandVoice
+	if err := json.Unmarshal(data, &voices); err != nil {
+		return fmt.Errorf("parse brand voices: %w", err)
+	}
+
+	for _, v := range voices {
+		if v.Account == "" {
+			continue
+		}
+		a.SetBrandVoice(v)
+	}
+	return nil
+}
+
+// DraftReplies 为未回复的评论生成回复草稿，审核通过的进入待审批，未通过的标记为 blocked
+func (a *ReplyAssistant) DraftReplies(ctx context.Context, req *DraftRepliesRequest) ([]*ReplyDraft, error) {
+	if req.FeedID == "" {
+		return nil, fmt.Errorf("feed_id is required")
+	}
+	if req.Platform == "" {
+		req.Platform = "xiaohongshu"
+	}
+
+	queued, err := a.storage.List(ReplyFilter{FeedID: req.FeedID})
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(queued))
+	for _, d := range queued {
+		// 已拒绝或发送失败的评论允许重新生成
+		if d.Status != ReplyStatusRejected && d.Status != ReplyStatusFailed {
+			seen[d.CommentID] = true
+		}
+	}
+
+	voice := a.BrandVoice(req.Account)
+	drafts := make([]*ReplyDraft, 0)
+	for _, c := range req.Comments.Unreplied() {
+		if seen[c.ID] {
+			continue
+		}
+		if req.Limit > 0 && len(drafts) >= req.Limit {
+			break
+		}
+
+		draft, err := a.draftReply(ctx, req, voice, &c)
+		if err != nil {
+			return drafts, fmt.Errorf("draft reply for comment %s: %w", c.ID, err)
+		}
+		if draft == nil {
+			continue
+		}
+		if err := a.storage.Save(draft); err != nil {
+			return drafts, err
+		}
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, nil
+}
+
+// replyOutput reply_comment 提示词的输出
+type replyOutput struct {
+	Reply  string `json:"reply"`
+	Skip   bool   `json:"skip"`
+	Reason string `json:"reason"`
+}
+
+func (a *ReplyAssistant) draftReply(ctx context.Context, req *DraftRepliesRequest, voice BrandVoice, c *FeedComment) (*ReplyDraft, error) {
+	maxLength := voice.MaxLength
+	if maxLength <= 0 {
+		maxLength = 60
+	}
+
+	messages, err := prompts.BuildPromptData("reply_comment", map[string]any{
+		"Account":    req.Account,
+		"Platform":   req.Platform,
+		"NoteTitle":  req.NoteTitle,
+		"Nickname":   c.UserInfo.Nickname,
+		"Comment":    c.Content,
+		"Persona":    voice.Persona,
+		"Tone":       voice.Tone,
+		"Guidelines": voice.Guidelines,
+		"Forbidden":  voice.Forbidden,
+		"MaxLength":  maxLength,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.service.Generate(ctx, &provider.GenerateOptions{
+		Messages:    messages,
+		Temperature: 0.7,
+		Template:    "reply_comment",
+		Team:        req.Team,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var out replyOutput
+	if err := decodeModelJSON(result.Content, &out); err != nil {
+		return nil, err
+	}
+	if out.Skip || strings.TrimSpace(out.Reply) == "" {
+		logrus.Infof("skip reply for comment %s: %s", c.ID, out.Reason)
+		return nil, nil
+	}
+
+	draft := &ReplyDraft{
+		ID:        uuid.New().String(),
+		Account:   req.Account,
+		Platform:  req.Platform,
+		FeedID:    req.FeedID,
+		XsecToken: req.XsecToken,
+		NoteTitle: req.NoteTitle,
+		CommentID: c.ID,
+		UserID:    c.UserInfo.UserID,
+		Nickname:  c.UserInfo.Nickname,
+		Comment:   c.Content,
+		Reply:     strings.TrimSpace(out.Reply),
+		Status:    ReplyStatusPending,
+		Provider:  result.Provider,
+		Model:     result.Model,
+		CreatedAt: time.Now(),
+	}
+
+	audit, err := a.audit(ctx, draft.Reply, req.Team)
+	if err != nil {
+		return nil, err
+	}
+	draft.Audit = audit
+	if !audit.Passed || containsAny(draft.Reply, voice.Forbidden) {
+		draft.Status = ReplyStatusBlocked
+	}
+
+	return draft, nil
+}
+
+// audit 使用 audit_content 提示词审核回复内容
+func (a *ReplyAssistant) audit(ctx context.Context, content, team string) (*AuditResult, error) {
+	messages, err := prompts.BuildPrompt("audit_content", map[string]string{"Content": content})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.service.Generate(ctx, &provider.GenerateOptions{
+		Messages:    messages,
+		Temperature: 0,
+		Template:    "audit_content",
+		Team:        team,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var audit AuditResult
+	if err := decodeModelJSON(result.Content, &audit); err != nil {
+		return nil, err
+	}
+	return &audit, nil
+}
+
+// List 查询审批队列，按创建时间倒序
+func (a *ReplyAssistant) List(filter ReplyFilter) ([]*ReplyDraft, error) {
+	return a.storage.List(filter)
+}
+
+// Approve 批准回复草稿，reply 不为空时使用人工修改后的内容
+func (a *ReplyAssistant) Approve(id, reply string) (*ReplyDraft, error) {
+	draft, err := a.storage.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status != ReplyStatusPending && draft.Status != ReplyStatusBlocked {
+		return nil, fmt.Errorf("reply %s cannot be approved in status %s", id, draft.Status)
+	}
+	if reply = strings.TrimSpace(reply); reply != "" {
+		draft.Reply = reply
+	} else if draft.Status == ReplyStatusBlocked {
+		return nil, fmt.Errorf("reply %s failed audit, provide an edited reply to approve", id)
+	}
+
+	now := time.Now()
+	draft.Status = ReplyStatusApproved
+	draft.ReviewedAt = &now
+	return draft, a.storage.Save(draft)
+}
+
+// Reject 拒绝回复草稿
+func (a *ReplyAssistant) Reject(id, note string) (*ReplyDraft, error) {
+	draft, err := a.storage.Load(id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == ReplyStatusSent {
+		return nil, fmt.Errorf("reply %s has already been sent", id)
+	}
+
+	now := time.Now()
+	draft.Status = ReplyStatusRejected
+	draft.Note = note
+	draft.ReviewedAt = &now
+	return draft, a.storage.Save(draft)
+}
+
+// SendApproved 按节奏发送已批准的回复，ctx 取消时停止并保留未发送的草稿
+func (a *ReplyAssistant) SendApproved(ctx context.Context, sender ReplySender, account string, pacing Pacing) (*SendReport, error) {
+	approved, err := a.storage.List(ReplyFilter{Account: account, Status: ReplyStatusApproved})
+	if err != nil {
+		return nil, err
+	}
+	// 先批准的先发
+	sort.Slice(approved, func(i, j int) bool {
+		return reviewedAt(approved[i]).Before(reviewedAt(approved[j]))
+	})
+
+	report := &SendReport{Drafts: make([]*ReplyDraft, 0)}
+	for i, draft := range approved {
+		if pacing.MaxPerRun > 0 && i >= pacing.MaxPerRun {
+			report.Pending = len(approved) - i
+			break
+		}
+		if i > 0 {
+			select {
+			case <-ctx.Done():
+				report.Pending = len(approved) - i
+				return report, ctx.Err()
+			case <-time.After(pacing.delay()):
+			}
+		}
+
+		if err := sender.ReplyToComment(ctx, draft.FeedID, draft.XsecToken, draft.CommentID, draft.UserID, draft.Reply); err != nil {
+			logrus.Warnf("send reply %s failed: %v", draft.ID, err)
+			draft.Status = ReplyStatusFailed
+			draft.Note = err.Error()
+			report.Failed++
+		} else {
+			now := time.Now()
+			draft.Status = ReplyStatusSent
+			draft.SentAt = &now
+			report.Sent++
+		}
+		if err := a.storage.Save(draft); err != nil {
+			return report, err
+		}
+		report.Drafts = append(report.Drafts, draft)
+	}
+
+	return report, nil
+}
+
+func reviewedAt(d *ReplyDraft) time.Time {
+	if d.ReviewedAt != nil {
+		return *d.ReviewedAt
+	}
+	return d.CreatedAt
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if w != "" && strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// decodeModelJSON 解析模型输出的 JSON，兼容 ```json 代码块和前后说明文字
+func decodeModelJSON(content string, v any) error {
+	content = strings.TrimSpace(content)
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("model output is not JSON: %q", truncateForLog(content, 200))
+	}
+	if err := json.Unmarshal([]byte(content[start:end+1]), v); err != nil {
+		return fmt.Errorf("parse model output: %w", err)
+	}
+	return nil
+}
+
+func truncateForLog(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
+
+// JSONReplyQueueStorage 每条草稿一个 JSON 文件的审批队列存储
+type JSONReplyQueueStorage struct {
+	dataDir string
+	mu      sync.RWMutex
+}
+
+func NewJSONReplyQueueStorage(dataDir string) (*JSONReplyQueueStorage, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &JSONReplyQueueStorage{dataDir: dataDir}, nil
+}
+
+func (s *JSONReplyQueueStorage) Save(draft *ReplyDraft) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(draft, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.dataDir, draft.ID+".json"), data, 0644)
+}
+
+func (s *JSONReplyQueueStorage) Load(id string) (*ReplyDraft, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, err := os.ReadFile(filepath.Join(s.dataDir, filepath.Base(id)+".json"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: %s", ErrReplyNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var draft ReplyDraft
+	if err := json.Unmarshal(data, &draft); err != nil {
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (s *JSONReplyQueueStorage) List(filter ReplyFilter) ([]*ReplyDraft, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dataDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	drafts := make([]*ReplyDraft, 0)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var draft ReplyDraft
+		if err := json.Unmarshal(data, &draft); err != nil {
+			continue
+		}
+		if filter.match(&draft) {
+			drafts = append(drafts, &draft)
+		}
+	}
+
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].CreatedAt.After(drafts[j].CreatedAt)
+	})
+	if filter.Limit > 0 && len(drafts) > filter.Limit {
+		drafts = drafts[:filter.Limit]
+	}
+	return drafts, nil
+}
@@ -0,0 +1,66 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// HTTPReplySender 通过 xiaohongshu-mcp 的 HTTP 接口（POST /api/v1/comment/reply）发送评论回复，
+// 接口内部调用 CommentFeedAction.ReplyToComment
+type HTTPReplySender struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPReplySender 创建回复发送器，baseURL 如 http://localhost:18060
+func NewHTTPReplySender(baseURL string) *HTTPReplySender {
+	return &HTTPReplySender{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		// 回复需要打开笔记页面，耗时较长
+		client: &http.Client{Timeout: 3 * time.Minute},
+	}
+}
+
+// ReplyToComment 实现 ReplySender
+func (s *HTTPReplySender) ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, userID, content string) error {
+	body, err := json.Marshal(map[string]string{
+		"feed_id":    feedID,
+		"xsec_token": xsecToken,
+		"comment_id": commentID,
+		"user_id":    userID,
+		"content":    content,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/api/v1/comment/reply", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send reply request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Success bool   `json:"success"`
+		Error   string `json:"error"`
+		Details any    `json:"details"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("decode reply response (status %d): %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || !result.Success {
+		return fmt.Errorf("reply failed (status %d): %s %v", resp.StatusCode, result.Error, result.Details)
+	}
+	return nil
+}
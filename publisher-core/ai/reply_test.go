@@ -0,0 +1,213 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/monkeycode/publisher-core/ai/provider"
+)
+
+// replyTestProvider 按模板返回固定输出：含"广告"的评论不回复，含"违禁"的回复审核不通过
+type replyTestProvider struct {
+	teams []string
+}
+
+func (p *replyTestProvider) Name() provider.ProviderType { return provider.ProviderDeepSeek }
+func (p *replyTestProvider) Models() []string            { return []string{"test"} }
+func (p *replyTestProvider) DefaultModel() string        { return "test" }
+
+func (p *replyTestProvider) GenerateStream(ctx context.Context, opts *provider.GenerateOptions) (<-chan string, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *replyTestProvider) Generate(ctx context.Context, opts *provider.GenerateOptions) (*provider.GenerateResult, error) {
+	p.teams = append(p.teams, opts.Team)
+	prompt := opts.Messages[len(opts.Messages)-1].Content
+
+	var out any
+	switch opts.Template {
+	case "reply_comment":
+		comment := ""
+		for _, line := range strings.Split(prompt, "\n") {
+			if v, ok := strings.CutPrefix(line, "评论内容："); ok {
+				comment = v
+			}
+		}
+		if strings.Contains(comment, "广告") {
+			out = replyOutput{Skip: true, Reason: "广告"}
+		} else {
+			out = replyOutput{Reply: "谢谢，" + comment}
+		}
+	case "audit_content":
+		out = AuditResult{Passed: !strings.Contains(prompt, "违禁"), Score: 90}
+	}
+
+	data, _ := json.Marshal(out)
+	return &provider.GenerateResult{Content: "```json\n" + string(data) + "\n```", Model: "test", Provider: string(p.Name())}, nil
+}
+
+type replyTestSender struct {
+	sent []string
+	fail map[string]bool
+}
+
+func (s *replyTestSender) ReplyToComment(ctx context.Context, feedID, xsecToken, commentID, userID, content string) error {
+	if s.fail[commentID] {
+		return errors.New("reply failed")
+	}
+	s.sent = append(s.sent, commentID)
+	return nil
+}
+
+func newTestReplyAssistant(t *testing.T) (*ReplyAssistant, *replyTestProvider) {
+	t.Helper()
+	p := &replyTestProvider{}
+	service := NewServiceWithDefaults()
+	service.RegisterProvider(p)
+
+	storage, err := NewJSONReplyQueueStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewReplyAssistant(service, storage), p
+}
+
+func testComment(id, content string, tags ...string) FeedComment {
+	c := FeedComment{ID: id, NoteID: "n1", Content: content, ShowTags: tags}
+	c.UserInfo.UserID = "u-" + id
+	c.UserInfo.Nickname = "用户" + id
+	return c
+}
+
+func TestDraftReplies(t *testing.T) {
+	a, p := newTestReplyAssistant(t)
+
+	replied := testComment("c4", "已经回复过")
+	replied.SubComments = []FeedComment{testComment("c4-1", "谢谢", "is_author")}
+	req := &DraftRepliesRequest{
+		Account: "旅行号",
+		Team:    "运营一组",
+		FeedID:  "n1",
+		Comments: FeedCommentList{List: []FeedComment{
+			testComment("c1", "好喜欢这组照片"),
+			testComment("c2", "有违禁词"),
+			testComment("c3", "加我看广告"),
+			replied,
+			testComment("c5", "作者自己的评论", "is_author"),
+		}},
+	}
+
+	drafts, err := a.DraftReplies(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	status := make(map[string]string)
+	for _, d := range drafts {
+		status[d.CommentID] = d.Status
+	}
+	want := map[string]string{"c1": ReplyStatusPending, "c2": ReplyStatusBlocked}
+	if len(status) != len(want) || status["c1"] != want["c1"] || status["c2"] != want["c2"] {
+		t.Fatalf("draft status = %v, want %v", status, want)
+	}
+	for _, team := range p.teams {
+		if team != "运营一组" {
+			t.Fatalf("usage team = %q, want the request team", team)
+		}
+	}
+
+	// 已在队列中的评论不重复生成
+	again, err := a.DraftReplies(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 0 {
+		t.Fatalf("redraft = %d drafts, want 0", len(again))
+	}
+}
+
+func TestReplyApprovalAndSend(t *testing.T) {
+	a, _ := newTestReplyAssistant(t)
+	drafts, err := a.DraftReplies(context.Background(), &DraftRepliesRequest{
+		Account: "旅行号",
+		FeedID:  "n1",
+		Comments: FeedCommentList{List: []FeedComment{
+			testComment("c1", "好喜欢"),
+			testComment("c2", "有违禁词"),
+			testComment("c3", "求攻略"),
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ids := make(map[string]string)
+	for _, d := range drafts {
+		ids[d.CommentID] = d.ID
+	}
+
+	if _, err := a.Approve(ids["c2"], ""); err == nil {
+		t.Fatal("approving a blocked draft without edits should fail")
+	}
+	if d, err := a.Approve(ids["c2"], "谢谢支持"); err != nil || d.Reply != "谢谢支持" {
+		t.Fatalf("approve edited blocked draft: %v, %+v", err, d)
+	}
+	if _, err := a.Approve(ids["c1"], ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Reject(ids["c3"], "不需要回复"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Approve(ids["c3"], ""); err == nil {
+		t.Fatal("approving a rejected draft should fail")
+	}
+
+	sender := &replyTestSender{fail: map[string]bool{"c1": true}}
+	report, err := a.SendApproved(context.Background(), sender, "旅行号", Pacing{MaxPerRun: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Sent != 1 || report.Failed != 1 || report.Pending != 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	if len(sender.sent) != 1 || sender.sent[0] != "c2" {
+		t.Fatalf("sent = %v, want [c2]", sender.sent)
+	}
+
+	failed, err := a.List(ReplyFilter{Status: ReplyStatusFailed})
+	if err != nil || len(failed) != 1 || failed[0].CommentID != "c1" || failed[0].Note == "" {
+		t.Fatalf("failed drafts = %+v, err = %v", failed, err)
+	}
+}
+
+func TestHTTPReplySender(t *testing.T) {
+	var got map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/comment/reply" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		if got["content"] == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":"回复评论失败","code":"REPLY_COMMENT_FAILED"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer server.Close()
+
+	sender := NewHTTPReplySender(server.URL + "/")
+	if err := sender.ReplyToComment(context.Background(), "n1", "token", "c1", "u1", "谢谢"); err != nil {
+		t.Fatal(err)
+	}
+	if got["feed_id"] != "n1" || got["comment_id"] != "c1" || got["content"] != "谢谢" {
+		t.Fatalf("request body = %v", got)
+	}
+	if err := sender.ReplyToComment(context.Background(), "n1", "token", "c1", "u1", "fail"); err == nil {
+		t.Fatal("server error should be returned")
+	}
+}
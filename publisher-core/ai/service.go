@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 
 	"github.com/monkeycode/publisher-core/ai/provider"
@@ -17,6 +18,8 @@ type Service struct {
 	providers map[provider.ProviderType]provider.Provider
 	config    *Config
 	primary   provider.ProviderType
+	usage     *UsageLedger
+	cache     *ResponseCache
 }
 
 type Config struct {
@@ -160,17 +163,45 @@ func (s *Service) ListModels() map[string][]string {
 	return result
 }
 
+// SetUsageLedger 设置用量账本，设置后所有调用都会记账并受预算约束
+func (s *Service) SetUsageLedger(l *UsageLedger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.usage = l
+}
+
+// UsageLedger 返回当前用量账本
+func (s *Service) UsageLedger() *UsageLedger {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.usage
+}
+
+// SetResponseCache 设置响应缓存
+func (s *Service) SetResponseCache(c *ResponseCache) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache = c
+}
+
+// CacheStats 返回响应缓存命中统计，未启用缓存时返回零值
+func (s *Service) CacheStats() CacheStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.cache == nil {
+		return CacheStats{}
+	}
+	return s.cache.Stats()
+}
+
 func (s *Service) Generate(ctx context.Context, opts *provider.GenerateOptions) (*provider.GenerateResult, error) {
 	p := s.GetPrimary()
 	if p == nil {
 		return nil, fmt.Errorf("no AI provider available")
 	}
 
-	if opts.Model == "" {
-		opts.Model = p.DefaultModel()
-	}
-
-	return p.Generate(ctx, opts)
+	return s.generate(ctx, p, opts)
 }
 
 func (s *Service) GenerateStream(ctx context.Context, opts *provider.GenerateOptions) (<-chan string, error) {
@@ -183,7 +214,19 @@ func (s *Service) GenerateStream(ctx context.Context, opts *provider.GenerateOpt
 		opts.Model = p.DefaultModel()
 	}
 
-	return p.GenerateStream(ctx, opts)
+	ledger := s.UsageLedger()
+	if ledger != nil {
+		if err := ledger.CheckBudget(opts.Team, string(p.Name()), opts.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	ch, err := p.GenerateStream(ctx, opts)
+	if err != nil || ledger == nil {
+		return ch, err
+	}
+
+	return s.meterStream(ctx, ledger, p.Name(), opts, ch), nil
 }
 
 func (s *Service) GenerateWithProvider(ctx context.Context, pt provider.ProviderType, opts *provider.GenerateOptions) (*provider.GenerateResult, error) {
@@ -192,11 +235,108 @@ func (s *Service) GenerateWithProvider(ctx context.Context, pt provider.Provider
 		return nil, err
 	}
 
+	return s.generate(ctx, p, opts)
+}
+
+func (s *Service) generate(ctx context.Context, p provider.Provider, opts *provider.GenerateOptions) (*provider.GenerateResult, error) {
 	if opts.Model == "" {
 		opts.Model = p.DefaultModel()
 	}
 
-	return p.Generate(ctx, opts)
+	s.mu.RLock()
+	cache := s.cache
+	s.mu.RUnlock()
+
+	var cacheKey string
+	if cache != nil {
+		if Cacheable(opts) {
+			cacheKey = CacheKey(p.Name(), opts)
+			if result, ok := cache.Get(cacheKey); ok {
+				return result, nil
+			}
+		} else {
+			cache.bypassed.Add(1)
+		}
+	}
+
+	ledger := s.UsageLedger()
+	if ledger != nil {
+		if err := ledger.CheckBudget(opts.Team, string(p.Name()), opts.Model); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := p.Generate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheKey != "" {
+		if err := cache.Set(cacheKey, result); err != nil {
+			logrus.Warnf("write AI response cache failed: %v", err)
+		}
+	}
+
+	if ledger == nil {
+		return result, nil
+	}
+
+	record := &UsageRecord{
+		Provider:     string(p.Name()),
+		Model:        result.Model,
+		Template:     opts.Template,
+		Team:         opts.Team,
+		InputTokens:  result.InputTokens,
+		OutputTokens: result.OutputTokens,
+	}
+	// 部分服务商不返回用量，回退到本地估算
+	if record.InputTokens == 0 && record.OutputTokens == 0 {
+		record.InputTokens = EstimateMessagesTokens(opts.Messages)
+		record.OutputTokens = EstimateTokens(result.Content)
+		record.Estimated = true
+	}
+	if err := ledger.Record(record); err != nil {
+		logrus.Warnf("record AI usage failed: %v", err)
+	}
+
+	return result, nil
+}
+
+// meterStream 透传流式输出，结束后按估算 Token 数记账
+// 调用方不再读取时以 ctx 取消为准停止转发，已收到的部分照常记账
+func (s *Service) meterStream(ctx context.Context, ledger *UsageLedger, pt provider.ProviderType, opts *provider.GenerateOptions, in <-chan string) <-chan string {
+	out := make(chan string, cap(in))
+
+	go func() {
+		defer close(out)
+
+		var sb strings.Builder
+	forward:
+		for chunk := range in {
+			sb.WriteString(chunk)
+			select {
+			case out <- chunk:
+			case <-ctx.Done():
+				break forward
+			}
+		}
+
+		record := &UsageRecord{
+			Provider:     string(pt),
+			Model:        opts.Model,
+			Template:     opts.Template,
+			Team:         opts.Team,
+			InputTokens:  EstimateMessagesTokens(opts.Messages),
+			OutputTokens: EstimateTokens(sb.String()),
+			Stream:       true,
+			Estimated:    true,
+		}
+		if err := ledger.Record(record); err != nil {
+			logrus.Warnf("record AI stream usage failed: %v", err)
+		}
+	}()
+
+	return out
 }
 
 func SaveConfig(cfg *Config, path string) error {
@@ -0,0 +1,450 @@
+package ai
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	_ "modernc.org/sqlite"
+)
+
+// sqliteSchema 历史与模板表结构。全文索引使用 trigram 分词，支持中文子串检索
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS content_history (
+	id            TEXT PRIMARY KEY,
+	platform      TEXT NOT NULL DEFAULT '',
+	type          TEXT NOT NULL DEFAULT '',
+	title         TEXT NOT NULL DEFAULT '',
+	content       TEXT NOT NULL DEFAULT '',
+	original_text TEXT NOT NULL DEFAULT '',
+	prompt        TEXT NOT NULL DEFAULT '',
+	template      TEXT NOT NULL DEFAULT '',
+	provider      TEXT NOT NULL DEFAULT '',
+	model         TEXT NOT NULL DEFAULT '',
+	input_tokens  INTEGER NOT NULL DEFAULT 0,
+	output_tokens INTEGER NOT NULL DEFAULT 0,
+	total_tokens  INTEGER NOT NULL DEFAULT 0,
+	rating        INTEGER NOT NULL DEFAULT 0,
+	tags          TEXT NOT NULL DEFAULT '[]',
+	metadata      TEXT NOT NULL DEFAULT '{}',
+	created_at    INTEGER NOT NULL,
+	published_at  INTEGER
+);
+CREATE INDEX IF NOT EXISTS idx_history_created ON content_history(created_at);
+CREATE INDEX IF NOT EXISTS idx_history_platform ON content_history(platform, created_at);
+CREATE INDEX IF NOT EXISTS idx_history_type ON content_history(type, created_at);
+CREATE INDEX IF NOT EXISTS idx_history_model ON content_history(model);
+
+CREATE VIRTUAL TABLE IF NOT EXISTS content_history_fts USING fts5(
+	title, content, content='content_history', content_rowid='rowid', tokenize='trigram'
+);
+CREATE TRIGGER IF NOT EXISTS content_history_ai AFTER INSERT ON content_history BEGIN
+	INSERT INTO content_history_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+END;
+CREATE TRIGGER IF NOT EXISTS content_history_ad AFTER DELETE ON content_history BEGIN
+	INSERT INTO content_history_fts(content_history_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+END;
+CREATE TRIGGER IF NOT EXISTS content_history_au AFTER UPDATE ON content_history BEGIN
+	INSERT INTO content_history_fts(content_history_fts, rowid, title, content) VALUES ('delete', old.rowid, old.title, old.content);
+	INSERT INTO content_history_fts(rowid, title, content) VALUES (new.rowid, new.title, new.content);
+END;
+
+CREATE TABLE IF NOT EXISTS content_templates (
+	id         TEXT PRIMARY KEY,
+	platform   TEXT NOT NULL DEFAULT '',
+	category   TEXT NOT NULL DEFAULT '',
+	data       TEXT NOT NULL,
+	updated_at INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_templates_platform ON content_templates(platform, category);
+`
+
+// OpenSQLite 打开（或创建）SQLite 数据库并初始化表结构，历史与模板存储可共用同一个连接
+func OpenSQLite(path string) (*sql.DB, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("sqlite", path+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	// SQLite 单写者，限制连接数避免 database is locked
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("init schema: %w", err)
+	}
+	return db, nil
+}
+
+// SQLiteHistoryStorage SQLite 历史存储实现
+type SQLiteHistoryStorage struct {
+	db *sql.DB
+}
+
+func NewSQLiteHistoryStorage(db *sql.DB) *SQLiteHistoryStorage {
+	return &SQLiteHistoryStorage{db: db}
+}
+
+const historyColumns = `id, platform, type, title, content, original_text, prompt, template, provider, model,
+	input_tokens, output_tokens, total_tokens, rating, tags, metadata, created_at, published_at`
+
+func (s *SQLiteHistoryStorage) Save(history *ContentHistory) error {
+	tags, err := json.Marshal(history.Tags)
+	if err != nil {
+		return err
+	}
+	metadata, err := json.Marshal(history.Metadata)
+	if err != nil {
+		return err
+	}
+
+	var publishedAt any
+	if history.PublishedAt != nil {
+		publishedAt = history.PublishedAt.UnixNano()
+	}
+
+	_, err = s.db.Exec(`INSERT INTO content_history (`+historyColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			platform = excluded.platform, type = excluded.type, title = excluded.title,
+			content = excluded.content, original_text = excluded.original_text, prompt = excluded.prompt,
+			template = excluded.template, provider = excluded.provider, model = excluded.model,
+			input_tokens = excluded.input_tokens, output_tokens = excluded.output_tokens,
+			total_tokens = excluded.total_tokens, rating = excluded.rating, tags = excluded.tags,
+			metadata = excluded.metadata, created_at = excluded.created_at, published_at = excluded.published_at`,
+		history.ID, history.Platform, history.Type, history.Title, history.Content, history.OriginalText,
+		history.Prompt, history.Template, history.Provider, history.Model,
+		history.Tokens.Input, history.Tokens.Output, history.Tokens.Total, history.Rating,
+		string(tags), string(metadata), history.CreatedAt.UnixNano(), publishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("save history: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteHistoryStorage) Load(id string) (*ContentHistory, error) {
+	row := s.db.QueryRow(`SELECT `+historyColumns+` FROM content_history WHERE id = ?`, id)
+	h, err := scanHistory(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("history not found: %s", id)
+	}
+	return h, err
+}
+
+func (s *SQLiteHistoryStorage) List(filter HistoryFilter) ([]*ContentHistory, error) {
+	where, args := historyWhere(filter)
+
+	orderBy := "h.created_at"
+	switch filter.SortBy {
+	case HistorySortRating:
+		orderBy = "h.rating %[1]s, h.created_at"
+	case HistorySortTokens:
+		orderBy = "h.total_tokens %[1]s, h.created_at"
+	}
+	order := "DESC"
+	if filter.SortAsc {
+		order = "ASC"
+	}
+	orderBy = fmt.Sprintf(orderBy+" %[1]s", order)
+
+	query := `SELECT ` + prefixColumns("h.", historyColumns) + ` FROM content_history h` + where + ` ORDER BY ` + orderBy
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	} else if filter.Offset > 0 {
+		query += ` LIMIT -1`
+	}
+	if filter.Offset > 0 {
+		query += ` OFFSET ?`
+		args = append(args, filter.Offset)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list history: %w", err)
+	}
+	defer rows.Close()
+
+	histories := make([]*ContentHistory, 0)
+	for rows.Next() {
+		h, err := scanHistory(rows)
+		if err != nil {
+			return nil, err
+		}
+		histories = append(histories, h)
+	}
+	return histories, rows.Err()
+}
+
+func (s *SQLiteHistoryStorage) Delete(id string) error {
+	res, err := s.db.Exec(`DELETE FROM content_history WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return fmt.Errorf("history not found: %s", id)
+	}
+	return nil
+}
+
+func (s *SQLiteHistoryStorage) GetStats(platform string, days int) (*HistoryStats, error) {
+	filter := HistoryFilter{Platform: platform}
+	if days > 0 {
+		startDate := time.Now().AddDate(0, 0, -days)
+		filter.StartDate = &startDate
+	}
+	where, args := historyWhere(filter)
+
+	stats := &HistoryStats{
+		PlatformStats: make(map[string]int),
+		TypeStats:     make(map[string]int),
+		TopModels:     make([]ModelUsage, 0),
+	}
+
+	var avgRating sql.NullFloat64
+	err := s.db.QueryRow(`SELECT COUNT(*), COUNT(h.published_at),
+			COALESCE(SUM(h.input_tokens), 0), COALESCE(SUM(h.output_tokens), 0), COALESCE(SUM(h.total_tokens), 0),
+			AVG(NULLIF(h.rating, 0))
+		FROM content_history h`+where, args...).Scan(
+		&stats.TotalGenerated, &stats.TotalPublished,
+		&stats.TotalTokens.Input, &stats.TotalTokens.Output, &stats.TotalTokens.Total,
+		&avgRating,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("query stats: %w", err)
+	}
+	stats.AvgRating = avgRating.Float64
+
+	if err := s.groupCount(`h.platform`, where, args, stats.PlatformStats); err != nil {
+		return nil, err
+	}
+	if err := s.groupCount(`h.type`, where, args, stats.TypeStats); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.Query(`SELECT h.model, COUNT(*) AS cnt, AVG(NULLIF(h.rating, 0))
+		FROM content_history h`+where+`
+		GROUP BY h.model ORDER BY cnt DESC, h.model ASC LIMIT ?`, append(args, topModelsLimit)...)
+	if err != nil {
+		return nil, fmt.Errorf("query top models: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var usage ModelUsage
+		var rating sql.NullFloat64
+		if err := rows.Scan(&usage.Model, &usage.Count, &rating); err != nil {
+			return nil, err
+		}
+		usage.AvgRating = int(math.Round(rating.Float64))
+		stats.TopModels = append(stats.TopModels, usage)
+	}
+	return stats, rows.Err()
+}
+
+func (s *SQLiteHistoryStorage) groupCount(column, where string, args []any, dst map[string]int) error {
+	rows, err := s.db.Query(`SELECT `+column+`, COUNT(*) FROM content_history h`+where+` GROUP BY `+column, args...)
+	if err != nil {
+		return fmt.Errorf("group by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key string
+		var count int
+		if err := rows.Scan(&key, &count); err != nil {
+			return err
+		}
+		dst[key] = count
+	}
+	return rows.Err()
+}
+
+// historyWhere 构造过滤条件；查询词不足 3 个字符时 trigram 索引无法命中，退化为 LIKE
+func historyWhere(filter HistoryFilter) (string, []any) {
+	var conds []string
+	var args []any
+
+	if filter.Platform != "" {
+		conds = append(conds, "h.platform = ?")
+		args = append(args, filter.Platform)
+	}
+	if filter.Type != "" {
+		conds = append(conds, "h.type = ?")
+		args = append(args, filter.Type)
+	}
+	if filter.StartDate != nil {
+		conds = append(conds, "h.created_at >= ?")
+		args = append(args, filter.StartDate.UnixNano())
+	}
+	if filter.EndDate != nil {
+		conds = append(conds, "h.created_at <= ?")
+		args = append(args, filter.EndDate.UnixNano())
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		if utf8.RuneCountInString(q) >= 3 {
+			conds = append(conds, "h.rowid IN (SELECT rowid FROM content_history_fts WHERE content_history_fts MATCH ?)")
+			args = append(args, `"`+strings.ReplaceAll(q, `"`, `""`)+`"`)
+		} else {
+			conds = append(conds, "(h.title LIKE ? OR h.content LIKE ?)")
+			like := "%" + q + "%"
+			args = append(args, like, like)
+		}
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanHistory(row rowScanner) (*ContentHistory, error) {
+	var h ContentHistory
+	var tags, metadata string
+	var createdAt int64
+	var publishedAt sql.NullInt64
+
+	err := row.Scan(&h.ID, &h.Platform, &h.Type, &h.Title, &h.Content, &h.OriginalText, &h.Prompt,
+		&h.Template, &h.Provider, &h.Model, &h.Tokens.Input, &h.Tokens.Output, &h.Tokens.Total,
+		&h.Rating, &tags, &metadata, &createdAt, &publishedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = json.Unmarshal([]byte(tags), &h.Tags)
+	_ = json.Unmarshal([]byte(metadata), &h.Metadata)
+	h.CreatedAt = time.Unix(0, createdAt)
+	if publishedAt.Valid {
+		t := time.Unix(0, publishedAt.Int64)
+		h.PublishedAt = &t
+	}
+	return &h, nil
+}
+
+func prefixColumns(prefix, columns string) string {
+	parts := strings.Split(columns, ",")
+	for i, p := range parts {
+		parts[i] = prefix + strings.TrimSpace(p)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SQLiteTemplateStorage SQLite 模板存储实现
+type SQLiteTemplateStorage struct {
+	db *sql.DB
+}
+
+func NewSQLiteTemplateStorage(db *sql.DB) *SQLiteTemplateStorage {
+	return &SQLiteTemplateStorage{db: db}
+}
+
+func (s *SQLiteTemplateStorage) Save(template *ContentTemplate) error {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.db.Exec(`INSERT INTO content_templates (id, platform, category, data, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			platform = excluded.platform, category = excluded.category,
+			data = excluded.data, updated_at = excluded.updated_at`,
+		template.ID, template.Platform, template.Category, string(data), template.UpdatedAt.UnixNano(),
+	)
+	if err != nil {
+		return fmt.Errorf("save template: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLiteTemplateStorage) Load(id string) (*ContentTemplate, error) {
+	var data string
+	err := s.db.QueryRow(`SELECT data FROM content_templates WHERE id = ?`, id).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("template not found: %s", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var template ContentTemplate
+	if err := json.Unmarshal([]byte(data), &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+func (s *SQLiteTemplateStorage) List(filter TemplateFilter) ([]*ContentTemplate, error) {
+	var conds []string
+	var args []any
+
+	if filter.Platform != "" && filter.Platform != "all" {
+		conds = append(conds, "(platform = ? OR platform = 'all')")
+		args = append(args, filter.Platform)
+	}
+	if filter.Category != "" {
+		conds = append(conds, "category = ?")
+		args = append(args, filter.Category)
+	}
+
+	query := `SELECT data FROM content_templates`
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += ` ORDER BY updated_at DESC`
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	defer rows.Close()
+
+	templates := make([]*ContentTemplate, 0)
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var t ContentTemplate
+		if err := json.Unmarshal([]byte(data), &t); err != nil {
+			continue
+		}
+		if !hasAllTags(t.Tags, filter.Tags) {
+			continue
+		}
+		templates = append(templates, &t)
+		if filter.Limit > 0 && len(templates) >= filter.Limit {
+			break
+		}
+	}
+	return templates, rows.Err()
+}
+
+func (s *SQLiteTemplateStorage) Delete(id string) error {
+	_, err := s.db.Exec(`DELETE FROM content_templates WHERE id = ?`, id)
+	return err
+}
+
+func hasAllTags(tags, required []string) bool {
+	for _, r := range required {
+		if !slices.Contains(tags, r) {
+			return false
+		}
+	}
+	return true
+}
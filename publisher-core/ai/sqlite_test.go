@@ -0,0 +1,197 @@
+package ai
+
+import (
+	"database/sql"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func openTestSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := OpenSQLite(filepath.Join(t.TempDir(), "publisher.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// seedHistories 按创建时间从早到晚写入 h1..h5
+func seedHistories(t *testing.T, storage HistoryStorage, base time.Time) {
+	t.Helper()
+	histories := []*ContentHistory{
+		{ID: "h1", Platform: "xiaohongshu", Type: "generated", Title: "春季露营装备清单", Content: "帐篷和睡袋", Model: "deepseek-chat", Rating: 5, Tokens: TokenUsage{Total: 300}},
+		{ID: "h2", Platform: "douyin", Type: "rewritten", Title: "周末去哪儿", Content: "城市周边露营地推荐", Model: "deepseek-chat", Rating: 3, Tokens: TokenUsage{Total: 900}},
+		{ID: "h3", Platform: "xiaohongshu", Type: "rewritten", Title: "咖啡探店", Content: "手冲咖啡", Model: "gemini-2.5-flash", Rating: 4, Tokens: TokenUsage{Total: 100}},
+		{ID: "h4", Platform: "toutiao", Type: "generated", Title: "科技新闻速递", Content: "新品发布会", Model: "deepseek-chat", Tokens: TokenUsage{Total: 500}},
+		{ID: "h5", Platform: "xiaohongshu", Type: "generated", Title: "露营美食", Content: "烧烤食材", Model: "gemini-2.5-flash", Rating: 2, Tokens: TokenUsage{Total: 700}},
+	}
+	for i, h := range histories {
+		h.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		if err := storage.Save(h); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func historyIDs(histories []*ContentHistory) []string {
+	ids := make([]string, len(histories))
+	for i, h := range histories {
+		ids[i] = h.ID
+	}
+	return ids
+}
+
+func TestSQLiteHistoryList(t *testing.T) {
+	storage := NewSQLiteHistoryStorage(openTestSQLite(t))
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	seedHistories(t, storage, base)
+
+	start := base.Add(1 * time.Hour)
+	end := base.Add(3 * time.Hour)
+	tests := []struct {
+		name   string
+		filter HistoryFilter
+		want   []string
+	}{
+		{name: "默认按创建时间倒序", filter: HistoryFilter{}, want: []string{"h5", "h4", "h3", "h2", "h1"}},
+		{name: "按创建时间升序", filter: HistoryFilter{SortAsc: true}, want: []string{"h1", "h2", "h3", "h4", "h5"}},
+		{name: "按评分倒序", filter: HistoryFilter{SortBy: HistorySortRating}, want: []string{"h1", "h3", "h2", "h5", "h4"}},
+		{name: "按 Token 升序", filter: HistoryFilter{SortBy: HistorySortTokens, SortAsc: true}, want: []string{"h3", "h1", "h4", "h5", "h2"}},
+		{name: "按平台过滤", filter: HistoryFilter{Platform: "xiaohongshu"}, want: []string{"h5", "h3", "h1"}},
+		{name: "按类型过滤", filter: HistoryFilter{Type: "rewritten"}, want: []string{"h3", "h2"}},
+		{name: "按日期范围过滤", filter: HistoryFilter{StartDate: &start, EndDate: &end}, want: []string{"h4", "h3", "h2"}},
+		{name: "全文搜索标题和正文", filter: HistoryFilter{Query: "露营地推荐"}, want: []string{"h2"}},
+		{name: "短查询词", filter: HistoryFilter{Query: "露营"}, want: []string{"h5", "h2", "h1"}},
+		{name: "组合过滤", filter: HistoryFilter{Platform: "xiaohongshu", Query: "露营"}, want: []string{"h5", "h1"}},
+		{name: "分页", filter: HistoryFilter{Limit: 2, Offset: 1}, want: []string{"h4", "h3"}},
+		{name: "只有偏移", filter: HistoryFilter{Offset: 3}, want: []string{"h2", "h1"}},
+		{name: "超出范围", filter: HistoryFilter{Limit: 2, Offset: 10}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.List(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ids := historyIDs(got); !slices.Equal(ids, tt.want) {
+				t.Errorf("List() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLiteHistorySaveLoadDelete(t *testing.T) {
+	storage := NewSQLiteHistoryStorage(openTestSQLite(t))
+	published := time.Date(2024, 5, 2, 9, 0, 0, 0, time.Local)
+	h := &ContentHistory{
+		ID: "h1", Platform: "xiaohongshu", Title: "春季露营", Content: "帐篷",
+		Tags: []string{"露营"}, Metadata: map[string]interface{}{"source": "test"},
+		CreatedAt: time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local), PublishedAt: &published,
+	}
+	if err := storage.Save(h); err != nil {
+		t.Fatal(err)
+	}
+
+	// 同 ID 再次保存覆盖原记录，全文索引同步更新
+	h.Title = "夏季海边"
+	if err := storage.Save(h); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := storage.Load("h1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Title != "夏季海边" || !slices.Equal(loaded.Tags, []string{"露营"}) || loaded.Metadata["source"] != "test" ||
+		!loaded.CreatedAt.Equal(h.CreatedAt) || loaded.PublishedAt == nil || !loaded.PublishedAt.Equal(published) {
+		t.Errorf("Load() = %+v", loaded)
+	}
+	if got, _ := storage.List(HistoryFilter{Query: "春季露营"}); len(got) != 0 {
+		t.Errorf("stale full-text entry still matches: %v", historyIDs(got))
+	}
+	if got, _ := storage.List(HistoryFilter{Query: "夏季海边"}); len(got) != 1 {
+		t.Errorf("updated title not searchable: %v", historyIDs(got))
+	}
+
+	if err := storage.Delete("h1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := storage.Load("h1"); err == nil {
+		t.Error("deleted history should not load")
+	}
+	if err := storage.Delete("h1"); err == nil {
+		t.Error("deleting a missing history should fail")
+	}
+}
+
+func TestSQLiteHistoryStats(t *testing.T) {
+	storage := NewSQLiteHistoryStorage(openTestSQLite(t))
+	seedHistories(t, storage, time.Now().Add(-6*time.Hour))
+
+	stats, err := storage.GetStats("", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalGenerated != 5 || stats.TotalTokens.Total != 2500 || stats.AvgRating != 3.5 {
+		t.Errorf("stats = %+v", stats)
+	}
+	if stats.PlatformStats["xiaohongshu"] != 3 || stats.TypeStats["generated"] != 3 {
+		t.Errorf("group stats = %v, %v", stats.PlatformStats, stats.TypeStats)
+	}
+	want := []ModelUsage{{Model: "deepseek-chat", Count: 3, AvgRating: 4}, {Model: "gemini-2.5-flash", Count: 2, AvgRating: 3}}
+	if !slices.Equal(stats.TopModels, want) {
+		t.Errorf("TopModels = %+v, want %+v", stats.TopModels, want)
+	}
+
+	stats, err = storage.GetStats("douyin", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.TotalGenerated != 1 || len(stats.TopModels) != 1 {
+		t.Errorf("douyin stats = %+v", stats)
+	}
+}
+
+func TestSQLiteTemplateList(t *testing.T) {
+	storage := NewSQLiteTemplateStorage(openTestSQLite(t))
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.Local)
+	templates := []*ContentTemplate{
+		{ID: "t1", Platform: "xiaohongshu", Category: "生活", Tags: []string{"种草", "好物"}, UpdatedAt: base},
+		{ID: "t2", Platform: "all", Category: "教程", Tags: []string{"干货"}, UpdatedAt: base.Add(time.Hour)},
+		{ID: "t3", Platform: "douyin", Category: "生活", Tags: []string{"种草"}, UpdatedAt: base.Add(2 * time.Hour)},
+	}
+	for _, tmpl := range templates {
+		if err := storage.Save(tmpl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		filter TemplateFilter
+		want   []string
+	}{
+		{name: "全部按更新时间倒序", filter: TemplateFilter{}, want: []string{"t3", "t2", "t1"}},
+		{name: "平台包含通用模板", filter: TemplateFilter{Platform: "xiaohongshu"}, want: []string{"t2", "t1"}},
+		{name: "按分类", filter: TemplateFilter{Category: "生活"}, want: []string{"t3", "t1"}},
+		{name: "按标签", filter: TemplateFilter{Tags: []string{"种草", "好物"}}, want: []string{"t1"}},
+		{name: "数量限制", filter: TemplateFilter{Limit: 1}, want: []string{"t3"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := storage.List(tt.filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids := make([]string, len(got))
+			for i, tmpl := range got {
+				ids[i] = tmpl.ID
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("List() = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -1,40 +1,60 @@
 package ai
 
 import (
+	"embed"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/monkeycode/publisher-core/ai/prompts"
+	"github.com/sirupsen/logrus"
 )
 
 // ContentTemplate 内容模板
 type ContentTemplate struct {
-	ID          string                 `json:"id"`
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	Platform    string                 `json:"platform"`
-	Category    string                 `json:"category"` // 新闻、教程、生活、娱乐等
-	Template    string                 `json:"template"`
-	Variables   []TemplateVariable     `json:"variables"`
-	Examples    []string               `json:"examples"`
-	Tags        []string               `json:"tags"`
-	CreatedAt   time.Time              `json:"created_at"`
-	UpdatedAt   time.Time              `json:"updated_at"`
-	UsageCount  int                    `json:"usage_count"`
-	Rating      float64                `json:"rating"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Platform    string             `json:"platform"`
+	Category    string             `json:"category"` // 新闻、教程、生活、娱乐等
+	Template    string             `json:"template"`
+	Variables   []TemplateVariable `json:"variables"`
+	Examples    []string           `json:"examples"`
+	Tags        []string           `json:"tags"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+	UsageCount  int                `json:"usage_count"`
+	Rating      float64            `json:"rating"`
+
+	// 内置模板版本管理：Builtin 表示来自内置模板库，BaseVersion 记录用户修改时所基于的内置版本
+	Version     int  `json:"version,omitempty"`
+	Builtin     bool `json:"builtin,omitempty"`
+	BaseVersion int  `json:"base_version,omitempty"`
 }
 
+// 模板变量类型
+const (
+	VariableText        = "text"
+	VariableNumber      = "number"
+	VariableSelect      = "select"
+	VariableMultiSelect = "multiselect" // 取值以逗号分隔
+)
+
 // TemplateVariable 模板变量
 type TemplateVariable struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Type        string `json:"type"` // text, number, select, multiselect
-	Required    bool   `json:"required"`
-	Default     string `json:"default"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Type        string   `json:"type"` // text, number, select, multiselect
+	Required    bool     `json:"required"`
+	Default     string   `json:"default"`
 	Options     []string `json:"options,omitempty"`
 }
 
@@ -42,6 +62,7 @@ type TemplateVariable struct {
 type TemplateManager struct {
 	mu        sync.RWMutex
 	templates map[string]*ContentTemplate
+	builtins  map[string]*ContentTemplate
 	storage   TemplateStorage
 }
 
@@ -65,123 +86,84 @@ type TemplateFilter struct {
 func NewTemplateManager(storage TemplateStorage) *TemplateManager {
 	tm := &TemplateManager{
 		templates: make(map[string]*ContentTemplate),
+		builtins:  make(map[string]*ContentTemplate),
 		storage:   storage,
 	}
 	tm.loadDefaults()
+	tm.loadStored()
 	return tm
 }
 
-// loadDefaults 加载默认模板
+//go:embed defaults/*.json
+var defaultTemplatesFS embed.FS
+
+// LoadBuiltinTemplates 读取内置模板库，每个平台一个 JSON 文件
+func LoadBuiltinTemplates() ([]*ContentTemplate, error) {
+	files, err := fs.Glob(defaultTemplatesFS, "defaults/*.json")
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*ContentTemplate, 0)
+	for _, file := range files {
+		data, err := defaultTemplatesFS.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var items []*ContentTemplate
+		if err := json.Unmarshal(data, &items); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", file, err)
+		}
+		for _, t := range items {
+			t.Builtin = true
+			if t.Version == 0 {
+				t.Version = 1
+			}
+		}
+		templates = append(templates, items...)
+	}
+	return templates, nil
+}
+
+// loadDefaults 加载内置模板库
 func (tm *TemplateManager) loadDefaults() {
-	defaults := []*ContentTemplate{
-		{
-			ID:          "news-hotspot",
-			Name:        "热点新闻评论",
-			Description: "针对热点事件生成评论性内容",
-			Platform:    "all",
-			Category:    "新闻",
-			Template:    "【{title}】{event}
-
-{comment}
-
-#热点解读 #{tags}",
-			Variables: []TemplateVariable{
-				{Name: "title", Description: "标题", Type: "text", Required: true},
-				{Name: "event", Description: "事件描述", Type: "text", Required: true},
-				{Name: "comment", Description: "评论内容", Type: "text", Required: true},
-				{Name: "tags", Description: "话题标签", Type: "text", Required: false},
-			},
-			Tags:      []string{"热点", "新闻", "评论"},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		{
-			ID:          "tutorial-guide",
-			Name:        "教程指南",
-			Description: "生成教程类内容",
-			Platform:    "xiaohongshu",
-			Category:    "教程",
-			Template:    "【{title}】
-
-✨ {intro}
-
-📝 {steps}
-
-💡 {tips}
-
-#{tags}",
-			Variables: []TemplateVariable{
-				{Name: "title", Description: "教程标题", Type: "text", Required: true},
-				{Name: "intro", Description: "简介", Type: "text", Required: true},
-				{Name: "steps", Description: "步骤说明", Type: "text", Required: true},
-				{Name: "tips", Description: "小贴士", Type: "text", Required: false},
-				{Name: "tags", Description: "话题标签", Type: "text", Required: false},
-			},
-			Tags:      []string{"教程", "指南", "干货"},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		{
-			ID:          "lifestyle-share",
-			Name:        "生活分享",
-			Description: "生活类内容分享模板",
-			Platform:    "xiaohongshu",
-			Category:    "生活",
-			Template:    "【{title}】
-
-{content}
-
-💭 {thoughts}
-
-📍 {location}
-
-#{tags}",
-			Variables: []TemplateVariable{
-				{Name: "title", Description: "标题", Type: "text", Required: true},
-				{Name: "content", Description: "内容", Type: "text", Required: true},
-				{Name: "thoughts", Description: "感悟", Type: "text", Required: false},
-				{Name: "location", Description: "地点", Type: "text", Required: false},
-				{Name: "tags", Description: "话题标签", Type: "text", Required: false},
-			},
-			Tags:      []string{"生活", "分享", "日常"},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
-		{
-			ID:          "entertainment-review",
-			Name:        "娱乐测评",
-			Description: "娱乐类内容测评模板",
-			Platform:    "douyin",
-			Category:    "娱乐",
-			Template:    "【{title}】
-
-🎯 {overview}
-
-✅ 优点：{pros}
-
-❌ 缺点：{cons}
-
-💰 价格：{price}
-
-💭 总结：{summary}
-
-#{tags}",
-			Variables: []TemplateVariable{
-				{Name: "title", Description: "测评标题", Type: "text", Required: true},
-				{Name: "overview", Description: "概述", Type: "text", Required: true},
-				{Name: "pros", Description: "优点", Type: "text", Required: true},
-				{Name: "cons", Description: "缺点", Type: "text", Required: true},
-				{Name: "price", Description: "价格", Type: "text", Required: false},
-				{Name: "summary", Description: "总结", Type: "text", Required: true},
-				{Name: "tags", Description: "话题标签", Type: "text", Required: false},
-			},
-			Tags:      []string{"测评", "娱乐", "推荐"},
-			CreatedAt: time.Now(),
-			UpdatedAt: time.Now(),
-		},
+	defaults, err := LoadBuiltinTemplates()
+	if err != nil {
+		logrus.Warnf("load builtin templates failed: %v", err)
+		return
 	}
 
 	for _, t := range defaults {
+		if err := t.Validate(); err != nil {
+			logrus.Warnf("skip invalid builtin template %s: %v", t.ID, err)
+			continue
+		}
+		tm.builtins[t.ID] = t
+		c := *t
+		tm.templates[t.ID] = &c
+	}
+}
+
+// loadStored 加载用户模板，同 ID 的用户模板覆盖内置模板
+func (tm *TemplateManager) loadStored() {
+	if tm.storage == nil {
+		return
+	}
+
+	stored, err := tm.storage.List(TemplateFilter{})
+	if err != nil {
+		logrus.Warnf("load stored templates failed: %v", err)
+		return
+	}
+
+	for _, t := range stored {
+		if b, ok := tm.builtins[t.ID]; ok {
+			t.Builtin = false
+			if t.BaseVersion < b.Version {
+				logrus.Infof("builtin template %s upgraded to v%d, keeping user edits based on v%d", t.ID, b.Version, t.BaseVersion)
+			}
+		}
 		tm.templates[t.ID] = t
 	}
 }
@@ -191,6 +173,10 @@ func (tm *TemplateManager) CreateTemplate(template *ContentTemplate) error {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
 	if template.ID == "" {
 		template.ID = uuid.New().String()
 	}
@@ -246,6 +232,17 @@ func (tm *TemplateManager) UpdateTemplate(template *ContentTemplate) error {
 	if _, exists := tm.templates[template.ID]; !exists {
 		return fmt.Errorf("template not found: %s", template.ID)
 	}
+	if err := template.Validate(); err != nil {
+		return err
+	}
+
+	// 修改内置模板时另存为用户模板，升级内置库不会覆盖
+	if b, ok := tm.builtins[template.ID]; ok {
+		if template.Builtin || template.BaseVersion == 0 {
+			template.BaseVersion = b.Version
+		}
+		template.Builtin = false
+	}
 
 	template.UpdatedAt = time.Now()
 	tm.templates[template.ID] = template
@@ -256,12 +253,20 @@ func (tm *TemplateManager) UpdateTemplate(template *ContentTemplate) error {
 	return nil
 }
 
-// DeleteTemplate 删除模板
+// DeleteTemplate 删除模板，删除被修改过的内置模板时恢复为内置版本
 func (tm *TemplateManager) DeleteTemplate(id string) error {
 	tm.mu.Lock()
 	defer tm.mu.Unlock()
 
-	delete(tm.templates, id)
+	if b, ok := tm.builtins[id]; ok {
+		if t, exists := tm.templates[id]; exists && t.Builtin {
+			return fmt.Errorf("builtin template cannot be deleted: %s", id)
+		}
+		c := *b
+		tm.templates[id] = &c
+	} else {
+		delete(tm.templates, id)
+	}
 
 	if tm.storage != nil {
 		return tm.storage.Delete(id)
@@ -269,6 +274,33 @@ func (tm *TemplateManager) DeleteTemplate(id string) error {
 	return nil
 }
 
+// ResetTemplate 丢弃用户修改，恢复为最新的内置模板
+func (tm *TemplateManager) ResetTemplate(id string) error {
+	tm.mu.RLock()
+	_, ok := tm.builtins[id]
+	tm.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("builtin template not found: %s", id)
+	}
+	return tm.DeleteTemplate(id)
+}
+
+// OutdatedOverrides 列出基于旧版内置模板修改、且内置模板已升级的用户模板
+func (tm *TemplateManager) OutdatedOverrides() []*ContentTemplate {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	result := make([]*ContentTemplate, 0)
+	for id, b := range tm.builtins {
+		t, ok := tm.templates[id]
+		if ok && !t.Builtin && t.BaseVersion < b.Version {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // ApplyTemplate 应用模板
 func (tm *TemplateManager) ApplyTemplate(templateID string, values map[string]string) (string, error) {
 	template, err := tm.GetTemplate(templateID)
@@ -276,16 +308,14 @@ func (tm *TemplateManager) ApplyTemplate(templateID string, values map[string]st
 		return "", err
 	}
 
-	result := template.Template
-	for _, v := range template.Variables {
-		value, ok := values[v.Name]
-		if !ok {
-			if v.Required {
-				return "", fmt.Errorf("missing required variable: %s", v.Name)
-			}
-			value = v.Default
-		}
-		result = replaceAll(result, "{"+v.Name+"}", value)
+	data, err := template.BuildData(values)
+	if err != nil {
+		return "", err
+	}
+
+	result, err := prompts.Render(template.ID, template.normalizedTemplate(), data, prompts.RenderOptions{Strict: true})
+	if err != nil {
+		return "", err
 	}
 
 	// 更新使用次数
@@ -296,6 +326,118 @@ func (tm *TemplateManager) ApplyTemplate(templateID string, values map[string]st
 	return result, nil
 }
 
+// Validate 校验模板语法及变量定义
+func (t *ContentTemplate) Validate() error {
+	seen := make(map[string]bool, len(t.Variables))
+	for _, v := range t.Variables {
+		if v.Name == "" {
+			return fmt.Errorf("variable name is required")
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("duplicate variable: %s", v.Name)
+		}
+		seen[v.Name] = true
+
+		switch v.Type {
+		case "", VariableText, VariableNumber:
+		case VariableSelect, VariableMultiSelect:
+			if len(v.Options) == 0 {
+				return fmt.Errorf("variable %s: %s requires options", v.Name, v.Type)
+			}
+		default:
+			return fmt.Errorf("variable %s: unknown type %q", v.Name, v.Type)
+		}
+
+		if v.Default != "" {
+			if _, err := v.Parse(v.Default); err != nil {
+				return fmt.Errorf("variable %s: invalid default: %w", v.Name, err)
+			}
+		}
+	}
+
+	if _, err := prompts.Parse(t.ID, t.normalizedTemplate(), prompts.RenderOptions{Strict: true}); err != nil {
+		return fmt.Errorf("parse template: %w", err)
+	}
+	return nil
+}
+
+// BuildData 校验变量取值并转换为模板数据：number 转为 float64，multiselect 转为 []string
+func (t *ContentTemplate) BuildData(values map[string]string) (map[string]any, error) {
+	data := make(map[string]any, len(t.Variables))
+	for _, v := range t.Variables {
+		raw, ok := values[v.Name]
+		if !ok || raw == "" {
+			if v.Required && v.Default == "" {
+				return nil, fmt.Errorf("missing required variable: %s", v.Name)
+			}
+			raw = v.Default
+		}
+
+		// 可选变量未提供时置空，模板中可用 {{if .name}} 判断
+		if raw == "" {
+			if v.Type == VariableMultiSelect {
+				data[v.Name] = []string{}
+			} else {
+				data[v.Name] = ""
+			}
+			continue
+		}
+
+		value, err := v.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", v.Name, err)
+		}
+		data[v.Name] = value
+	}
+	return data, nil
+}
+
+// normalizedTemplate 兼容旧版 {name} 占位符，转换为 {{.name}}
+func (t *ContentTemplate) normalizedTemplate() string {
+	if strings.Contains(t.Template, "{{") {
+		return t.Template
+	}
+
+	result := t.Template
+	for _, v := range t.Variables {
+		result = strings.ReplaceAll(result, "{"+v.Name+"}", "{{."+v.Name+"}}")
+	}
+	return result
+}
+
+// Parse 按变量类型解析取值
+func (v TemplateVariable) Parse(raw string) (any, error) {
+	switch v.Type {
+	case VariableNumber:
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", raw)
+		}
+		return n, nil
+	case VariableSelect:
+		if !slices.Contains(v.Options, raw) {
+			return nil, fmt.Errorf("%q is not one of %v", raw, v.Options)
+		}
+		return raw, nil
+	case VariableMultiSelect:
+		items := strings.FieldsFunc(raw, func(r rune) bool { return r == ',' || r == '，' })
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			if !slices.Contains(v.Options, item) {
+				return nil, fmt.Errorf("%q is not one of %v", item, v.Options)
+			}
+			selected = append(selected, item)
+		}
+		return selected, nil
+	default:
+		return raw, nil
+	}
+}
+
 // JSONTemplateStorage JSON文件存储实现
 type JSONTemplateStorage struct {
 	dataDir string
@@ -356,30 +498,3 @@ func (s *JSONTemplateStorage) Delete(id string) error {
 	path := filepath.Join(s.dataDir, id+".json")
 	return os.Remove(path)
 }
-
-func replaceAll(s, old, new string) string {
-	for {
-		replaced := replaceFirst(s, old, new)
-		if replaced == s {
-			return s
-		}
-		s = replaced
-	}
-}
-
-func replaceFirst(s, old, new string) string {
-	idx := indexOf(s, old)
-	if idx == -1 {
-		return s
-	}
-	return s[:idx] + new + s[idx+len(old):]
-}
-
-func indexOf(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
-	}
-	return -1
-}
@@ -0,0 +1,69 @@
+package ai
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestContentTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    ContentTemplate
+		wantErr bool
+	}{
+		{name: "合法", tmpl: ContentTemplate{
+			Template: "{{.topic}} {{if .tips}}{{.tips}}{{end}}",
+			Variables: []TemplateVariable{
+				{Name: "topic", Type: VariableText, Required: true},
+				{Name: "count", Type: VariableNumber, Default: "3"},
+				{Name: "style", Type: VariableSelect, Options: []string{"干货", "种草"}, Default: "干货"},
+				{Name: "tips", Type: VariableMultiSelect, Options: []string{"省钱", "避坑"}},
+			},
+		}},
+		{name: "旧版占位符", tmpl: ContentTemplate{Template: "主题：{topic}", Variables: []TemplateVariable{{Name: "topic"}}}},
+		{name: "变量名为空", tmpl: ContentTemplate{Variables: []TemplateVariable{{Type: VariableText}}}, wantErr: true},
+		{name: "变量重复", tmpl: ContentTemplate{Variables: []TemplateVariable{{Name: "a"}, {Name: "a"}}}, wantErr: true},
+		{name: "未知类型", tmpl: ContentTemplate{Variables: []TemplateVariable{{Name: "a", Type: "date"}}}, wantErr: true},
+		{name: "select 缺少选项", tmpl: ContentTemplate{Variables: []TemplateVariable{{Name: "a", Type: VariableSelect}}}, wantErr: true},
+		{name: "默认值不是数字", tmpl: ContentTemplate{Variables: []TemplateVariable{{Name: "a", Type: VariableNumber, Default: "三"}}}, wantErr: true},
+		{name: "默认值不在选项中", tmpl: ContentTemplate{Variables: []TemplateVariable{{Name: "a", Type: VariableSelect, Options: []string{"x"}, Default: "y"}}}, wantErr: true},
+		{name: "模板语法错误", tmpl: ContentTemplate{Template: "{{if .a}}"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.tmpl.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestContentTemplateBuildData(t *testing.T) {
+	tmpl := ContentTemplate{Variables: []TemplateVariable{
+		{Name: "topic", Type: VariableText, Required: true},
+		{Name: "count", Type: VariableNumber, Default: "3"},
+		{Name: "style", Type: VariableSelect, Options: []string{"干货", "种草"}},
+		{Name: "tips", Type: VariableMultiSelect, Options: []string{"省钱", "避坑"}},
+	}}
+
+	data, err := tmpl.BuildData(map[string]string{"topic": "露营", "tips": "省钱， 避坑"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]any{"topic": "露营", "count": float64(3), "style": "", "tips": []string{"省钱", "避坑"}}
+	if !reflect.DeepEqual(data, want) {
+		t.Errorf("BuildData() = %#v, want %#v", data, want)
+	}
+
+	errorCases := map[string]map[string]string{
+		"缺少必填变量":   {"count": "5"},
+		"数字格式错误":   {"topic": "露营", "count": "五"},
+		"选项不存在":    {"topic": "露营", "style": "段子"},
+		"多选包含未知选项": {"topic": "露营", "tips": "省钱,打折"},
+	}
+	for name, values := range errorCases {
+		if _, err := tmpl.BuildData(values); err == nil {
+			t.Errorf("%s: BuildData() should fail", name)
+		}
+	}
+}
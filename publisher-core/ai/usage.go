@@ -0,0 +1,458 @@
+package ai
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/monkeycode/publisher-core/ai/provider"
+	"github.com/sirupsen/logrus"
+)
+
+// ErrBudgetExceeded 预算已用尽
+var ErrBudgetExceeded = errors.New("AI usage budget exceeded")
+
+// ErrUnpricedModel 设置了费用预算，但价格表中没有该模型，无法计入费用
+var ErrUnpricedModel = errors.New("AI model has no price")
+
+// ModelPrice 模型单价（美元 / 百万 Token）
+type ModelPrice struct {
+	Input  float64 `json:"input"`
+	Output float64 `json:"output"`
+}
+
+// PriceTable 价格表，键为 "provider/model" 或 "model"
+type PriceTable map[string]ModelPrice
+
+// DefaultPriceTable 内置价格表
+func DefaultPriceTable() PriceTable {
+	return PriceTable{
+		"deepseek/deepseek-chat":                  {Input: 0.27, Output: 1.10},
+		"deepseek/deepseek-coder":                 {Input: 0.27, Output: 1.10},
+		"deepseek/deepseek-reasoner":              {Input: 0.55, Output: 2.19},
+		"groq/llama-3.3-70b-versatile":            {Input: 0.59, Output: 0.79},
+		"groq/llama-3.1-8b-instant":               {Input: 0.05, Output: 0.08},
+		"groq/llama-4-maverick-17b-128e-instruct": {Input: 0.20, Output: 0.60},
+		"groq/llama-4-scout-17b-16e-instruct":     {Input: 0.11, Output: 0.34},
+		"groq/qwen/qwen3-32b":                     {Input: 0.29, Output: 0.59},
+		"groq/deepseek-r1-distill-llama-70b":      {Input: 0.75, Output: 0.99},
+		"google/gemini-2.5-flash":                 {Input: 0.30, Output: 2.50},
+		"google/gemini-2.5-flash-lite":            {Input: 0.10, Output: 0.40},
+		"google/gemini-3-flash":                   {Input: 0.50, Output: 3.00},
+	}
+}
+
+// Lookup 查找模型单价，OpenRouter 的 ":free" 模型及 Gemma 系列视为免费
+func (pt PriceTable) Lookup(providerName, model string) (ModelPrice, bool) {
+	if p, ok := pt[providerName+"/"+model]; ok {
+		return p, true
+	}
+	if p, ok := pt[model]; ok {
+		return p, true
+	}
+	if strings.HasSuffix(model, ":free") || strings.HasPrefix(model, "gemma-") {
+		return ModelPrice{}, true
+	}
+	return ModelPrice{}, false
+}
+
+// Cost 计算费用，价格表中没有该模型时返回 0 和 false
+func (pt PriceTable) Cost(providerName, model string, inputTokens, outputTokens int) (float64, bool) {
+	p, ok := pt.Lookup(providerName, model)
+	if !ok {
+		return 0, false
+	}
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6, true
+}
+
+// UsageRecord 单次调用的用量记录
+type UsageRecord struct {
+	ID           string    `json:"id"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	Template     string    `json:"template,omitempty"`
+	Team         string    `json:"team,omitempty"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	Cost         float64   `json:"cost"`
+	Stream       bool      `json:"stream,omitempty"`
+	Estimated    bool      `json:"estimated,omitempty"` // Token 数为本地估算值
+	Unpriced     bool      `json:"unpriced,omitempty"`  // 价格表中没有该模型，费用按 0 计
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Budget 预算配置，零值表示不限制
+type Budget struct {
+	DailyTokens int     `json:"daily_tokens,omitempty"`
+	DailyCost   float64 `json:"daily_cost,omitempty"`
+}
+
+func (b Budget) exceeded(tokens int, cost float64) bool {
+	if b.DailyTokens > 0 && tokens >= b.DailyTokens {
+		return true
+	}
+	if b.DailyCost > 0 && cost >= b.DailyCost {
+		return true
+	}
+	return false
+}
+
+// UsageFilter 用量过滤器
+type UsageFilter struct {
+	Provider  string
+	Model     string
+	Template  string
+	Team      string
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+func (f UsageFilter) match(r *UsageRecord) bool {
+	if f.Provider != "" && r.Provider != f.Provider {
+		return false
+	}
+	if f.Model != "" && r.Model != f.Model {
+		return false
+	}
+	if f.Template != "" && r.Template != f.Template {
+		return false
+	}
+	if f.Team != "" && r.Team != f.Team {
+		return false
+	}
+	if f.StartDate != nil && r.CreatedAt.Before(*f.StartDate) {
+		return false
+	}
+	if f.EndDate != nil && r.CreatedAt.After(*f.EndDate) {
+		return false
+	}
+	return true
+}
+
+// UsageStats 聚合用量
+type UsageStats struct {
+	Requests     int     `json:"requests"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	TotalTokens  int     `json:"total_tokens"`
+	Cost         float64 `json:"cost"`
+}
+
+func (u *UsageStats) add(r *UsageRecord) {
+	u.Requests++
+	u.InputTokens += r.InputTokens
+	u.OutputTokens += r.OutputTokens
+	u.TotalTokens += r.InputTokens + r.OutputTokens
+	u.Cost += r.Cost
+}
+
+// UsageSummary 用量汇总
+type UsageSummary struct {
+	Total      UsageStats             `json:"total"`
+	ByProvider map[string]*UsageStats `json:"by_provider"`
+	ByModel    map[string]*UsageStats `json:"by_model"`
+	ByTemplate map[string]*UsageStats `json:"by_template"`
+}
+
+// UsageStorage 用量存储接口
+type UsageStorage interface {
+	Append(record *UsageRecord) error
+	List(filter UsageFilter) ([]*UsageRecord, error)
+}
+
+// UsageLedger 用量账本
+type UsageLedger struct {
+	mu      sync.Mutex
+	storage UsageStorage
+	prices  PriceTable
+	budget  Budget
+	teams   map[string]Budget
+
+	// 当日累计，用于预算判断
+	day       string
+	dayTotal  UsageStats
+	dayByTeam map[string]*UsageStats
+}
+
+// NewUsageLedger 创建用量账本，prices 为空时使用内置价格表
+func NewUsageLedger(storage UsageStorage, prices PriceTable) *UsageLedger {
+	if prices == nil {
+		prices = DefaultPriceTable()
+	}
+	return &UsageLedger{
+		storage:   storage,
+		prices:    prices,
+		teams:     make(map[string]Budget),
+		dayByTeam: make(map[string]*UsageStats),
+	}
+}
+
+// SetPrice 设置单个模型价格
+func (l *UsageLedger) SetPrice(key string, price ModelPrice) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prices[key] = price
+}
+
+// SetBudget 设置全局每日预算
+func (l *UsageLedger) SetBudget(b Budget) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.budget = b
+}
+
+// SetTeamBudget 设置团队每日预算
+func (l *UsageLedger) SetTeamBudget(team string, b Budget) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.teams[team] = b
+}
+
+// CheckBudget 检查全局及团队预算，超出时返回 ErrBudgetExceeded；
+// 设置了费用预算而模型没有价格时返回 ErrUnpricedModel，避免未计价的调用绕过预算
+func (l *UsageLedger) CheckBudget(team, providerName, model string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := l.rolloverLocked(time.Now()); err != nil {
+		return err
+	}
+
+	if l.budget.exceeded(l.dayTotal.TotalTokens, l.dayTotal.Cost) {
+		return fmt.Errorf("%w: daily limit reached", ErrBudgetExceeded)
+	}
+	costLimited := l.budget.DailyCost > 0
+
+	if b, ok := l.teams[team]; ok && team != "" {
+		var used UsageStats
+		if s, ok := l.dayByTeam[team]; ok {
+			used = *s
+		}
+		if b.exceeded(used.TotalTokens, used.Cost) {
+			return fmt.Errorf("%w: daily limit reached for team %s", ErrBudgetExceeded, team)
+		}
+		costLimited = costLimited || b.DailyCost > 0
+	}
+
+	if costLimited {
+		if _, ok := l.prices.Lookup(providerName, model); !ok {
+			return fmt.Errorf("%w: %s/%s", ErrUnpricedModel, providerName, model)
+		}
+	}
+	return nil
+}
+
+// Record 记录一次调用，自动补全 ID、时间和费用
+func (l *UsageLedger) Record(record *UsageRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if record.ID == "" {
+		record.ID = uuid.New().String()
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	cost, ok := l.prices.Cost(record.Provider, record.Model, record.InputTokens, record.OutputTokens)
+	record.Cost = cost
+	record.Unpriced = !ok
+	if !ok {
+		logrus.Warnf("no price for AI model %s/%s, cost recorded as 0", record.Provider, record.Model)
+	}
+
+	// 按当前日期滚动，补记的历史记录不会清空当日累计
+	if err := l.rolloverLocked(time.Now()); err != nil {
+		return err
+	}
+	if record.CreatedAt.Format("2006-01-02") == l.day {
+		l.addDayLocked(record)
+	}
+
+	if l.storage != nil {
+		return l.storage.Append(record)
+	}
+	return nil
+}
+
+// Summary 按 provider、model、template 汇总用量
+func (l *UsageLedger) Summary(filter UsageFilter) (*UsageSummary, error) {
+	if l.storage == nil {
+		return nil, fmt.Errorf("storage not initialized")
+	}
+
+	records, err := l.storage.List(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &UsageSummary{
+		ByProvider: make(map[string]*UsageStats),
+		ByModel:    make(map[string]*UsageStats),
+		ByTemplate: make(map[string]*UsageStats),
+	}
+	group := func(m map[string]*UsageStats, key string, r *UsageRecord) {
+		s, ok := m[key]
+		if !ok {
+			s = &UsageStats{}
+			m[key] = s
+		}
+		s.add(r)
+	}
+
+	for _, r := range records {
+		summary.Total.add(r)
+		group(summary.ByProvider, r.Provider, r)
+		group(summary.ByModel, r.Model, r)
+		group(summary.ByTemplate, r.Template, r)
+	}
+	return summary, nil
+}
+
+// rolloverLocked 跨天时从存储重建当日累计
+func (l *UsageLedger) rolloverLocked(now time.Time) error {
+	day := now.Format("2006-01-02")
+	if day == l.day {
+		return nil
+	}
+
+	l.day = day
+	l.dayTotal = UsageStats{}
+	l.dayByTeam = make(map[string]*UsageStats)
+
+	if l.storage == nil {
+		return nil
+	}
+
+	start, _ := time.ParseInLocation("2006-01-02", day, now.Location())
+	records, err := l.storage.List(UsageFilter{StartDate: &start})
+	if err != nil {
+		return fmt.Errorf("load today's usage: %w", err)
+	}
+	for _, r := range records {
+		l.addDayLocked(r)
+	}
+	return nil
+}
+
+func (l *UsageLedger) addDayLocked(r *UsageRecord) {
+	l.dayTotal.add(r)
+	if r.Team == "" {
+		return
+	}
+	s, ok := l.dayByTeam[r.Team]
+	if !ok {
+		s = &UsageStats{}
+		l.dayByTeam[r.Team] = s
+	}
+	s.add(r)
+}
+
+// EstimateTokens 粗略估算 Token 数：CJK 字符按 1 个计，其余约 4 个字符计 1 个
+func EstimateTokens(text string) int {
+	cjk, other := 0, 0
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Han, r), unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r), unicode.Is(unicode.Hangul, r):
+			cjk++
+		case unicode.IsSpace(r):
+		default:
+			other++
+		}
+	}
+	return cjk + (other+3)/4
+}
+
+// EstimateMessagesTokens 估算消息列表的 Token 数
+func EstimateMessagesTokens(messages []provider.Message) int {
+	total := 0
+	for _, m := range messages {
+		// 每条消息约有 4 个 Token 的角色与分隔开销
+		total += EstimateTokens(m.Content) + 4
+	}
+	return total
+}
+
+// JSONUsageStorage 按天 JSONL 文件存储实现
+type JSONUsageStorage struct {
+	dataDir string
+	mu      sync.Mutex
+}
+
+func NewJSONUsageStorage(dataDir string) (*JSONUsageStorage, error) {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return nil, err
+	}
+	return &JSONUsageStorage{dataDir: dataDir}, nil
+}
+
+func (s *JSONUsageStorage) Append(record *UsageRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	path := filepath.Join(s.dataDir, record.CreatedAt.Format("2006-01-02")+".jsonl")
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+func (s *JSONUsageStorage) List(filter UsageFilter) ([]*UsageRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dataDir, "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	records := make([]*UsageRecord, 0)
+	for _, file := range files {
+		// 文件名即日期，可跳过范围外的整天
+		day, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(filepath.Base(file), ".jsonl"), time.Local)
+		if err == nil {
+			if filter.StartDate != nil && day.AddDate(0, 0, 1).Before(*filter.StartDate) {
+				continue
+			}
+			if filter.EndDate != nil && day.After(*filter.EndDate) {
+				continue
+			}
+		}
+
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var r UsageRecord
+			if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+				continue
+			}
+			if filter.match(&r) {
+				records = append(records, &r)
+			}
+		}
+		f.Close()
+	}
+
+	return records, nil
+}
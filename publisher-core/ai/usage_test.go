@@ -0,0 +1,200 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/monkeycode/publisher-core/ai/provider"
+)
+
+func newTestLedger(t *testing.T) (*UsageLedger, *JSONUsageStorage) {
+	t.Helper()
+	storage, err := NewJSONUsageStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewUsageLedger(storage, nil), storage
+}
+
+func TestPriceTableCost(t *testing.T) {
+	pt := DefaultPriceTable()
+
+	cost, ok := pt.Cost("deepseek", "deepseek-chat", 1_000_000, 1_000_000)
+	if !ok || cost != 0.27+1.10 {
+		t.Errorf("deepseek-chat cost = %v, %v", cost, ok)
+	}
+	if cost, ok := pt.Cost("openrouter", "meta-llama/llama-3.3-70b-instruct:free", 1000, 1000); !ok || cost != 0 {
+		t.Errorf("free model cost = %v, %v", cost, ok)
+	}
+	if _, ok := pt.Cost("groq", "unknown-model", 1000, 1000); ok {
+		t.Error("unknown model should be unpriced")
+	}
+}
+
+func TestUsageLedgerRecord(t *testing.T) {
+	ledger, storage := newTestLedger(t)
+
+	priced := &UsageRecord{Provider: "deepseek", Model: "deepseek-chat", InputTokens: 1000, OutputTokens: 500}
+	if err := ledger.Record(priced); err != nil {
+		t.Fatal(err)
+	}
+	if priced.ID == "" || priced.CreatedAt.IsZero() || priced.Cost <= 0 || priced.Unpriced {
+		t.Errorf("priced record not filled in: %+v", priced)
+	}
+
+	unpriced := &UsageRecord{Provider: "groq", Model: "unknown-model", InputTokens: 1000, OutputTokens: 500}
+	if err := ledger.Record(unpriced); err != nil {
+		t.Fatal(err)
+	}
+	if !unpriced.Unpriced || unpriced.Cost != 0 {
+		t.Errorf("unpriced record = %+v", unpriced)
+	}
+
+	// JSON 存储往返后字段保持不变
+	records, err := storage.List(UsageFilter{Model: "unknown-model"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].ID != unpriced.ID || !records[0].Unpriced || records[0].InputTokens != 1000 {
+		t.Fatalf("stored records = %+v", records)
+	}
+
+	summary, err := ledger.Summary(UsageFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.Total.Requests != 2 || summary.Total.TotalTokens != 3000 || summary.ByProvider["groq"].Requests != 1 {
+		t.Errorf("summary = %+v", summary)
+	}
+}
+
+func TestUsageLedgerBudget(t *testing.T) {
+	ledger, storage := newTestLedger(t)
+	ledger.SetBudget(Budget{DailyTokens: 1000})
+	ledger.SetTeamBudget("运营一组", Budget{DailyTokens: 300})
+
+	record := func(team string, tokens int, at time.Time) {
+		t.Helper()
+		r := &UsageRecord{Provider: "deepseek", Model: "deepseek-chat", Team: team, InputTokens: tokens, CreatedAt: at}
+		if err := ledger.Record(r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 前一天的用量不计入今天，补记时也不会清空今天的累计
+	record("", 5000, time.Now().AddDate(0, 0, -1))
+	if err := ledger.CheckBudget("", "deepseek", "deepseek-chat"); err != nil {
+		t.Fatalf("yesterday's usage should not count: %v", err)
+	}
+
+	record("运营一组", 300, time.Now())
+	if err := ledger.CheckBudget("运营一组", "deepseek", "deepseek-chat"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("team budget err = %v, want ErrBudgetExceeded", err)
+	}
+	if err := ledger.CheckBudget("运营二组", "deepseek", "deepseek-chat"); err != nil {
+		t.Fatalf("other team should not be limited: %v", err)
+	}
+
+	record("运营二组", 700, time.Now())
+	if err := ledger.CheckBudget("运营二组", "deepseek", "deepseek-chat"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("global budget err = %v, want ErrBudgetExceeded", err)
+	}
+
+	// 新账本从存储中恢复当日累计
+	reloaded := NewUsageLedger(storage, nil)
+	reloaded.SetBudget(Budget{DailyTokens: 1000})
+	if err := reloaded.CheckBudget("", "deepseek", "deepseek-chat"); !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("reloaded ledger err = %v, want ErrBudgetExceeded", err)
+	}
+}
+
+func TestUsageLedgerUnpricedBudget(t *testing.T) {
+	ledger, _ := newTestLedger(t)
+
+	if err := ledger.CheckBudget("", "groq", "unknown-model"); err != nil {
+		t.Fatalf("no cost budget: %v", err)
+	}
+
+	ledger.SetTeamBudget("运营一组", Budget{DailyCost: 1})
+	if err := ledger.CheckBudget("运营一组", "groq", "unknown-model"); !errors.Is(err, ErrUnpricedModel) {
+		t.Fatalf("team cost budget err = %v, want ErrUnpricedModel", err)
+	}
+	if err := ledger.CheckBudget("", "groq", "unknown-model"); err != nil {
+		t.Fatalf("team budget should not apply to other calls: %v", err)
+	}
+
+	ledger.SetBudget(Budget{DailyCost: 1})
+	if err := ledger.CheckBudget("", "groq", "unknown-model"); !errors.Is(err, ErrUnpricedModel) {
+		t.Fatalf("global cost budget err = %v, want ErrUnpricedModel", err)
+	}
+	if err := ledger.CheckBudget("", "deepseek", "deepseek-chat"); err != nil {
+		t.Fatalf("priced model: %v", err)
+	}
+}
+
+// streamTestProvider 逐块输出固定内容，输出结束或 ctx 取消时关闭通道
+type streamTestProvider struct {
+	chunks []string
+}
+
+func (p *streamTestProvider) Name() provider.ProviderType { return provider.ProviderDeepSeek }
+func (p *streamTestProvider) Models() []string            { return []string{"deepseek-chat"} }
+func (p *streamTestProvider) DefaultModel() string        { return "deepseek-chat" }
+
+func (p *streamTestProvider) Generate(ctx context.Context, opts *provider.GenerateOptions) (*provider.GenerateResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (p *streamTestProvider) GenerateStream(ctx context.Context, opts *provider.GenerateOptions) (<-chan string, error) {
+	ch := make(chan string)
+	go func() {
+		defer close(ch)
+		for _, chunk := range p.chunks {
+			select {
+			case ch <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+func TestMeterStreamRecordsAbandonedStream(t *testing.T) {
+	service := NewServiceWithDefaults()
+	service.RegisterProvider(&streamTestProvider{chunks: []string{"第一段", "第二段", "第三段"}})
+	ledger, storage := newTestLedger(t)
+	service.SetUsageLedger(ledger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out, err := service.GenerateStream(ctx, &provider.GenerateOptions{
+		Messages: []provider.Message{{Role: "user", Content: "写一段文案"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 只读第一段就不再读取
+	<-out
+	cancel()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		records, err := storage.List(UsageFilter{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) == 1 {
+			if !records[0].Stream || records[0].OutputTokens == 0 {
+				t.Errorf("stream record = %+v", records[0])
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("abandoned stream was never recorded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,38 @@
+// migrate 将 JSON 文件存储的历史记录和模板迁移到 SQLite
+package main
+
+import (
+	"flag"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/monkeycode/publisher-core/ai"
+)
+
+func main() {
+	var (
+		historyDir  string
+		templateDir string
+		dbPath      string
+	)
+	flag.StringVar(&historyDir, "history-dir", "./data/history", "JSON 历史记录目录")
+	flag.StringVar(&templateDir, "template-dir", "./data/templates", "JSON 模板目录")
+	flag.StringVar(&dbPath, "db", "./data/publisher.db", "SQLite 数据库路径")
+	flag.Parse()
+
+	db, err := ai.OpenSQLite(dbPath)
+	if err != nil {
+		logrus.Fatalf("打开数据库失败: %v", err)
+	}
+	defer db.Close()
+
+	result, err := ai.MigrateJSONStorage(
+		historyDir, templateDir,
+		ai.NewSQLiteHistoryStorage(db), ai.NewSQLiteTemplateStorage(db),
+	)
+	if err != nil {
+		logrus.Fatalf("迁移失败: %v", err)
+	}
+
+	logrus.Infof("迁移完成: 历史记录 %d 条, 模板 %d 个, 失败 %d 条", result.Histories, result.Templates, result.Failed)
+}
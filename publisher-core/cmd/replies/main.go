@@ -0,0 +1,130 @@
+// replies 评论回复审批：生成回复草稿、人工审批，再通过 xiaohongshu-mcp 按节奏发送
+//
+//	replies draft -feed <id> -xsec <token> -comments comments.json -account <账号>
+//	replies list [-status pending]
+//	replies approve -id <草稿ID> [-reply <修改后的回复>]
+//	replies reject -id <草稿ID> [-note <原因>]
+//	replies send -account <账号> [-server http://localhost:18060]
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/monkeycode/publisher-core/ai"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "usage: replies <draft|list|approve|reject|send> [flags]")
+		os.Exit(2)
+	}
+
+	var (
+		queueDir   string
+		configPath string
+		voicesPath string
+	)
+	fs := flag.NewFlagSet(os.Args[1], flag.ExitOnError)
+	fs.StringVar(&queueDir, "queue", "./data/replies", "审批队列目录")
+	fs.StringVar(&configPath, "config", ai.DefaultConfigPath(), "AI 配置文件路径")
+	fs.StringVar(&voicesPath, "voices", "", "账号回复口吻配置（BrandVoice JSON 数组）")
+
+	var (
+		account, team, platform string
+		feedID, xsecToken       string
+		noteTitle, commentsPath string
+		limit                   int
+		status, id, reply, note string
+		server                  string
+		maxPerRun               int
+	)
+	fs.StringVar(&account, "account", "", "账号")
+	fs.StringVar(&team, "team", "", "用量统计所属团队")
+	fs.StringVar(&platform, "platform", "xiaohongshu", "平台")
+	fs.StringVar(&feedID, "feed", "", "笔记ID")
+	fs.StringVar(&xsecToken, "xsec", "", "笔记的 xsec_token")
+	fs.StringVar(&noteTitle, "title", "", "笔记标题")
+	fs.StringVar(&commentsPath, "comments", "", "评论列表 JSON 文件（get_feed_detail 返回的 comments）")
+	fs.IntVar(&limit, "limit", 0, "最多生成的草稿数或列出的条数")
+	fs.StringVar(&status, "status", "", "按状态过滤")
+	fs.StringVar(&id, "id", "", "草稿ID")
+	fs.StringVar(&reply, "reply", "", "人工修改后的回复")
+	fs.StringVar(&note, "note", "", "拒绝原因")
+	fs.StringVar(&server, "server", "http://localhost:18060", "xiaohongshu-mcp 服务地址")
+	fs.IntVar(&maxPerRun, "max", ai.DefaultPacing().MaxPerRun, "单次最多发送条数")
+	_ = fs.Parse(os.Args[2:])
+
+	storage, err := ai.NewJSONReplyQueueStorage(queueDir)
+	if err != nil {
+		logrus.Fatalf("打开审批队列失败: %v", err)
+	}
+	service, err := ai.NewService(configPath)
+	if err != nil {
+		logrus.Fatalf("初始化 AI 服务失败: %v", err)
+	}
+	assistant := ai.NewReplyAssistant(service, storage)
+	if voicesPath != "" {
+		if err := assistant.LoadBrandVoices(voicesPath); err != nil {
+			logrus.Fatalf("加载回复口吻失败: %v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var result any
+	switch os.Args[1] {
+	case "draft":
+		var comments ai.FeedCommentList
+		data, err := os.ReadFile(commentsPath)
+		if err == nil {
+			err = json.Unmarshal(data, &comments)
+		}
+		if err != nil {
+			logrus.Fatalf("读取评论列表失败: %v", err)
+		}
+		result, err = assistant.DraftReplies(ctx, &ai.DraftRepliesRequest{
+			Account:   account,
+			Team:      team,
+			Platform:  platform,
+			FeedID:    feedID,
+			XsecToken: xsecToken,
+			NoteTitle: noteTitle,
+			Comments:  comments,
+			Limit:     limit,
+		})
+		exitOnError(err)
+	case "list":
+		result, err = assistant.List(ai.ReplyFilter{Account: account, FeedID: feedID, Status: status, Limit: limit})
+		exitOnError(err)
+	case "approve":
+		result, err = assistant.Approve(id, reply)
+		exitOnError(err)
+	case "reject":
+		result, err = assistant.Reject(id, note)
+		exitOnError(err)
+	case "send":
+		pacing := ai.DefaultPacing()
+		pacing.MaxPerRun = maxPerRun
+		result, err = assistant.SendApproved(ctx, ai.NewHTTPReplySender(server), account, pacing)
+		exitOnError(err)
+	default:
+		logrus.Fatalf("未知命令: %s", os.Args[1])
+	}
+
+	out, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(out))
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		logrus.Fatal(err)
+	}
+}